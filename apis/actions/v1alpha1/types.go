@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// SelfHostedRunnerParameters define the desired state of a self-hosted GitHub
+// Actions runner.
+type SelfHostedRunnerParameters struct {
+	// Organization the runner is registered with.
+	Organization string `json:"organization"`
+
+	// Repository the runner is registered with. When omitted the runner is
+	// registered at the organization level.
+	// +optional
+	Repository *string `json:"repository,omitempty"`
+
+	// Name the runner registers itself with, i.e. the --name passed to the
+	// runner's config script. It is used to find the runner once registered.
+	Name string `json:"name"`
+}
+
+// SelfHostedRunnerSpec defines the desired state of a SelfHostedRunner.
+type SelfHostedRunnerSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SelfHostedRunnerParameters `json:"forProvider"`
+}
+
+// SelfHostedRunnerObservation is the representation of the current state that
+// is observed.
+type SelfHostedRunnerObservation struct {
+	// ID of the runner, once it has registered itself.
+	ID *int64 `json:"id,omitempty"`
+
+	// OS the runner is running on.
+	OS *string `json:"os,omitempty"`
+
+	// Status of the runner. Possible values are: "online", "offline"
+	Status *string `json:"status,omitempty"`
+
+	// Busy is true while the runner is executing a job.
+	Busy *bool `json:"busy,omitempty"`
+
+	// Labels assigned to the runner.
+	Labels []string `json:"labels,omitempty"`
+
+	// RegistrationTokenExpiresAt is when the registration token last written
+	// to the connection secret expires. A new token is issued if the runner
+	// has not registered by then.
+	RegistrationTokenExpiresAt *metav1.Time `json:"registrationTokenExpiresAt,omitempty"`
+}
+
+// SelfHostedRunnerStatus represents the observed state of a SelfHostedRunner.
+type SelfHostedRunnerStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SelfHostedRunnerObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SelfHostedRunner is a managed resource that represents a self-hosted
+// GitHub Actions runner. Creating it issues a registration token, written to
+// the connection secret under the "token" key, that the runner uses to
+// register itself. Deleting it deregisters the runner.
+// +kubebuilder:printcolumn:name="ID",type="integer",JSONPath=".status.atProvider.id"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type SelfHostedRunner struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SelfHostedRunnerSpec   `json:"spec"`
+	Status SelfHostedRunnerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SelfHostedRunnerList contains a list of SelfHostedRunner
+type SelfHostedRunnerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SelfHostedRunner `json:"items"`
+}
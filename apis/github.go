@@ -20,7 +20,11 @@ package apis
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 
+	actionsv1alpha1 "github.com/crossplane-contrib/provider-github/apis/actions/v1alpha1"
 	organizationsv1alpha1 "github.com/crossplane-contrib/provider-github/apis/organizations/v1alpha1"
+	repositoriesv1alpha1 "github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	secretsv1alpha1 "github.com/crossplane-contrib/provider-github/apis/secrets/v1alpha1"
+	teamsv1alpha1 "github.com/crossplane-contrib/provider-github/apis/teams/v1alpha1"
 	v1beta1 "github.com/crossplane-contrib/provider-github/apis/v1beta1"
 )
 
@@ -29,6 +33,10 @@ func init() {
 	AddToSchemes = append(AddToSchemes,
 		v1beta1.SchemeBuilder.AddToScheme,
 		organizationsv1alpha1.SchemeBuilder.AddToScheme,
+		actionsv1alpha1.SchemeBuilder.AddToScheme,
+		repositoriesv1alpha1.SchemeBuilder.AddToScheme,
+		teamsv1alpha1.SchemeBuilder.AddToScheme,
+		secretsv1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
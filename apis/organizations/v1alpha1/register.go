@@ -45,6 +45,33 @@ var (
 	MembershipGroupVersionKind = SchemeGroupVersion.WithKind(MembershipKind)
 )
 
+// EnterpriseMembership type metadata.
+var (
+	EnterpriseMembershipKind             = reflect.TypeOf(EnterpriseMembership{}).Name()
+	EnterpriseMembershipGroupKind        = schema.GroupKind{Group: Group, Kind: EnterpriseMembershipKind}.String()
+	EnterpriseMembershipKindAPIVersion   = EnterpriseMembershipKind + "." + SchemeGroupVersion.String()
+	EnterpriseMembershipGroupVersionKind = SchemeGroupVersion.WithKind(EnterpriseMembershipKind)
+)
+
+// OrganizationMembers type metadata.
+var (
+	OrganizationMembersKind             = reflect.TypeOf(OrganizationMembers{}).Name()
+	OrganizationMembersGroupKind        = schema.GroupKind{Group: Group, Kind: OrganizationMembersKind}.String()
+	OrganizationMembersKindAPIVersion   = OrganizationMembersKind + "." + SchemeGroupVersion.String()
+	OrganizationMembersGroupVersionKind = SchemeGroupVersion.WithKind(OrganizationMembersKind)
+)
+
+// OrgCustomRepoRole type metadata.
+var (
+	OrgCustomRepoRoleKind             = reflect.TypeOf(OrgCustomRepoRole{}).Name()
+	OrgCustomRepoRoleGroupKind        = schema.GroupKind{Group: Group, Kind: OrgCustomRepoRoleKind}.String()
+	OrgCustomRepoRoleKindAPIVersion   = OrgCustomRepoRoleKind + "." + SchemeGroupVersion.String()
+	OrgCustomRepoRoleGroupVersionKind = SchemeGroupVersion.WithKind(OrgCustomRepoRoleKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&Membership{}, &MembershipList{})
+	SchemeBuilder.Register(&EnterpriseMembership{}, &EnterpriseMembershipList{})
+	SchemeBuilder.Register(&OrganizationMembers{}, &OrganizationMembersList{})
+	SchemeBuilder.Register(&OrgCustomRepoRole{}, &OrgCustomRepoRoleList{})
 }
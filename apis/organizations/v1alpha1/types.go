@@ -66,6 +66,10 @@ type MembershipObservation struct {
 	// Possible values are: "active", "pending"
 	State *string `json:"state,omitempty"`
 
+	// Role is the user's role within the organization.
+	// Possible values are: "admin", "member", "billing_manager"
+	Role *string `json:"role,omitempty"`
+
 	// TODO(hasheddan): User and Organization are omitted here because they are
 	// overly verbose.
 }
@@ -101,3 +105,257 @@ type MembershipList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []Membership `json:"items"`
 }
+
+// EnterpriseMembershipParameters define the desired membership of a user
+// across a set of organizations belonging to a GitHub Enterprise account.
+type EnterpriseMembershipParameters struct {
+	// User is the username of the github user.
+	User string `json:"user"`
+
+	// Organizations the user should be a member of. The user is removed from
+	// organizations that are dropped from this list. Every organization must
+	// be on the GitHub Enterprise plan.
+	// +kubebuilder:validation:MinItems=1
+	Organizations []string `json:"organizations"`
+
+	// Role the user should have in each organization. Can be one of:
+	// * admin - Organization owner.
+	// * member - Non-owner organization member.
+	// Default is "member".
+	// +kubebuilder:validation:Enum=admin;member
+	// +optional
+	Role *string `json:"role,omitempty"`
+}
+
+// EnterpriseMembershipSpec defines the desired state of an
+// EnterpriseMembership.
+type EnterpriseMembershipSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       EnterpriseMembershipParameters `json:"forProvider"`
+}
+
+// OrganizationMembershipObservation is the observed membership of the user in
+// a single organization.
+type OrganizationMembershipObservation struct {
+	// Organization the membership belongs to.
+	Organization string `json:"organization"`
+
+	// State is the user's status within the organization.
+	// Possible values are: "active", "pending"
+	State *string `json:"state,omitempty"`
+
+	// Role the user has in the organization.
+	Role *string `json:"role,omitempty"`
+}
+
+// EnterpriseMembershipObservation is the representation of the current state
+// that is observed.
+type EnterpriseMembershipObservation struct {
+	// Memberships the user currently has in the managed organizations,
+	// including organizations that were removed from the spec but that the
+	// user has not yet been removed from.
+	Memberships []OrganizationMembershipObservation `json:"memberships,omitempty"`
+}
+
+// EnterpriseMembershipStatus represents the observed state of an
+// EnterpriseMembership.
+type EnterpriseMembershipStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          EnterpriseMembershipObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// An EnterpriseMembership is a managed resource that represents a user's
+// membership across several organizations of a GitHub Enterprise account.
+// +kubebuilder:printcolumn:name="USER",type="string",JSONPath=".spec.forProvider.user"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type EnterpriseMembership struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EnterpriseMembershipSpec   `json:"spec"`
+	Status EnterpriseMembershipStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EnterpriseMembershipList contains a list of EnterpriseMembership
+type EnterpriseMembershipList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EnterpriseMembership `json:"items"`
+}
+
+// OrganizationMembersParameters define the full list of members of an
+// organization.
+type OrganizationMembersParameters struct {
+	// Organization whose members are managed.
+	Organization string `json:"organization"`
+
+	// Members are the usernames of the users that should be members of the
+	// organization. Users that are not yet members are invited.
+	Members []string `json:"members"`
+
+	// RemoveUnlisted removes members of the organization that are not listed
+	// in Members. By default unlisted members are only reported in the
+	// status.
+	// +optional
+	RemoveUnlisted *bool `json:"removeUnlisted,omitempty"`
+
+	// AllowOwnerRemoval allows unlisted organization owners to be removed
+	// when RemoveUnlisted is true. By default owners are never removed.
+	// +optional
+	AllowOwnerRemoval *bool `json:"allowOwnerRemoval,omitempty"`
+
+	// MaxRemovals is the most members that may be removed in a single
+	// reconcile. If more members would be removed nothing is removed and an
+	// error is reported instead, which guards against an incomplete list
+	// emptying the organization. Defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRemovals *int `json:"maxRemovals,omitempty"`
+}
+
+// OrganizationMembersSpec defines the desired state of an
+// OrganizationMembers.
+type OrganizationMembersSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OrganizationMembersParameters `json:"forProvider"`
+}
+
+// OrganizationMembersObservation is the representation of the current state
+// that is observed.
+type OrganizationMembersObservation struct {
+	// Members of the organization.
+	Members []string `json:"members,omitempty"`
+
+	// Pending are the users with a pending invitation to the organization.
+	Pending []string `json:"pending,omitempty"`
+
+	// Missing are the listed users that are neither members of the
+	// organization nor invited to it.
+	Missing []string `json:"missing,omitempty"`
+
+	// Unlisted are the members of the organization that are not listed.
+	Unlisted []string `json:"unlisted,omitempty"`
+}
+
+// OrganizationMembersStatus represents the observed state of an
+// OrganizationMembers.
+type OrganizationMembersStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          OrganizationMembersObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OrganizationMembers is a managed resource that represents the full list
+// of members of a GitHub organization. Deleting it leaves the organization's
+// members untouched.
+// +kubebuilder:printcolumn:name="ORGANIZATION",type="string",JSONPath=".spec.forProvider.organization"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type OrganizationMembers struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrganizationMembersSpec   `json:"spec"`
+	Status OrganizationMembersStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrganizationMembersList contains a list of OrganizationMembers
+type OrganizationMembersList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrganizationMembers `json:"items"`
+}
+
+// OrgCustomRepoRoleParameters define the desired state of a custom
+// repository role of a GitHub organization.
+type OrgCustomRepoRoleParameters struct {
+	// Organization the role belongs to.
+	Organization string `json:"organization"`
+
+	// Name of the role.
+	Name string `json:"name"`
+
+	// Description of the role.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// BaseRole is the built-in role the custom role extends. Can be one of
+	// read, triage, write or maintain.
+	// +kubebuilder:validation:Enum=read;triage;write;maintain
+	BaseRole string `json:"baseRole"`
+
+	// Permissions the role grants on top of its base role, for example
+	// "delete_alerts_code_scanning".
+	// +kubebuilder:validation:MinItems=1
+	Permissions []string `json:"permissions"`
+}
+
+// OrgCustomRepoRoleSpec defines the desired state of an OrgCustomRepoRole.
+type OrgCustomRepoRoleSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OrgCustomRepoRoleParameters `json:"forProvider"`
+}
+
+// OrgCustomRepoRoleObservation is the representation of the current state
+// that is observed.
+type OrgCustomRepoRoleObservation struct {
+	// ID of the role.
+	ID *int64 `json:"id,omitempty"`
+
+	// BaseRole the role extends.
+	BaseRole *string `json:"baseRole,omitempty"`
+
+	// Permissions the role grants on top of its base role.
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// OrgCustomRepoRoleStatus represents the observed state of an
+// OrgCustomRepoRole.
+type OrgCustomRepoRoleStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          OrgCustomRepoRoleObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OrgCustomRepoRole is a managed resource that represents a custom
+// repository role of a GitHub organization. Custom roles require GitHub
+// Enterprise Cloud. Its external name is the ID of the role, which is
+// assigned when the role is created.
+// +kubebuilder:printcolumn:name="ORGANIZATION",type="string",JSONPath=".spec.forProvider.organization"
+// +kubebuilder:printcolumn:name="BASE-ROLE",type="string",JSONPath=".spec.forProvider.baseRole"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type OrgCustomRepoRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrgCustomRepoRoleSpec   `json:"spec"`
+	Status OrgCustomRepoRoleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrgCustomRepoRoleList contains a list of OrgCustomRepoRole
+type OrgCustomRepoRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrgCustomRepoRole `json:"items"`
+}
@@ -24,6 +24,146 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnterpriseMembership) DeepCopyInto(out *EnterpriseMembership) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnterpriseMembership.
+func (in *EnterpriseMembership) DeepCopy() *EnterpriseMembership {
+	if in == nil {
+		return nil
+	}
+	out := new(EnterpriseMembership)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EnterpriseMembership) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnterpriseMembershipList) DeepCopyInto(out *EnterpriseMembershipList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EnterpriseMembership, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnterpriseMembershipList.
+func (in *EnterpriseMembershipList) DeepCopy() *EnterpriseMembershipList {
+	if in == nil {
+		return nil
+	}
+	out := new(EnterpriseMembershipList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EnterpriseMembershipList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnterpriseMembershipObservation) DeepCopyInto(out *EnterpriseMembershipObservation) {
+	*out = *in
+	if in.Memberships != nil {
+		in, out := &in.Memberships, &out.Memberships
+		*out = make([]OrganizationMembershipObservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnterpriseMembershipObservation.
+func (in *EnterpriseMembershipObservation) DeepCopy() *EnterpriseMembershipObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(EnterpriseMembershipObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnterpriseMembershipParameters) DeepCopyInto(out *EnterpriseMembershipParameters) {
+	*out = *in
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnterpriseMembershipParameters.
+func (in *EnterpriseMembershipParameters) DeepCopy() *EnterpriseMembershipParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(EnterpriseMembershipParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnterpriseMembershipSpec) DeepCopyInto(out *EnterpriseMembershipSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnterpriseMembershipSpec.
+func (in *EnterpriseMembershipSpec) DeepCopy() *EnterpriseMembershipSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EnterpriseMembershipSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnterpriseMembershipStatus) DeepCopyInto(out *EnterpriseMembershipStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnterpriseMembershipStatus.
+func (in *EnterpriseMembershipStatus) DeepCopy() *EnterpriseMembershipStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EnterpriseMembershipStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Membership) DeepCopyInto(out *Membership) {
 	*out = *in
@@ -96,6 +236,11 @@ func (in *MembershipObservation) DeepCopyInto(out *MembershipObservation) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MembershipObservation.
@@ -171,3 +316,339 @@ func (in *MembershipStatus) DeepCopy() *MembershipStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgCustomRepoRole) DeepCopyInto(out *OrgCustomRepoRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgCustomRepoRole.
+func (in *OrgCustomRepoRole) DeepCopy() *OrgCustomRepoRole {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgCustomRepoRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrgCustomRepoRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgCustomRepoRoleList) DeepCopyInto(out *OrgCustomRepoRoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OrgCustomRepoRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgCustomRepoRoleList.
+func (in *OrgCustomRepoRoleList) DeepCopy() *OrgCustomRepoRoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgCustomRepoRoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrgCustomRepoRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgCustomRepoRoleObservation) DeepCopyInto(out *OrgCustomRepoRoleObservation) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BaseRole != nil {
+		in, out := &in.BaseRole, &out.BaseRole
+		*out = new(string)
+		**out = **in
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgCustomRepoRoleObservation.
+func (in *OrgCustomRepoRoleObservation) DeepCopy() *OrgCustomRepoRoleObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgCustomRepoRoleObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgCustomRepoRoleParameters) DeepCopyInto(out *OrgCustomRepoRoleParameters) {
+	*out = *in
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgCustomRepoRoleParameters.
+func (in *OrgCustomRepoRoleParameters) DeepCopy() *OrgCustomRepoRoleParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgCustomRepoRoleParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgCustomRepoRoleSpec) DeepCopyInto(out *OrgCustomRepoRoleSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgCustomRepoRoleSpec.
+func (in *OrgCustomRepoRoleSpec) DeepCopy() *OrgCustomRepoRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgCustomRepoRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgCustomRepoRoleStatus) DeepCopyInto(out *OrgCustomRepoRoleStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgCustomRepoRoleStatus.
+func (in *OrgCustomRepoRoleStatus) DeepCopy() *OrgCustomRepoRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgCustomRepoRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMembers) DeepCopyInto(out *OrganizationMembers) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMembers.
+func (in *OrganizationMembers) DeepCopy() *OrganizationMembers {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMembers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationMembers) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMembersList) DeepCopyInto(out *OrganizationMembersList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OrganizationMembers, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMembersList.
+func (in *OrganizationMembersList) DeepCopy() *OrganizationMembersList {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMembersList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationMembersList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMembersObservation) DeepCopyInto(out *OrganizationMembersObservation) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pending != nil {
+		in, out := &in.Pending, &out.Pending
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Missing != nil {
+		in, out := &in.Missing, &out.Missing
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Unlisted != nil {
+		in, out := &in.Unlisted, &out.Unlisted
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMembersObservation.
+func (in *OrganizationMembersObservation) DeepCopy() *OrganizationMembersObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMembersObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMembersParameters) DeepCopyInto(out *OrganizationMembersParameters) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemoveUnlisted != nil {
+		in, out := &in.RemoveUnlisted, &out.RemoveUnlisted
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowOwnerRemoval != nil {
+		in, out := &in.AllowOwnerRemoval, &out.AllowOwnerRemoval
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxRemovals != nil {
+		in, out := &in.MaxRemovals, &out.MaxRemovals
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMembersParameters.
+func (in *OrganizationMembersParameters) DeepCopy() *OrganizationMembersParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMembersParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMembersSpec) DeepCopyInto(out *OrganizationMembersSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMembersSpec.
+func (in *OrganizationMembersSpec) DeepCopy() *OrganizationMembersSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMembersSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMembersStatus) DeepCopyInto(out *OrganizationMembersStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMembersStatus.
+func (in *OrganizationMembersStatus) DeepCopy() *OrganizationMembersStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMembersStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationMembershipObservation) DeepCopyInto(out *OrganizationMembershipObservation) {
+	*out = *in
+	if in.State != nil {
+		in, out := &in.State, &out.State
+		*out = new(string)
+		**out = **in
+	}
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationMembershipObservation.
+func (in *OrganizationMembershipObservation) DeepCopy() *OrganizationMembershipObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationMembershipObservation)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// BranchProtectionParameters define the desired protection of a branch.
+type BranchProtectionParameters struct {
+	// Owner of the repository.
+	Owner string `json:"owner"`
+
+	// Repository containing the branch.
+	Repository string `json:"repository"`
+
+	// Branch to protect.
+	Branch string `json:"branch"`
+
+	// RequiredStatusChecks that must pass before merging. Status checks are
+	// not required when omitted. Do not also manage the branch's status
+	// checks with a RequiredStatusChecks resource.
+	// +optional
+	RequiredStatusChecks *StatusChecks `json:"requiredStatusChecks,omitempty"`
+
+	// RequiredPullRequestReviews before merging. Reviews are not required
+	// when omitted.
+	// +optional
+	RequiredPullRequestReviews *PullRequestReviews `json:"requiredPullRequestReviews,omitempty"`
+
+	// EnforceAdmins applies the protection to repository administrators too.
+	// +optional
+	EnforceAdmins bool `json:"enforceAdmins,omitempty"`
+
+	// Restrictions limit who can push to the branch. Anyone with write
+	// access can push when omitted. Only available for organization owned
+	// repositories.
+	// +optional
+	Restrictions *BranchRestrictions `json:"restrictions,omitempty"`
+
+	// RequireLinearHistory prevents merge commits from being pushed to the
+	// branch.
+	// +optional
+	RequireLinearHistory *bool `json:"requireLinearHistory,omitempty"`
+
+	// AllowForcePushes permits force pushes by anyone with write access.
+	// +optional
+	AllowForcePushes *bool `json:"allowForcePushes,omitempty"`
+
+	// AllowDeletions permits deletion of the branch by anyone with write
+	// access.
+	// +optional
+	AllowDeletions *bool `json:"allowDeletions,omitempty"`
+}
+
+// StatusChecks that must pass before merging.
+type StatusChecks struct {
+	// Strict requires branches to be up to date with the base branch before
+	// merging.
+	// +optional
+	Strict bool `json:"strict,omitempty"`
+
+	// Contexts are the names of the status checks that must pass. Order is
+	// not significant.
+	Contexts []string `json:"contexts"`
+}
+
+// PullRequestReviews required before merging.
+type PullRequestReviews struct {
+	// DismissalRestrictions limit who can dismiss reviews. Anyone with write
+	// access can dismiss reviews when omitted, or when no users or teams are
+	// listed.
+	// +optional
+	DismissalRestrictions *DismissalRestrictions `json:"dismissalRestrictions,omitempty"`
+
+	// DismissStaleReviews dismisses approving reviews when new commits are
+	// pushed.
+	// +optional
+	DismissStaleReviews bool `json:"dismissStaleReviews,omitempty"`
+
+	// RequireCodeOwnerReviews requires an approving review from a code owner
+	// of the changed files.
+	// +optional
+	RequireCodeOwnerReviews bool `json:"requireCodeOwnerReviews,omitempty"`
+
+	// RequiredApprovingReviewCount is the number of approving reviews
+	// required.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=6
+	RequiredApprovingReviewCount int `json:"requiredApprovingReviewCount"`
+}
+
+// DismissalRestrictions list who can dismiss pull request reviews.
+type DismissalRestrictions struct {
+	// Users who can dismiss reviews.
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// Teams, identified by slug, who can dismiss reviews.
+	// +optional
+	Teams []string `json:"teams,omitempty"`
+}
+
+// BranchRestrictions list who can push to a branch.
+type BranchRestrictions struct {
+	// Users who can push.
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// Teams, identified by slug, who can push.
+	// +optional
+	Teams []string `json:"teams,omitempty"`
+
+	// Apps, identified by slug, that can push.
+	// +optional
+	Apps []string `json:"apps,omitempty"`
+}
+
+// BranchProtectionSpec defines the desired state of a BranchProtection.
+type BranchProtectionSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       BranchProtectionParameters `json:"forProvider"`
+}
+
+// BranchProtectionObservation is the representation of the current state
+// that is observed.
+type BranchProtectionObservation struct {
+	// EnforceAdmins is true if the protection applies to administrators.
+	EnforceAdmins bool `json:"enforceAdmins,omitempty"`
+
+	// RequiredStatusChecks currently required to pass before merging.
+	RequiredStatusChecks []string `json:"requiredStatusChecks,omitempty"`
+
+	// RequiredApprovingReviewCount currently required before merging.
+	RequiredApprovingReviewCount *int `json:"requiredApprovingReviewCount,omitempty"`
+}
+
+// BranchProtectionStatus represents the observed state of a
+// BranchProtection.
+type BranchProtectionStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          BranchProtectionObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// A BranchProtection is a managed resource that represents the protection of
+// a repository branch.
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="BRANCH",type="string",JSONPath=".spec.forProvider.branch"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type BranchProtection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BranchProtectionSpec   `json:"spec"`
+	Status BranchProtectionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BranchProtectionList contains a list of BranchProtection
+type BranchProtectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BranchProtection `json:"items"`
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// CollaboratorParameters define the desired access of a user to a repository.
+type CollaboratorParameters struct {
+	// Owner of the repository.
+	Owner string `json:"owner"`
+
+	// Repository the user collaborates on.
+	Repository string `json:"repository"`
+
+	// User is the username of the collaborator.
+	User string `json:"user"`
+
+	// Permission granted to the user. Can be one of pull, triage, push,
+	// maintain or admin. GitHub only reports whether a collaborator can
+	// read, write or administer the repository, so changing the permission
+	// between pull and triage, or between push and maintain, is not
+	// detected. Users that inherit a higher permission, for example as
+	// owners of the organization that owns the repository, keep it, and
+	// their access is reported by status.atProvider.inheritedPermission.
+	// Defaults to push.
+	// +kubebuilder:validation:Enum=pull;triage;push;maintain;admin
+	// +optional
+	Permission *string `json:"permission,omitempty"`
+}
+
+// CollaboratorSpec defines the desired state of a Collaborator.
+type CollaboratorSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CollaboratorParameters `json:"forProvider"`
+}
+
+// CollaboratorObservation is the representation of the current state that is
+// observed.
+type CollaboratorObservation struct {
+	// State of the collaborator. Possible values are: "active", "pending"
+	State string `json:"state,omitempty"`
+
+	// Permission the collaborator has, or is invited with. Possible values
+	// are: "admin", "maintain", "push", "triage", "pull", or "write" and
+	// "read" when GitHub reports only the permission level of a collaborator.
+	Permission string `json:"permission,omitempty"`
+
+	// InheritedPermission is set to Permission when the user has a higher
+	// permission than the one granted to them as a collaborator, because
+	// they inherit it from the organization or a team. GitHub grants users
+	// the higher of the two, so the user keeps the inherited permission.
+	InheritedPermission string `json:"inheritedPermission,omitempty"`
+
+	// InvitationID is the ID of the pending invitation of the user.
+	InvitationID *int64 `json:"invitationId,omitempty"`
+}
+
+// CollaboratorStatus represents the observed state of a Collaborator.
+type CollaboratorStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CollaboratorObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Collaborator is a managed resource that represents a user's access to a
+// repository. Users that are not yet collaborators are invited, and the
+// Collaborator is not available until they accept the invitation.
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="USER",type="string",JSONPath=".spec.forProvider.user"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type Collaborator struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CollaboratorSpec   `json:"spec"`
+	Status CollaboratorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CollaboratorList contains a list of Collaborator
+type CollaboratorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Collaborator `json:"items"`
+}
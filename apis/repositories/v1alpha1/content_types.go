@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ContentParameters define the desired state of a file in a repository.
+type ContentParameters struct {
+	// Owner of the repository.
+	Owner string `json:"owner"`
+
+	// Repository containing the file.
+	Repository string `json:"repository"`
+
+	// Path of the file within the repository.
+	Path string `json:"path"`
+
+	// Branch the file is committed to. Defaults to the repository's default
+	// branch.
+	// +optional
+	Branch *string `json:"branch,omitempty"`
+
+	// Content of the file. It is ignored when Template is set.
+	// +optional
+	Content string `json:"content,omitempty"`
+
+	// Template the content of the file is generated from, so that files
+	// such as LICENSE and .gitignore can be kept in line with GitHub's
+	// templates after a repository has been created.
+	// +optional
+	Template *ContentTemplate `json:"template,omitempty"`
+
+	// Message of the commits that create, update and delete the file.
+	Message string `json:"message"`
+
+	// SkipValidation commits the file without validating it. By default
+	// known files, such as .github/FUNDING.yml, SECURITY.md and workflows in
+	// .github/workflows, are validated before they are committed.
+	// +optional
+	SkipValidation *bool `json:"skipValidation,omitempty"`
+}
+
+// ContentTemplate identifies a GitHub license or .gitignore template. Exactly
+// one of License and Gitignore must be set.
+type ContentTemplate struct {
+	// License is the key of a license template, for example "mit" or
+	// "apache-2.0".
+	// +optional
+	License *string `json:"license,omitempty"`
+
+	// Gitignore is the name of a .gitignore template, for example "Go".
+	// +optional
+	Gitignore *string `json:"gitignore,omitempty"`
+
+	// Replacements fill in the bracketed placeholders of the template. Keys
+	// are placeholder names without brackets, for example "year" or
+	// "fullname" for most license templates.
+	// +optional
+	Replacements map[string]string `json:"replacements,omitempty"`
+}
+
+// ContentSpec defines the desired state of a Content.
+type ContentSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ContentParameters `json:"forProvider"`
+}
+
+// ContentObservation is the representation of the current state that is
+// observed.
+type ContentObservation struct {
+	// URL of the file in the GitHub API.
+	URL *string `json:"url,omitempty"`
+
+	// HTMLURL of the file on GitHub.
+	HTMLURL *string `json:"htmlUrl,omitempty"`
+
+	// SHA of the file's blob. It is required to update or delete the file.
+	SHA *string `json:"sha,omitempty"`
+}
+
+// ContentStatus represents the observed state of a Content.
+type ContentStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ContentObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Content is a managed resource that represents a file in a repository.
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="PATH",type="string",JSONPath=".spec.forProvider.path"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type Content struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ContentSpec   `json:"spec"`
+	Status ContentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ContentList contains a list of Content
+type ContentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Content `json:"items"`
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// DeployKeyParameters define the desired state of a repository deploy key.
+type DeployKeyParameters struct {
+	// Owner of the repository.
+	Owner string `json:"owner"`
+
+	// Repository the deploy key grants access to.
+	Repository string `json:"repository"`
+
+	// Title of the deploy key.
+	Title string `json:"title"`
+
+	// Key is the public SSH key, for example "ssh-ed25519 AAAA...". Either
+	// Key or KeySecretRef must be set.
+	// +optional
+	Key *string `json:"key,omitempty"`
+
+	// KeySecretRef references a secret containing the public SSH key. Either
+	// Key or KeySecretRef must be set.
+	// +optional
+	KeySecretRef *xpv1.SecretKeySelector `json:"keySecretRef,omitempty"`
+
+	// ReadOnly deploy keys can only pull from the repository. Defaults to
+	// true.
+	// +optional
+	ReadOnly *bool `json:"readOnly,omitempty"`
+}
+
+// DeployKeySpec defines the desired state of a DeployKey.
+type DeployKeySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DeployKeyParameters `json:"forProvider"`
+}
+
+// DeployKeyObservation is the representation of the current state that is
+// observed.
+type DeployKeyObservation struct {
+	// ID of the deploy key.
+	ID *int64 `json:"id,omitempty"`
+
+	// URL of the deploy key in the GitHub API.
+	URL *string `json:"url,omitempty"`
+
+	// CreatedAt is the time the deploy key was created.
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+}
+
+// DeployKeyStatus represents the observed state of a DeployKey.
+type DeployKeyStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DeployKeyObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DeployKey is a managed resource that represents an SSH key granting
+// access to a single repository. Its external name is the ID of the deploy
+// key. Deploy keys cannot be edited, so changing the title, the key or
+// whether the key is read only replaces the deploy key with a new one, which
+// gets a new ID. The replacement happens in place during an update: the old
+// key is deleted, the new one created, and the resource is written back
+// immediately to record the new ID as its external name, rather than
+// deleting and recreating the resource itself.
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="ID",type="integer",JSONPath=".status.atProvider.id"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type DeployKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DeployKeySpec   `json:"spec"`
+	Status DeployKeyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DeployKeyList contains a list of DeployKey
+type DeployKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeployKey `json:"items"`
+}
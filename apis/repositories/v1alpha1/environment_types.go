@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// EnvironmentParameters define the desired state of a deployment
+// environment.
+type EnvironmentParameters struct {
+	// Owner of the repository.
+	Owner string `json:"owner"`
+
+	// Repository the environment belongs to.
+	Repository string `json:"repository"`
+
+	// WaitTimer is how many minutes a job referencing the environment waits
+	// before it runs.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=43200
+	// +optional
+	WaitTimer *int `json:"waitTimer,omitempty"`
+
+	// Reviewers that may approve jobs referencing the environment. Order is
+	// not significant. At most six reviewers may be set.
+	// +kubebuilder:validation:MaxItems=6
+	// +optional
+	Reviewers []EnvironmentReviewer `json:"reviewers,omitempty"`
+
+	// DeploymentBranchPolicy limits the branches that can deploy to the
+	// environment. When it is omitted, the environment's current policy is
+	// late initialized. To remove a policy, so that any branch can deploy,
+	// set both of its fields to false.
+	// +optional
+	DeploymentBranchPolicy *DeploymentBranchPolicy `json:"deploymentBranchPolicy,omitempty"`
+}
+
+// An EnvironmentReviewer is a user or team that may approve jobs.
+type EnvironmentReviewer struct {
+	// Type of the reviewer.
+	// +kubebuilder:validation:Enum=User;Team
+	Type string `json:"type"`
+
+	// ID of the user or team.
+	ID int64 `json:"id"`
+}
+
+// DeploymentBranchPolicy limits the branches that can deploy to an
+// environment. At most one of ProtectedBranches and CustomBranchPolicies may
+// be true. When both are false the environment has no policy and any branch
+// can deploy.
+type DeploymentBranchPolicy struct {
+	// ProtectedBranches allows only branches with branch protection rules
+	// to deploy.
+	ProtectedBranches bool `json:"protectedBranches"`
+
+	// CustomBranchPolicies allows only branches matching the name patterns
+	// configured for the environment on GitHub to deploy.
+	CustomBranchPolicies bool `json:"customBranchPolicies"`
+}
+
+// EnvironmentSpec defines the desired state of an Environment.
+type EnvironmentSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       EnvironmentParameters `json:"forProvider"`
+}
+
+// EnvironmentObservation is the representation of the current state that is
+// observed.
+type EnvironmentObservation struct {
+	// ID of the environment.
+	ID *int64 `json:"id,omitempty"`
+
+	// NodeID of the environment in the GraphQL API.
+	NodeID *string `json:"nodeId,omitempty"`
+
+	// URL of the environment in the GitHub API.
+	URL *string `json:"url,omitempty"`
+
+	// HTMLURL of the environment on GitHub.
+	HTMLURL *string `json:"htmlUrl,omitempty"`
+}
+
+// EnvironmentStatus represents the observed state of an Environment.
+type EnvironmentStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          EnvironmentObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// An Environment is a managed resource that represents a GitHub Actions
+// deployment environment of a repository. Its external name is the name of
+// the environment.
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type Environment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EnvironmentSpec   `json:"spec"`
+	Status EnvironmentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EnvironmentList contains a list of Environment
+type EnvironmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Environment `json:"items"`
+}
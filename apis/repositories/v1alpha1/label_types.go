@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// LabelParameters define the desired state of an issue label.
+type LabelParameters struct {
+	// Owner of the repository.
+	Owner string `json:"owner"`
+
+	// Repository the label belongs to.
+	Repository string `json:"repository"`
+
+	// Name of the label. Changing it renames the label.
+	Name string `json:"name"`
+
+	// Color of the label as a hexadecimal value, for example "#d73a4a" or
+	// "fff". Defaults to a color chosen by GitHub.
+	// +optional
+	Color *string `json:"color,omitempty"`
+
+	// Description of the label.
+	// +optional
+	Description *string `json:"description,omitempty"`
+}
+
+// LabelSpec defines the desired state of a Label.
+type LabelSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       LabelParameters `json:"forProvider"`
+}
+
+// LabelObservation is the representation of the current state that is
+// observed.
+type LabelObservation struct {
+	// ID of the label.
+	ID *int64 `json:"id,omitempty"`
+
+	// Name of the label at GitHub. It is used to find the label when Name is
+	// changed, so that the label is renamed rather than created anew.
+	Name string `json:"name,omitempty"`
+
+	// URL of the label in the GitHub API.
+	URL *string `json:"url,omitempty"`
+
+	// Default is true for the labels GitHub creates with a repository.
+	Default *bool `json:"default,omitempty"`
+}
+
+// LabelStatus represents the observed state of a Label.
+type LabelStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          LabelObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Label is a managed resource that represents an issue label of a
+// repository.
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="LABEL",type="string",JSONPath=".spec.forProvider.name"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type Label struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LabelSpec   `json:"spec"`
+	Status LabelStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LabelList contains a list of Label
+type LabelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Label `json:"items"`
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+// Package type metadata.
+const (
+	Group   = "repositories.github.crossplane.io"
+	Version = "v1alpha1"
+)
+
+var (
+	// SchemeGroupVersion is group version used to register these objects
+	SchemeGroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
+)
+
+// RequiredStatusChecks type metadata.
+var (
+	RequiredStatusChecksKind             = reflect.TypeOf(RequiredStatusChecks{}).Name()
+	RequiredStatusChecksGroupKind        = schema.GroupKind{Group: Group, Kind: RequiredStatusChecksKind}.String()
+	RequiredStatusChecksKindAPIVersion   = RequiredStatusChecksKind + "." + SchemeGroupVersion.String()
+	RequiredStatusChecksGroupVersionKind = SchemeGroupVersion.WithKind(RequiredStatusChecksKind)
+)
+
+// Content type metadata.
+var (
+	ContentKind             = reflect.TypeOf(Content{}).Name()
+	ContentGroupKind        = schema.GroupKind{Group: Group, Kind: ContentKind}.String()
+	ContentKindAPIVersion   = ContentKind + "." + SchemeGroupVersion.String()
+	ContentGroupVersionKind = SchemeGroupVersion.WithKind(ContentKind)
+)
+
+// BranchProtection type metadata.
+var (
+	BranchProtectionKind             = reflect.TypeOf(BranchProtection{}).Name()
+	BranchProtectionGroupKind        = schema.GroupKind{Group: Group, Kind: BranchProtectionKind}.String()
+	BranchProtectionKindAPIVersion   = BranchProtectionKind + "." + SchemeGroupVersion.String()
+	BranchProtectionGroupVersionKind = SchemeGroupVersion.WithKind(BranchProtectionKind)
+)
+
+// Webhook type metadata.
+var (
+	WebhookKind             = reflect.TypeOf(Webhook{}).Name()
+	WebhookGroupKind        = schema.GroupKind{Group: Group, Kind: WebhookKind}.String()
+	WebhookKindAPIVersion   = WebhookKind + "." + SchemeGroupVersion.String()
+	WebhookGroupVersionKind = SchemeGroupVersion.WithKind(WebhookKind)
+)
+
+// Label type metadata.
+var (
+	LabelKind             = reflect.TypeOf(Label{}).Name()
+	LabelGroupKind        = schema.GroupKind{Group: Group, Kind: LabelKind}.String()
+	LabelKindAPIVersion   = LabelKind + "." + SchemeGroupVersion.String()
+	LabelGroupVersionKind = SchemeGroupVersion.WithKind(LabelKind)
+)
+
+// DeployKey type metadata.
+var (
+	DeployKeyKind             = reflect.TypeOf(DeployKey{}).Name()
+	DeployKeyGroupKind        = schema.GroupKind{Group: Group, Kind: DeployKeyKind}.String()
+	DeployKeyKindAPIVersion   = DeployKeyKind + "." + SchemeGroupVersion.String()
+	DeployKeyGroupVersionKind = SchemeGroupVersion.WithKind(DeployKeyKind)
+)
+
+// Collaborator type metadata.
+var (
+	CollaboratorKind             = reflect.TypeOf(Collaborator{}).Name()
+	CollaboratorGroupKind        = schema.GroupKind{Group: Group, Kind: CollaboratorKind}.String()
+	CollaboratorKindAPIVersion   = CollaboratorKind + "." + SchemeGroupVersion.String()
+	CollaboratorGroupVersionKind = SchemeGroupVersion.WithKind(CollaboratorKind)
+)
+
+// Environment type metadata.
+var (
+	EnvironmentKind             = reflect.TypeOf(Environment{}).Name()
+	EnvironmentGroupKind        = schema.GroupKind{Group: Group, Kind: EnvironmentKind}.String()
+	EnvironmentKindAPIVersion   = EnvironmentKind + "." + SchemeGroupVersion.String()
+	EnvironmentGroupVersionKind = SchemeGroupVersion.WithKind(EnvironmentKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RequiredStatusChecks{}, &RequiredStatusChecksList{})
+	SchemeBuilder.Register(&Content{}, &ContentList{})
+	SchemeBuilder.Register(&BranchProtection{}, &BranchProtectionList{})
+	SchemeBuilder.Register(&Webhook{}, &WebhookList{})
+	SchemeBuilder.Register(&Label{}, &LabelList{})
+	SchemeBuilder.Register(&DeployKey{}, &DeployKeyList{})
+	SchemeBuilder.Register(&Collaborator{}, &CollaboratorList{})
+	SchemeBuilder.Register(&Environment{}, &EnvironmentList{})
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// RequiredStatusChecksParameters define the desired required status checks of
+// a protected branch.
+type RequiredStatusChecksParameters struct {
+	// Owner of the repository.
+	Owner string `json:"owner"`
+
+	// Repository containing the protected branch.
+	Repository string `json:"repository"`
+
+	// Branch whose required status checks are managed.
+	Branch string `json:"branch"`
+
+	// Strict requires branches to be up to date with the base branch before
+	// merging.
+	// +optional
+	Strict *bool `json:"strict,omitempty"`
+
+	// Contexts are the names of the status checks that must pass before
+	// merging. Order is not significant.
+	Contexts []string `json:"contexts"`
+}
+
+// RequiredStatusChecksSpec defines the desired state of a
+// RequiredStatusChecks.
+type RequiredStatusChecksSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RequiredStatusChecksParameters `json:"forProvider"`
+}
+
+// RequiredStatusChecksObservation is the representation of the current state
+// that is observed.
+type RequiredStatusChecksObservation struct {
+	// Strict is true if branches must be up to date before merging.
+	Strict bool `json:"strict,omitempty"`
+
+	// Contexts currently required to pass before merging.
+	Contexts []string `json:"contexts,omitempty"`
+}
+
+// RequiredStatusChecksStatus represents the observed state of a
+// RequiredStatusChecks.
+type RequiredStatusChecksStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RequiredStatusChecksObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RequiredStatusChecks is a managed resource that represents only the
+// required status checks of a protected branch, leaving review rules, admin
+// enforcement and restrictions to be managed elsewhere. Creating it for a
+// branch that is not protected with required status checks protects the
+// branch with them, keeping the rest of any existing protection.
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="BRANCH",type="string",JSONPath=".spec.forProvider.branch"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type RequiredStatusChecks struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RequiredStatusChecksSpec   `json:"spec"`
+	Status RequiredStatusChecksStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RequiredStatusChecksList contains a list of RequiredStatusChecks
+type RequiredStatusChecksList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RequiredStatusChecks `json:"items"`
+}
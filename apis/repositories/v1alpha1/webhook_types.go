@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// WebhookParameters define the desired state of a repository webhook.
+type WebhookParameters struct {
+	// Owner of the repository.
+	Owner string `json:"owner"`
+
+	// Repository the webhook belongs to.
+	Repository string `json:"repository"`
+
+	// URL payloads are delivered to.
+	URL string `json:"url"`
+
+	// ContentType of the payloads. Can be one of "json" or "form".
+	// +kubebuilder:validation:Enum=json;form
+	// +optional
+	ContentType *string `json:"contentType,omitempty"`
+
+	// InsecureSSL disables verification of the URL's TLS certificate.
+	// +optional
+	InsecureSSL *bool `json:"insecureSsl,omitempty"`
+
+	// Events that trigger the webhook. Order is not significant. Defaults to
+	// push events only.
+	// +optional
+	Events []string `json:"events,omitempty"`
+
+	// Active webhooks deliver payloads. Defaults to true.
+	// +optional
+	Active *bool `json:"active,omitempty"`
+
+	// SecretRef references the secret used to sign payloads. GitHub never
+	// returns the secret, so changes to it are detected by comparing its
+	// hash with the hash of the secret last applied.
+	// +optional
+	SecretRef *xpv1.SecretKeySelector `json:"secretRef,omitempty"`
+}
+
+// WebhookSpec defines the desired state of a Webhook.
+type WebhookSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       WebhookParameters `json:"forProvider"`
+}
+
+// WebhookObservation is the representation of the current state that is
+// observed.
+type WebhookObservation struct {
+	// ID of the webhook.
+	ID *int64 `json:"id,omitempty"`
+
+	// URL of the webhook in the GitHub API.
+	URL *string `json:"url,omitempty"`
+
+	// SecretHash is the versioned hash of the secret last applied to the
+	// webhook. It is recorded when the webhook is first observed after it
+	// was created, and whenever the webhook is updated.
+	SecretHash string `json:"secretHash,omitempty"`
+}
+
+// WebhookStatus represents the observed state of a Webhook.
+type WebhookStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          WebhookObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Webhook is a managed resource that represents a repository webhook. Its
+// external name is the ID of the webhook.
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="ID",type="integer",JSONPath=".status.atProvider.id"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type Webhook struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WebhookSpec   `json:"spec"`
+	Status WebhookStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WebhookList contains a list of Webhook
+type WebhookList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Webhook `json:"items"`
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// EnvironmentSecretParameters define the desired state of a GitHub Actions
+// secret scoped to a deployment environment.
+type EnvironmentSecretParameters struct {
+	// Owner of the repository.
+	Owner string `json:"owner"`
+
+	// Repository the environment belongs to.
+	Repository string `json:"repository"`
+
+	// Environment the secret belongs to.
+	Environment string `json:"environment"`
+
+	// Name of the secret. Defaults to the external name, which defaults to
+	// the name of this resource. Kubernetes resource names cannot contain
+	// underscores while secret names cannot contain hyphens, so set this (or
+	// the crossplane.io/external-name annotation) for a name like MY_SECRET.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// ValueSecretRef references the value of the secret.
+	ValueSecretRef xpv1.SecretKeySelector `json:"valueSecretRef"`
+}
+
+// EnvironmentSecretSpec defines the desired state of an EnvironmentSecret.
+type EnvironmentSecretSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       EnvironmentSecretParameters `json:"forProvider"`
+}
+
+// EnvironmentSecretObservation is the representation of the current state
+// that is observed.
+type EnvironmentSecretObservation struct {
+	// RepositoryID is the ID of the repository the environment belongs to.
+	// The environment secrets API addresses repositories by ID, so it is
+	// resolved once and recorded here.
+	RepositoryID int64 `json:"repositoryId,omitempty"`
+
+	// Repository is the owner and name of the repository RepositoryID was
+	// resolved from, for example "crossplane/crossplane".
+	Repository string `json:"repository,omitempty"`
+
+	// EncryptValue is the versioned hash of the value last written to the
+	// secret.
+	EncryptValue string `json:"encryptValue,omitempty"`
+
+	// LastUpdate is when the secret was last written by this resource.
+	LastUpdate *metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// EnvironmentSecretStatus represents the observed state of an
+// EnvironmentSecret.
+type EnvironmentSecretStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          EnvironmentSecretObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// An EnvironmentSecret is a managed resource that represents a GitHub Actions
+// secret scoped to a deployment environment. Its external name is the name of
+// the secret, which GitHub stores uppercased, unless spec.forProvider.name is
+// set.
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="ENVIRONMENT",type="string",JSONPath=".spec.forProvider.environment"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type EnvironmentSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EnvironmentSecretSpec   `json:"spec"`
+	Status EnvironmentSecretStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EnvironmentSecretList contains a list of EnvironmentSecret
+type EnvironmentSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EnvironmentSecret `json:"items"`
+}
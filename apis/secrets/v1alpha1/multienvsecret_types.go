@@ -0,0 +1,115 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// MultiEnvSecretParameters define the desired state of a GitHub Actions
+// secret written to several deployment environments of a repository.
+type MultiEnvSecretParameters struct {
+	// Owner of the repository.
+	Owner string `json:"owner"`
+
+	// Repository the environments belong to.
+	Repository string `json:"repository"`
+
+	// Environments the secret is written to.
+	// +kubebuilder:validation:MinItems=1
+	Environments []string `json:"environments"`
+
+	// Name of the secret, which GitHub stores uppercased.
+	Name string `json:"name"`
+
+	// ValueSecretRef references the value of the secret.
+	ValueSecretRef xpv1.SecretKeySelector `json:"valueSecretRef"`
+}
+
+// MultiEnvSecretSpec defines the desired state of a MultiEnvSecret.
+type MultiEnvSecretSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       MultiEnvSecretParameters `json:"forProvider"`
+}
+
+// EnvironmentSecretWrite records the value last written to the secret of one
+// environment.
+type EnvironmentSecretWrite struct {
+	// Environment the secret was written to.
+	Environment string `json:"environment"`
+
+	// EncryptValue is the versioned hash of the value last written to the
+	// secret.
+	EncryptValue string `json:"encryptValue,omitempty"`
+
+	// LastUpdate is when the secret was last written by this resource.
+	LastUpdate *metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// MultiEnvSecretObservation is the representation of the current state that
+// is observed.
+type MultiEnvSecretObservation struct {
+	// RepositoryID is the ID of the repository the environments belong to.
+	// The environment secrets API addresses repositories by ID, so it is
+	// resolved once and recorded here.
+	RepositoryID int64 `json:"repositoryId,omitempty"`
+
+	// Repository is the owner and name of the repository RepositoryID was
+	// resolved from, for example "crossplane/crossplane".
+	Repository string `json:"repository,omitempty"`
+
+	// Environments records the value last written to each environment. An
+	// environment recorded here but no longer in the spec has its secret
+	// deleted.
+	Environments []EnvironmentSecretWrite `json:"environments,omitempty"`
+}
+
+// MultiEnvSecretStatus represents the observed state of a MultiEnvSecret.
+type MultiEnvSecretStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          MultiEnvSecretObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// A MultiEnvSecret is a managed resource that represents a GitHub Actions
+// secret written to several deployment environments of a repository. The
+// secret is reconciled separately in each environment.
+// +kubebuilder:printcolumn:name="REPOSITORY",type="string",JSONPath=".spec.forProvider.repository"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type MultiEnvSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MultiEnvSecretSpec   `json:"spec"`
+	Status MultiEnvSecretStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MultiEnvSecretList contains a list of MultiEnvSecret
+type MultiEnvSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MultiEnvSecret `json:"items"`
+}
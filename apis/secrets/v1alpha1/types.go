@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// OrgSecretParameters define the desired state of an organization level
+// GitHub Actions secret.
+type OrgSecretParameters struct {
+	// Organization the secret belongs to.
+	Organization string `json:"organization"`
+
+	// Name of the secret. Defaults to the external name, which defaults to
+	// the name of this resource. Kubernetes resource names cannot contain
+	// underscores while secret names cannot contain hyphens, so set this (or
+	// the crossplane.io/external-name annotation) for a name like MY_SECRET.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Visibility of the secret to the organization's repositories. Can be
+	// one of:
+	// * all - all repositories.
+	// * private - private and internal repositories.
+	// * selected - only the repositories listed in SelectedRepositoryIDs.
+	// +kubebuilder:validation:Enum=all;private;selected
+	Visibility string `json:"visibility"`
+
+	// SelectedRepositoryIDs are the IDs of the repositories that can access
+	// the secret when Visibility is selected.
+	// +optional
+	SelectedRepositoryIDs []int64 `json:"selectedRepositoryIds,omitempty"`
+
+	// ValueSecretRef references the value of the secret.
+	ValueSecretRef xpv1.SecretKeySelector `json:"valueSecretRef"`
+}
+
+// OrgSecretSpec defines the desired state of an OrgSecret.
+type OrgSecretSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OrgSecretParameters `json:"forProvider"`
+}
+
+// OrgSecretObservation is the representation of the current state that is
+// observed.
+type OrgSecretObservation struct {
+	// EncryptValue is the hash of the value last written to the secret,
+	// prefixed with the version of the hashing used, for example "v1:".
+	// GitHub never returns secret values, so it is used to detect changes to
+	// the referenced value.
+	EncryptValue string `json:"encryptValue,omitempty"`
+
+	// LastUpdate is when the secret was last written by this resource. A
+	// secret updated at any other time was changed outside of it.
+	LastUpdate *metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// OrgSecretStatus represents the observed state of an OrgSecret.
+type OrgSecretStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          OrgSecretObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OrgSecret is a managed resource that represents an organization level
+// GitHub Actions secret. Its external name is the name of the secret, which
+// GitHub stores uppercased, unless spec.forProvider.name is set.
+// +kubebuilder:printcolumn:name="ORGANIZATION",type="string",JSONPath=".spec.forProvider.organization"
+// +kubebuilder:printcolumn:name="VISIBILITY",type="string",JSONPath=".spec.forProvider.visibility"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type OrgSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OrgSecretSpec   `json:"spec"`
+	Status OrgSecretStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OrgSecretList contains a list of OrgSecret
+type OrgSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrgSecret `json:"items"`
+}
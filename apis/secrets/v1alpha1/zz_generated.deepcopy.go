@@ -0,0 +1,451 @@
+// +build !ignore_autogenerated
+
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentSecret) DeepCopyInto(out *EnvironmentSecret) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentSecret.
+func (in *EnvironmentSecret) DeepCopy() *EnvironmentSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EnvironmentSecret) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentSecretList) DeepCopyInto(out *EnvironmentSecretList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EnvironmentSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentSecretList.
+func (in *EnvironmentSecretList) DeepCopy() *EnvironmentSecretList {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentSecretList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EnvironmentSecretList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentSecretObservation) DeepCopyInto(out *EnvironmentSecretObservation) {
+	*out = *in
+	if in.LastUpdate != nil {
+		in, out := &in.LastUpdate, &out.LastUpdate
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentSecretObservation.
+func (in *EnvironmentSecretObservation) DeepCopy() *EnvironmentSecretObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentSecretObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentSecretParameters) DeepCopyInto(out *EnvironmentSecretParameters) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	out.ValueSecretRef = in.ValueSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentSecretParameters.
+func (in *EnvironmentSecretParameters) DeepCopy() *EnvironmentSecretParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentSecretParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentSecretSpec) DeepCopyInto(out *EnvironmentSecretSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentSecretSpec.
+func (in *EnvironmentSecretSpec) DeepCopy() *EnvironmentSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentSecretStatus) DeepCopyInto(out *EnvironmentSecretStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentSecretStatus.
+func (in *EnvironmentSecretStatus) DeepCopy() *EnvironmentSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentSecretWrite) DeepCopyInto(out *EnvironmentSecretWrite) {
+	*out = *in
+	if in.LastUpdate != nil {
+		in, out := &in.LastUpdate, &out.LastUpdate
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentSecretWrite.
+func (in *EnvironmentSecretWrite) DeepCopy() *EnvironmentSecretWrite {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentSecretWrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiEnvSecret) DeepCopyInto(out *MultiEnvSecret) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiEnvSecret.
+func (in *MultiEnvSecret) DeepCopy() *MultiEnvSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiEnvSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultiEnvSecret) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiEnvSecretList) DeepCopyInto(out *MultiEnvSecretList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MultiEnvSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiEnvSecretList.
+func (in *MultiEnvSecretList) DeepCopy() *MultiEnvSecretList {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiEnvSecretList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultiEnvSecretList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiEnvSecretObservation) DeepCopyInto(out *MultiEnvSecretObservation) {
+	*out = *in
+	if in.Environments != nil {
+		in, out := &in.Environments, &out.Environments
+		*out = make([]EnvironmentSecretWrite, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiEnvSecretObservation.
+func (in *MultiEnvSecretObservation) DeepCopy() *MultiEnvSecretObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiEnvSecretObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiEnvSecretParameters) DeepCopyInto(out *MultiEnvSecretParameters) {
+	*out = *in
+	if in.Environments != nil {
+		in, out := &in.Environments, &out.Environments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.ValueSecretRef = in.ValueSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiEnvSecretParameters.
+func (in *MultiEnvSecretParameters) DeepCopy() *MultiEnvSecretParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiEnvSecretParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiEnvSecretSpec) DeepCopyInto(out *MultiEnvSecretSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiEnvSecretSpec.
+func (in *MultiEnvSecretSpec) DeepCopy() *MultiEnvSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiEnvSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiEnvSecretStatus) DeepCopyInto(out *MultiEnvSecretStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiEnvSecretStatus.
+func (in *MultiEnvSecretStatus) DeepCopy() *MultiEnvSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiEnvSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgSecret) DeepCopyInto(out *OrgSecret) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgSecret.
+func (in *OrgSecret) DeepCopy() *OrgSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrgSecret) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgSecretList) DeepCopyInto(out *OrgSecretList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OrgSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgSecretList.
+func (in *OrgSecretList) DeepCopy() *OrgSecretList {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgSecretList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrgSecretList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgSecretObservation) DeepCopyInto(out *OrgSecretObservation) {
+	*out = *in
+	if in.LastUpdate != nil {
+		in, out := &in.LastUpdate, &out.LastUpdate
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgSecretObservation.
+func (in *OrgSecretObservation) DeepCopy() *OrgSecretObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgSecretObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgSecretParameters) DeepCopyInto(out *OrgSecretParameters) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.SelectedRepositoryIDs != nil {
+		in, out := &in.SelectedRepositoryIDs, &out.SelectedRepositoryIDs
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+	out.ValueSecretRef = in.ValueSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgSecretParameters.
+func (in *OrgSecretParameters) DeepCopy() *OrgSecretParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgSecretParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgSecretSpec) DeepCopyInto(out *OrgSecretSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgSecretSpec.
+func (in *OrgSecretSpec) DeepCopy() *OrgSecretSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgSecretSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgSecretStatus) DeepCopyInto(out *OrgSecretStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgSecretStatus.
+func (in *OrgSecretStatus) DeepCopy() *OrgSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TeamParameters define the desired state of a GitHub team.
+type TeamParameters struct {
+	// Organization the team belongs to.
+	Organization string `json:"organization"`
+
+	// Name of the team. GitHub derives the team's slug, which is used as the
+	// external name, from it.
+	Name string `json:"name"`
+
+	// Description of the team.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Privacy level of the team. Can be one of:
+	// * secret - only visible to organization owners and members of the team.
+	// * closed - visible to all members of the organization.
+	// Nested teams must be closed.
+	// +kubebuilder:validation:Enum=secret;closed
+	// +optional
+	Privacy *string `json:"privacy,omitempty"`
+
+	// ParentTeam is the slug of the team's parent team. An empty string
+	// means the team has no parent.
+	// +optional
+	ParentTeam *string `json:"parentTeam,omitempty"`
+}
+
+// TeamSpec defines the desired state of a Team.
+type TeamSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TeamParameters `json:"forProvider"`
+}
+
+// TeamObservation is the representation of the current state that is
+// observed.
+type TeamObservation struct {
+	// ID of the team.
+	ID *int64 `json:"id,omitempty"`
+
+	// NodeID of the team, used by the GraphQL API.
+	NodeID *string `json:"nodeId,omitempty"`
+
+	// Slug of the team.
+	Slug *string `json:"slug,omitempty"`
+
+	// OrganizationID is the ID of the organization the team belongs to. It
+	// is used to find the team by ID after it has been renamed.
+	OrganizationID *int64 `json:"organizationId,omitempty"`
+}
+
+// TeamStatus represents the observed state of a Team.
+type TeamStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TeamObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Team is a managed resource that represents a GitHub team. Its external
+// name is the team's slug. An existing team is only adopted when the
+// crossplane.io/external-name annotation is set to its slug; otherwise a new
+// team is created and its slug recorded as the external name.
+// +kubebuilder:printcolumn:name="SLUG",type="string",JSONPath=".status.atProvider.slug"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type Team struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TeamSpec   `json:"spec"`
+	Status TeamStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TeamList contains a list of Team
+type TeamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Team `json:"items"`
+}
+
+// TeamMembershipParameters define the desired state of a user's membership
+// in a GitHub team.
+type TeamMembershipParameters struct {
+	// Organization the team belongs to.
+	Organization string `json:"organization"`
+
+	// Team is the slug of the team.
+	Team string `json:"team"`
+
+	// User is the username of the GitHub user.
+	User string `json:"user"`
+
+	// Role of the user in the team. Can be one of:
+	// * member - a normal member of the team.
+	// * maintainer - able to add and remove team members, promote members to
+	//   maintainer, and edit the team's name and description.
+	// Default is "member".
+	// +kubebuilder:validation:Enum=member;maintainer
+	// +optional
+	Role *string `json:"role,omitempty"`
+}
+
+// TeamMembershipSpec defines the desired state of a TeamMembership.
+type TeamMembershipSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TeamMembershipParameters `json:"forProvider"`
+}
+
+// TeamMembershipObservation is the representation of the current state that
+// is observed.
+type TeamMembershipObservation struct {
+	// State is the user's status within the team. The membership is pending
+	// until the user accepts an invitation to the organization.
+	// Possible values are: "active", "pending"
+	State *string `json:"state,omitempty"`
+
+	// Role of the user in the team.
+	Role *string `json:"role,omitempty"`
+}
+
+// TeamMembershipStatus represents the observed state of a TeamMembership.
+type TeamMembershipStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TeamMembershipObservation `json:"atProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TeamMembership is a managed resource that represents a user's membership
+// in a GitHub team.
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".status.atProvider.role"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,github}
+type TeamMembership struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TeamMembershipSpec   `json:"spec"`
+	Status TeamMembershipStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TeamMembershipList contains a list of TeamMembership
+type TeamMembershipList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TeamMembership `json:"items"`
+}
@@ -26,6 +26,67 @@ import (
 type ProviderConfigSpec struct {
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// BaseURL of the GitHub API, for example https://github.example.com/ for
+	// GitHub Enterprise Server. The /api/v3/ path is appended if missing.
+	// Defaults to https://api.github.com/.
+	// +optional
+	BaseURL *string `json:"baseURL,omitempty"`
+
+	// UploadURL of the GitHub uploads API. The /api/uploads/ path is appended
+	// if missing. Defaults to BaseURL when BaseURL is set, otherwise to
+	// https://uploads.github.com/.
+	// +optional
+	UploadURL *string `json:"uploadURL,omitempty"`
+
+	// RequestsPerSecond caps the rate of requests made to the GitHub API by
+	// all managed resources using this ProviderConfig. Requests over the cap
+	// are delayed rather than failed. Unset or zero means no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RequestsPerSecond *int `json:"requestsPerSecond,omitempty"`
+
+	// DisableUsageTracking stops managed resources from recording their use
+	// of this ProviderConfig, which otherwise happens on every reconcile.
+	// This saves a Kubernetes API write per reconcile at the cost of deletion
+	// protection: a ProviderConfig without usages can be deleted while
+	// managed resources still depend on it.
+	// +optional
+	DisableUsageTracking *bool `json:"disableUsageTracking,omitempty"`
+
+	// Retry configures retries of requests to the GitHub API that fail with a
+	// transient error. Requests are not retried when this is omitted.
+	// +optional
+	Retry *RetryConfig `json:"retry,omitempty"`
+
+	// Timeout of a single request to the GitHub API, including any retries
+	// of it. Requests do not time out when this is omitted.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// RetryConfig configures how requests that fail with a transient error, i.e.
+// a network error, a 5xx response or a secondary rate limit response, are
+// retried. Rate limited requests wait as long as GitHub asks, via the
+// Retry-After or X-RateLimit-Reset headers, instead of the backoff, unless
+// that is longer than a minute.
+type RetryConfig struct {
+	// MaxRetries is the number of times a failed request is retried before the
+	// error is returned.
+	// +kubebuilder:validation:Minimum=0
+	MaxRetries int `json:"maxRetries"`
+
+	// Backoff is how long to wait before the first retry. The wait doubles
+	// with each subsequent retry. Defaults to 1s.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+
+	// RetryNonIdempotent also retries POST and PATCH requests. By default
+	// only idempotent requests (GET, HEAD, OPTIONS, PUT and DELETE) are
+	// retried, since retrying a request that GitHub processed before failing
+	// could, for example, create a resource twice.
+	// +optional
+	RetryNonIdempotent *bool `json:"retryNonIdempotent,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -35,6 +96,22 @@ type ProviderCredentials struct {
 	Source xpv1.CredentialsSource `json:"source"`
 
 	xpv1.CommonCredentialSelectors `json:",inline"`
+
+	// App authenticates as an installation of a GitHub App rather than with
+	// a personal access token. When set, the credentials are the app's
+	// PEM-encoded private key. Installation tokens are minted from it and
+	// refreshed automatically before they expire.
+	// +optional
+	App *AppCredentials `json:"app,omitempty"`
+}
+
+// AppCredentials identify the GitHub App installation to authenticate as.
+type AppCredentials struct {
+	// ID of the GitHub App.
+	ID int64 `json:"id"`
+
+	// InstallationID of the GitHub App installation.
+	InstallationID int64 `json:"installationID"`
 }
 
 // A ProviderConfigStatus represents the status of a ProviderConfig.
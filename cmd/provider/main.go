@@ -22,6 +22,7 @@ import (
 
 	"gopkg.in/alecthomas/kingpin.v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
@@ -29,6 +30,8 @@ import (
 
 	"github.com/crossplane-contrib/provider-github/apis"
 	"github.com/crossplane-contrib/provider-github/pkg/controller"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/config"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/instrument"
 )
 
 func main() {
@@ -36,7 +39,11 @@ func main() {
 		app            = kingpin.New(filepath.Base(os.Args[0]), "GitHub support for Crossplane.").DefaultEnvars()
 		debug          = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
 		syncPeriod     = app.Flag("sync", "Controller manager sync period such as 300ms, 1.5h, or 2h45m").Short('s').Default("1h").Duration()
+		slowThreshold  = app.Flag("slow-operation-threshold", "Record a warning event when a single external operation takes longer than this. Zero disables the warning.").Default("30s").Duration()
 		leaderElection = app.Flag("leader-election", "Use leader election for the conroller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		healthAddr     = app.Flag("health-probe-bind-address", "Address the liveness and readiness probe endpoints bind to, such as :8081. Empty disables the probes.").Default("").String()
+		githubReady    = app.Flag("github-readiness-check", "Report the provider as not ready when GitHub cannot be reached, or its rate limit is exhausted, using any ProviderConfig. Requires --health-probe-bind-address.").Default("false").Bool()
+		githubInterval = app.Flag("github-readiness-check-interval", "How long the result of the GitHub readiness check is reused before GitHub is asked again.").Default("1m").Duration()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -58,9 +65,20 @@ func main() {
 		LeaderElection:   *leaderElection,
 		LeaderElectionID: "crossplane-leader-election-provider-github",
 		SyncPeriod:       syncPeriod,
+
+		HealthProbeBindAddress: *healthAddr,
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 
+	if *healthAddr != "" {
+		kingpin.FatalIfError(mgr.AddHealthzCheck("ping", healthz.Ping), "Cannot add liveness check")
+		if *githubReady {
+			kingpin.FatalIfError(mgr.AddReadyzCheck("github", config.NewHealthCheck(mgr.GetClient(), log, *githubInterval)), "Cannot add GitHub readiness check")
+		}
+	}
+
+	instrument.SlowThreshold = *slowThreshold
+
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add GitHub APIs to scheme")
 	kingpin.FatalIfError(controller.Setup(mgr, log, ratelimiter.NewDefaultProviderRateLimiter(ratelimiter.DefaultProviderRPS)), "Cannot setup GitHub controllers")
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
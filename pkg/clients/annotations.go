@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AnnotationKeyPaused is the key in the annotations map of a managed resource
+// that, when set to "true", pauses reconciliation of that resource. Paused
+// resources are reported as existing and up to date without calling the
+// GitHub API, and are not deleted until the annotation is removed.
+const AnnotationKeyPaused = "crossplane.io/paused"
+
+// ErrPaused is returned when an operation is refused because reconciliation
+// of the resource is paused.
+var ErrPaused = errors.New("reconciliation is paused via the " + AnnotationKeyPaused + " annotation")
+
+// IsPaused returns true if reconciliation of the supplied object is paused.
+func IsPaused(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyPaused] == "true"
+}
+
+// AnnotationKeyManageOnChangeOnly is the key in the annotations map of a
+// managed resource that, when set to "true", stops drift of the external
+// resource from being corrected. Changes to the resource's spec are still
+// applied.
+const AnnotationKeyManageOnChangeOnly = "github.crossplane.io/manage-on-change-only"
+
+// AnnotationKeyAppliedGeneration is the key in the annotations map of a
+// managed resource under which the generation of the spec last applied to
+// the external resource is recorded.
+const AnnotationKeyAppliedGeneration = "github.crossplane.io/applied-generation"
+
+// IsManageOnChangeOnly returns true if only changes to the spec of the
+// supplied object should be applied.
+func IsManageOnChangeOnly(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyManageOnChangeOnly] == "true"
+}
+
+// GetAppliedGeneration returns the generation of the spec last applied to
+// the external resource of the supplied object, and false if none was
+// recorded.
+func GetAppliedGeneration(o metav1.Object) (int64, bool) {
+	g, err := strconv.ParseInt(o.GetAnnotations()[AnnotationKeyAppliedGeneration], 10, 64)
+	return g, err == nil
+}
+
+// SetAppliedGeneration records the current generation of the supplied object
+// as the generation of the spec last applied to its external resource.
+func SetAppliedGeneration(o metav1.Object) {
+	a := o.GetAnnotations()
+	if a == nil {
+		a = map[string]string{}
+	}
+	a[AnnotationKeyAppliedGeneration] = strconv.FormatInt(o.GetGeneration(), 10)
+	o.SetAnnotations(a)
+}
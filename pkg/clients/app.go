@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+)
+
+const (
+	errDecodePrivateKey  = "cannot decode GitHub App private key: no PEM data found"
+	errParsePrivateKey   = "cannot parse GitHub App private key"
+	errNotRSAPrivateKey  = "GitHub App private key is not an RSA key"
+	errSignJWT           = "cannot sign GitHub App JWT"
+	errInstallationToken = "cannot create GitHub App installation token"
+)
+
+// jwtLifetime is how long the JWTs used to mint installation tokens are valid
+// for. GitHub rejects JWTs valid for more than ten minutes.
+const jwtLifetime = 9 * time.Minute
+
+// jwtClockSkew backdates JWTs to allow for clock drift between the provider
+// and GitHub.
+const jwtClockSkew = time.Minute
+
+// appTokenSourceKey identifies a token source by everything that affects how
+// it mints installation tokens, including the transport it sends requests
+// with, so that a token source is rebuilt when any of them change.
+type appTokenSourceKey struct {
+	baseURL        string
+	uploadURL      string
+	appID          int64
+	installationID int64
+	privateKey     string
+
+	limiter            *rate.Limiter
+	maxRetries         int
+	retryBackoff       time.Duration
+	retryNonIdempotent bool
+	timeout            time.Duration
+}
+
+// appTokenSources holds one token source per GitHub App installation so that
+// an installation token is reused by every client until it expires, rather
+// than minted on every reconcile.
+var appTokenSources = struct {
+	sync.Mutex
+	m map[appTokenSourceKey]oauth2.TokenSource
+}{m: map[appTokenSourceKey]oauth2.TokenSource{}}
+
+func appTokenSourceFor(cfg *Config, base http.RoundTripper) (oauth2.TokenSource, error) {
+	k := appTokenSourceKey{
+		baseURL:        cfg.BaseURL,
+		uploadURL:      cfg.UploadURL,
+		appID:          cfg.AppID,
+		installationID: cfg.InstallationID,
+		privateKey:     string(cfg.PrivateKey),
+
+		limiter:            cfg.Limiter,
+		maxRetries:         cfg.MaxRetries,
+		retryBackoff:       cfg.RetryBackoff,
+		retryNonIdempotent: cfg.RetryNonIdempotent,
+		timeout:            cfg.Timeout,
+	}
+
+	appTokenSources.Lock()
+	defer appTokenSources.Unlock()
+
+	if ts, ok := appTokenSources.m[k]; ok {
+		return ts, nil
+	}
+	key, err := parsePrivateKey(cfg.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	ts := oauth2.ReuseTokenSource(nil, &appTokenSource{
+		appID:          cfg.AppID,
+		installationID: cfg.InstallationID,
+		key:            key,
+		baseURL:        cfg.BaseURL,
+		uploadURL:      cfg.UploadURL,
+		timeout:        cfg.Timeout,
+		base:           base,
+	})
+	appTokenSources.m[k] = ts
+	return ts, nil
+}
+
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	b, _ := pem.Decode(data)
+	if b == nil {
+		return nil, errors.New(errDecodePrivateKey)
+	}
+	if k, err := x509.ParsePKCS1PrivateKey(b.Bytes); err == nil {
+		return k, nil
+	}
+	k, err := x509.ParsePKCS8PrivateKey(b.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, errParsePrivateKey)
+	}
+	rk, ok := k.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New(errNotRSAPrivateKey)
+	}
+	return rk, nil
+}
+
+// An appTokenSource mints GitHub App installation tokens. It authenticates
+// as the app using a short lived JWT signed with the app's private key.
+type appTokenSource struct {
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+
+	baseURL   string
+	uploadURL string
+	timeout   time.Duration
+	base      http.RoundTripper
+}
+
+func (s *appTokenSource) Token() (*oauth2.Token, error) {
+	jwt, err := s.jwt(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	hc := &http.Client{Transport: &oauth2.Transport{
+		Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: jwt}),
+		Base:   s.base,
+	}, Timeout: s.timeout}
+	gh, err := newGitHubClient(s.baseURL, s.uploadURL, hc)
+	if err != nil {
+		return nil, err
+	}
+	t, _, err := gh.Apps.CreateInstallationToken(context.Background(), s.installationID, nil)
+	if err != nil {
+		return nil, Wrap(err, errInstallationToken)
+	}
+	return &oauth2.Token{AccessToken: t.GetToken(), Expiry: t.GetExpiresAt()}, nil
+}
+
+// jwt returns an RS256 signed JWT identifying the app.
+func (s *appTokenSource) jwt(now time.Time) (string, error) {
+	h, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	c, _ := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-jwtClockSkew).Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+		"iss": strconv.FormatInt(s.appID, 10),
+	})
+	unsigned := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+
+	sum := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", errors.Wrap(err, errSignJWT)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/go-github/v33/github"
+)
+
+type cachedClientEntry struct {
+	fingerprint string
+	client      *github.Client
+}
+
+// clientCache holds the most recently built client of each ProviderConfig so
+// that reconciles don't each build a new client, which for GitHub Apps would
+// also mean minting a new installation token.
+var clientCache = struct {
+	sync.Mutex
+	m map[string]cachedClientEntry
+}{m: map[string]cachedClientEntry{}}
+
+// cachedClient returns the cached client of the ProviderConfig the supplied
+// Config was built from. A new client replaces the cached one when the
+// Config differs from the one the cached client was built from, for example
+// because the ProviderConfig's credentials were rotated.
+func cachedClient(cfg *Config) (*github.Client, error) {
+	fp := fingerprint(cfg)
+
+	clientCache.Lock()
+	defer clientCache.Unlock()
+
+	if e, ok := clientCache.m[cfg.ProviderConfigName]; ok && e.fingerprint == fp {
+		return e.client, nil
+	}
+	c, err := newClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	clientCache.m[cfg.ProviderConfigName] = cachedClientEntry{fingerprint: fp, client: c}
+	return c, nil
+}
+
+// fingerprint returns a hash of everything in the supplied Config that
+// affects the client built from it.
+func fingerprint(cfg *Config) string {
+	return Hash([]byte(fmt.Sprintf("%q %q %d %d %q %q %q %p %d %d %t %d",
+		cfg.ProviderConfigName, cfg.Token, cfg.AppID, cfg.InstallationID, cfg.PrivateKey,
+		cfg.BaseURL, cfg.UploadURL, cfg.Limiter, cfg.MaxRetries, cfg.RetryBackoff,
+		cfg.RetryNonIdempotent, cfg.Timeout)))
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+)
+
+func TestCachedClient(t *testing.T) {
+	base := Config{ProviderConfigName: "cache-test", Token: "token", MaxRetries: 3, RetryBackoff: time.Second}
+
+	cases := map[string]struct {
+		reason string
+		first  Config
+		second func(cfg Config) Config
+		hit    bool
+	}{
+		"SameConfig": {
+			reason: "A ProviderConfig whose configuration is unchanged should reuse its client.",
+			first:  base,
+			second: func(cfg Config) Config { return cfg },
+			hit:    true,
+		},
+		"TokenRotated": {
+			reason: "A ProviderConfig whose token was rotated should get a new client.",
+			first:  base,
+			second: func(cfg Config) Config { cfg.Token = "rotated"; return cfg },
+		},
+		"RetriesChanged": {
+			reason: "A ProviderConfig whose retry settings changed should get a new client.",
+			first:  base,
+			second: func(cfg Config) Config { cfg.MaxRetries = 5; return cfg },
+		},
+		"TimeoutChanged": {
+			reason: "A ProviderConfig whose timeout changed should get a new client.",
+			first:  base,
+			second: func(cfg Config) Config { cfg.Timeout = time.Minute; return cfg },
+		},
+		"OtherProviderConfig": {
+			reason: "Another ProviderConfig should not share the client, even with the same configuration.",
+			first:  base,
+			second: func(cfg Config) Config { cfg.ProviderConfigName = "cache-test-other"; return cfg },
+		},
+		"Unnamed": {
+			reason: "Clients of a Config that was not built from a ProviderConfig should not be cached.",
+			first:  Config{Token: "token"},
+			second: func(cfg Config) Config { return cfg },
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			clientCache.Lock()
+			clientCache.m = map[string]cachedClientEntry{}
+			clientCache.Unlock()
+
+			first, second := tc.first, tc.second(tc.first)
+			c1, err := NewClient(&first)
+			if err != nil {
+				t.Fatalf("NewClient(...): %v", err)
+			}
+			c2, err := NewClient(&second)
+			if err != nil {
+				t.Fatalf("NewClient(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.hit, c1 == c2); diff != "" {
+				t.Errorf("\n%s\nNewClient(...): -want reused, +got reused:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCachedClientLogsToContextLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	cfg := &Config{ProviderConfigName: "cache-test-logger", Token: "token", BaseURL: srv.URL + "/"}
+	var first, second []string
+	for _, tc := range []struct {
+		log  *[]string
+		path string
+	}{
+		{log: &first, path: "cache-test/first"},
+		{log: &second, path: "cache-test/second"},
+	} {
+		c, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient(...): %v", err)
+		}
+		ctx := WithLogger(context.Background(), recordingLogger{msgs: tc.log})
+		req, err := c.NewRequest(http.MethodGet, tc.path, nil)
+		if err != nil {
+			t.Fatalf("NewRequest(...): %v", err)
+		}
+		if _, err := c.Do(ctx, req, &github.Repository{}); err != nil {
+			t.Fatalf("Do(...): %v", err)
+		}
+	}
+
+	// The client is shared, but each deprecation warning should reach the
+	// logger of the context its request was made with.
+	if len(first) != 1 || len(second) != 1 {
+		t.Errorf("Do(...): want one warning logged to each context's logger, got %d and %d", len(first), len(second))
+	}
+}
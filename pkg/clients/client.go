@@ -18,40 +18,203 @@ package clients
 
 import (
 	"context"
+	"encoding/pem"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/google/go-github/v33/github"
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane-contrib/provider-github/apis/v1beta1"
 )
 
+const (
+	errNewEnterpriseClient       = "cannot create GitHub Enterprise client"
+	errFmtProviderConfigNotFound = "ProviderConfig %q not found"
+	errNoCredentials             = "ProviderConfig credentials are empty: a personal access token or GitHub App private key is required"
+	errAppCredentialsNotKey      = "ProviderConfig credentials must be a PEM encoded private key when GitHub App credentials are set, not a personal access token"
+)
+
+// defaultRetryBackoff is the wait before the first retry of a failed request
+// when the ProviderConfig does not specify one.
+const defaultRetryBackoff = 1 * time.Second
+
+// Config is the information needed to build a GitHub client.
+type Config struct {
+	// ProviderConfigName is the name of the ProviderConfig the Config was
+	// built from. Clients built from a Config with a name are cached.
+	ProviderConfigName string
+
+	// Token used to authenticate to the GitHub API.
+	Token string
+
+	// AppID and InstallationID identify the GitHub App installation to
+	// authenticate as. When AppID is set PrivateKey is used to authenticate
+	// instead of Token.
+	AppID          int64
+	InstallationID int64
+
+	// PrivateKey of the GitHub App, PEM encoded.
+	PrivateKey []byte
+
+	// BaseURL of the GitHub API. Empty means https://api.github.com/.
+	BaseURL string
+
+	// UploadURL of the GitHub uploads API. Empty means BaseURL when it is set,
+	// otherwise https://uploads.github.com/.
+	UploadURL string
+
+	// Limiter throttles requests to the GitHub API. It is shared by all
+	// clients built from the same ProviderConfig. Nil means no throttling.
+	Limiter *rate.Limiter
+
+	// MaxRetries is the number of times a request that fails with a
+	// transient error is retried. Zero disables retries.
+	MaxRetries int
+
+	// RetryBackoff is the wait before the first retry. It doubles with each
+	// subsequent retry.
+	RetryBackoff time.Duration
+
+	// RetryNonIdempotent allows POST and PATCH requests to be retried.
+	RetryNonIdempotent bool
+
+	// Timeout of a request, including its retries. Zero means no timeout.
+	Timeout time.Duration
+}
+
 // GetConfig gets the config.
-func GetConfig(ctx context.Context, c client.Client, mg resource.Managed) ([]byte, error) {
+func GetConfig(ctx context.Context, c client.Client, mg resource.Managed) (*Config, error) {
 	pc := &v1beta1.ProviderConfig{}
-	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
+	name := mg.GetProviderConfigReference().Name
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, errors.Errorf(errFmtProviderConfigNotFound, name)
+		}
 		return nil, errors.Wrap(err, "cannot get referenced ProviderConfig")
 	}
 
-	t := resource.NewProviderConfigUsageTracker(c, &v1beta1.ProviderConfigUsage{})
-	if err := t.Track(ctx, mg); err != nil {
-		return nil, errors.Wrap(err, "cannot track ProviderConfig usage")
+	if pc.Spec.DisableUsageTracking == nil || !*pc.Spec.DisableUsageTracking {
+		t := resource.NewProviderConfigUsageTracker(c, &v1beta1.ProviderConfigUsage{})
+		if err := t.Track(ctx, mg); err != nil {
+			return nil, errors.Wrap(err, "cannot track ProviderConfig usage")
+		}
+	}
+
+	return NewConfig(ctx, c, pc)
+}
+
+// NewConfig builds the config described by the supplied ProviderConfig.
+func NewConfig(ctx context.Context, c client.Client, pc *v1beta1.ProviderConfig) (*Config, error) {
+	token, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c, pc.Spec.Credentials.CommonCredentialSelectors)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{Token: string(token)}
+	if app := pc.Spec.Credentials.App; app != nil {
+		if b, _ := pem.Decode(token); b == nil {
+			return nil, errors.New(errAppCredentialsNotKey)
+		}
+		cfg = &Config{AppID: app.ID, InstallationID: app.InstallationID, PrivateKey: token}
+	}
+	if len(token) == 0 && pc.Spec.Credentials.Source != xpv1.CredentialsSourceNone {
+		return nil, errors.New(errNoCredentials)
+	}
+	if pc.Spec.BaseURL != nil {
+		cfg.BaseURL = *pc.Spec.BaseURL
+	}
+	if pc.Spec.UploadURL != nil {
+		cfg.UploadURL = *pc.Spec.UploadURL
+	}
+	if pc.Spec.RequestsPerSecond != nil {
+		cfg.Limiter = limiterFor(pc.GetName(), *pc.Spec.RequestsPerSecond)
 	}
+	if r := pc.Spec.Retry; r != nil {
+		cfg.MaxRetries = r.MaxRetries
+		cfg.RetryBackoff = defaultRetryBackoff
+		if r.Backoff != nil {
+			cfg.RetryBackoff = r.Backoff.Duration
+		}
+		cfg.RetryNonIdempotent = r.RetryNonIdempotent != nil && *r.RetryNonIdempotent
+	}
+	if pc.Spec.Timeout != nil {
+		cfg.Timeout = pc.Spec.Timeout.Duration
+	}
+	cfg.ProviderConfigName = pc.GetName()
+	return cfg, nil
+}
 
-	return resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c, pc.Spec.Credentials.CommonCredentialSelectors)
+// NewClient creates a new client. It talks to GitHub Enterprise Server when
+// the supplied Config has a BaseURL, and to github.com otherwise. Clients for
+// a Config with an AppID are built by NewAppClient. A client built from a
+// ProviderConfig is reused until that ProviderConfig or its credentials
+// change.
+func NewClient(cfg *Config) (*github.Client, error) {
+	if cfg.ProviderConfigName != "" {
+		return cachedClient(cfg)
+	}
+	return newClient(cfg)
 }
 
-// NewClient creates a new client.
-func NewClient(token string) *github.Client {
-	ctx := context.Background()
+func newClient(cfg *Config) (*github.Client, error) {
+	if cfg.AppID != 0 {
+		return NewAppClient(cfg)
+	}
 	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
+		&oauth2.Token{AccessToken: cfg.Token},
 	)
-	tc := oauth2.NewClient(ctx, ts)
+	return newGitHubClient(cfg.BaseURL, cfg.UploadURL, &http.Client{Transport: &oauth2.Transport{Source: ts, Base: newTransport(cfg)}, Timeout: cfg.Timeout})
+}
+
+// NewAppClient creates a new client that authenticates as a GitHub App
+// installation. Installation tokens are refreshed as they expire, and are
+// shared by all clients for the same installation.
+func NewAppClient(cfg *Config) (*github.Client, error) {
+	base := newTransport(cfg)
+	ts, err := appTokenSourceFor(cfg, base)
+	if err != nil {
+		return nil, err
+	}
+	return newGitHubClient(cfg.BaseURL, cfg.UploadURL, &http.Client{Transport: &oauth2.Transport{Source: ts, Base: base}, Timeout: cfg.Timeout})
+}
+
+// newTransport returns the transport requests are sent with before they are
+// authenticated.
+func newTransport(cfg *Config) http.RoundTripper {
+	var base http.RoundTripper = http.DefaultTransport
+	if cfg.Limiter != nil {
+		base = &throttledTransport{limiter: cfg.Limiter, base: base}
+	}
+	if cfg.MaxRetries > 0 {
+		base = &retryTransport{
+			maxRetries:    cfg.MaxRetries,
+			backoff:       cfg.RetryBackoff,
+			nonIdempotent: cfg.RetryNonIdempotent,
+			base:          base,
+		}
+	}
+	return &deprecationTransport{base: base}
+}
 
-	return github.NewClient(tc)
+func newGitHubClient(baseURL, uploadURL string, hc *http.Client) (*github.Client, error) {
+	if baseURL == "" {
+		return github.NewClient(hc), nil
+	}
+	if uploadURL == "" {
+		// go-github appends /api/uploads/ to the upload URL, so a BaseURL
+		// that already includes /api/v3/ must not be used as is.
+		uploadURL = strings.TrimSuffix(strings.TrimSuffix(baseURL, "/"), "/api/v3")
+	}
+	c, err := github.NewEnterpriseClient(baseURL, uploadURL, hc)
+	return c, errors.Wrap(err, errNewEnterpriseClient)
 }
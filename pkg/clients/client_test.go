@@ -0,0 +1,277 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/v1beta1"
+)
+
+func TestGetConfig(t *testing.T) {
+	mg := &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "default"}}}
+
+	type want struct {
+		cfg *Config
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		want   want
+	}{
+		"ProviderConfigNotFound": {
+			reason: "A missing ProviderConfig should be reported by name.",
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(
+				kerrors.NewNotFound(schema.GroupResource{Group: v1beta1.Group, Resource: "providerconfigs"}, "default"),
+			)},
+			want: want{err: errors.New(`ProviderConfig "default" not found`)},
+		},
+		"GetProviderConfigError": {
+			reason: "Other errors getting the ProviderConfig should be wrapped.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want:   want{err: errors.Wrap(errBoom, "cannot get referenced ProviderConfig")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := GetConfig(context.Background(), tc.kube, mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetConfig(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cfg, cfg); diff != "" {
+				t.Errorf("\n%s\nGetConfig(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetConfigUsageTracking(t *testing.T) {
+	mg := &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "default"}}}
+
+	cases := map[string]struct {
+		reason  string
+		disable *bool
+		want    bool
+	}{
+		"Default": {
+			reason: "Usage of the ProviderConfig should be tracked by default.",
+			want:   true,
+		},
+		"Enabled": {
+			reason:  "Usage of the ProviderConfig should be tracked when tracking is not disabled.",
+			disable: func() *bool { b := false; return &b }(),
+			want:    true,
+		},
+		"Disabled": {
+			reason:  "Usage of the ProviderConfig should not be tracked when tracking is disabled.",
+			disable: func() *bool { b := true; return &b }(),
+			want:    false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tracked := false
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, o client.Object) error {
+					switch o := o.(type) {
+					case *v1beta1.ProviderConfig:
+						o.SetName(key.Name)
+						o.Spec.Credentials.Source = xpv1.CredentialsSourceNone
+						o.Spec.DisableUsageTracking = tc.disable
+						return nil
+					case *v1beta1.ProviderConfigUsage:
+						return kerrors.NewNotFound(schema.GroupResource{Group: v1beta1.Group, Resource: "providerconfigusages"}, key.Name)
+					}
+					return errBoom
+				},
+				MockCreate: func(_ context.Context, o client.Object, _ ...client.CreateOption) error {
+					_, tracked = o.(*v1beta1.ProviderConfigUsage)
+					return nil
+				},
+			}
+			if _, err := GetConfig(context.Background(), kube, mg); err != nil {
+				t.Fatalf("\n%s\nGetConfig(...): %s\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, tracked); diff != "" {
+				t.Errorf("\n%s\nGetConfig(...): -want tracked, +got tracked:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNewConfig(t *testing.T) {
+	// withSecret returns a ProviderConfig whose credentials are read from a
+	// Secret, optionally authenticating as a GitHub App installation.
+	withSecret := func(app *v1beta1.AppCredentials) *v1beta1.ProviderConfig {
+		pc := &v1beta1.ProviderConfig{}
+		pc.Spec.Credentials.Source = xpv1.CredentialsSourceSecret
+		pc.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "github", Namespace: "crossplane-system"},
+			Key:             "credentials",
+		}
+		pc.Spec.Credentials.App = app
+		return pc
+	}
+	// secret returns a kube client that gets a Secret with the supplied
+	// credentials.
+	secret := func(credentials string) client.Client {
+		return &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, o client.Object) error {
+			s, ok := o.(*corev1.Secret)
+			if !ok {
+				return errBoom
+			}
+			s.Data = map[string][]byte{"credentials": []byte(credentials)}
+			return nil
+		}}
+	}
+	app := &v1beta1.AppCredentials{ID: 42, InstallationID: 7}
+
+	type args struct {
+		kube client.Client
+		pc   *v1beta1.ProviderConfig
+	}
+
+	type want struct {
+		cfg *Config
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"PersonalAccessToken": {
+			reason: "A ProviderConfig without App credentials should authenticate with its credentials as a token.",
+			args:   args{kube: secret("ghp_token"), pc: withSecret(nil)},
+			want:   want{cfg: &Config{Token: "ghp_token"}},
+		},
+		"App": {
+			reason: "A ProviderConfig with App credentials should authenticate as the App installation using its credentials as the private key.",
+			args:   args{kube: secret(testPrivateKey), pc: withSecret(app)},
+			want:   want{cfg: &Config{AppID: 42, InstallationID: 7, PrivateKey: []byte(testPrivateKey)}},
+		},
+		"AppWithToken": {
+			reason: "A ProviderConfig with App credentials and a token rather than a private key should return an error.",
+			args:   args{kube: secret("ghp_token"), pc: withSecret(app)},
+			want:   want{err: errors.New(errAppCredentialsNotKey)},
+		},
+		"NoCredentials": {
+			reason: "A ProviderConfig whose credentials are empty should return an error.",
+			args:   args{kube: secret(""), pc: withSecret(nil)},
+			want:   want{err: errors.New(errNoCredentials)},
+		},
+		"NoAppCredentials": {
+			reason: "A ProviderConfig with App credentials and an empty private key should return an error.",
+			args:   args{kube: secret(""), pc: withSecret(app)},
+			want:   want{err: errors.New(errAppCredentialsNotKey)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := NewConfig(context.Background(), tc.args.kube, tc.args.pc)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nNewConfig(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cfg, cfg); diff != "" {
+				t.Errorf("\n%s\nNewConfig(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNewGitHubClient(t *testing.T) {
+	type args struct {
+		baseURL   string
+		uploadURL string
+	}
+
+	type want struct {
+		baseURL   string
+		uploadURL string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"GitHub": {
+			reason: "An empty BaseURL should use github.com.",
+			want: want{
+				baseURL:   "https://api.github.com/",
+				uploadURL: "https://uploads.github.com/",
+			},
+		},
+		"Enterprise": {
+			reason: "The /api/v3/ path should be appended to an Enterprise BaseURL, and UploadURL should default to it.",
+			args:   args{baseURL: "https://ghe.example.com"},
+			want: want{
+				baseURL:   "https://ghe.example.com/api/v3/",
+				uploadURL: "https://ghe.example.com/api/uploads/",
+			},
+		},
+		"EnterpriseWithPath": {
+			reason: "An Enterprise BaseURL that already ends in /api/v3/ should be used as is.",
+			args:   args{baseURL: "https://ghe.example.com/api/v3/"},
+			want: want{
+				baseURL:   "https://ghe.example.com/api/v3/",
+				uploadURL: "https://ghe.example.com/api/uploads/",
+			},
+		},
+		"EnterpriseUploadURL": {
+			reason: "An Enterprise UploadURL should be used for uploads.",
+			args:   args{baseURL: "https://ghe.example.com/", uploadURL: "https://uploads.ghe.example.com/"},
+			want: want{
+				baseURL:   "https://ghe.example.com/api/v3/",
+				uploadURL: "https://uploads.ghe.example.com/api/uploads/",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, err := newGitHubClient(tc.args.baseURL, tc.args.uploadURL, nil)
+			if err != nil {
+				t.Fatalf("\n%s\nnewGitHubClient(...): %s\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.baseURL, c.BaseURL.String()); diff != "" {
+				t.Errorf("\n%s\nnewGitHubClient(...): -want BaseURL, +got BaseURL:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.uploadURL, c.UploadURL.String()); diff != "" {
+				t.Errorf("\n%s\nnewGitHubClient(...): -want UploadURL, +got UploadURL:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const errInvalidColor = "invalid color %q: must be a 3 or 6 digit hexadecimal value, optionally prefixed with #"
+
+var hexColor = regexp.MustCompile(`^[0-9a-f]{6}$`)
+
+// NormalizeColor converts a user supplied color such as "#FFF", "fff" or
+// "#A1B2C3" into the six lowercase hexadecimal digits, without a leading #,
+// that GitHub expects for colored resources such as labels.
+func NormalizeColor(c string) (string, error) {
+	n := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(c), "#"))
+	if len(n) == 3 {
+		n = string([]byte{n[0], n[0], n[1], n[1], n[2], n[2]})
+	}
+	if !hexColor.MatchString(n) {
+		return "", errors.Errorf(errInvalidColor, c)
+	}
+	return n, nil
+}
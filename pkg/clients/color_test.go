@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestNormalizeColor(t *testing.T) {
+	type want struct {
+		color string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		color  string
+		want   want
+	}{
+		"Normalized": {
+			reason: "A color in the form GitHub expects should be returned unchanged.",
+			color:  "ffffff",
+			want:   want{color: "ffffff"},
+		},
+		"LeadingHash": {
+			reason: "A leading # should be stripped.",
+			color:  "#d73a4a",
+			want:   want{color: "d73a4a"},
+		},
+		"Uppercase": {
+			reason: "Uppercase digits should be lowercased.",
+			color:  "#ABCDEF",
+			want:   want{color: "abcdef"},
+		},
+		"Shorthand": {
+			reason: "A three digit shorthand should be expanded.",
+			color:  "#fff",
+			want:   want{color: "ffffff"},
+		},
+		"ShorthandWithoutHash": {
+			reason: "A three digit shorthand without a # should be expanded.",
+			color:  "A1c",
+			want:   want{color: "aa11cc"},
+		},
+		"Whitespace": {
+			reason: "Surrounding whitespace should be ignored.",
+			color:  " #fff ",
+			want:   want{color: "ffffff"},
+		},
+		"InvalidHex": {
+			reason: "A color with digits that are not hexadecimal should be an error.",
+			color:  "#ggg",
+			want:   want{err: errors.Errorf(errInvalidColor, "#ggg")},
+		},
+		"Name": {
+			reason: "A color name should be an error.",
+			color:  "red",
+			want:   want{err: errors.Errorf(errInvalidColor, "red")},
+		},
+		"TooShort": {
+			reason: "A color of the wrong length should be an error.",
+			color:  "#ffff",
+			want:   want{err: errors.Errorf(errInvalidColor, "#ffff")},
+		},
+		"TooLong": {
+			reason: "A color with an alpha channel should be an error.",
+			color:  "#ffffff00",
+			want:   want{err: errors.Errorf(errInvalidColor, "#ffffff00")},
+		},
+		"Empty": {
+			reason: "An empty color should be an error.",
+			color:  "",
+			want:   want{err: errors.Errorf(errInvalidColor, "")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := NormalizeColor(tc.color)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nNormalizeColor(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.color, got); diff != "" {
+				t.Errorf("\n%s\nNormalizeColor(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+)
+
+// headerRequestID is the response header in which GitHub returns the ID of
+// the request. GitHub support can use it to trace a failed request.
+const headerRequestID = "X-GitHub-Request-Id"
+
+// IsAccepted returns true if the supplied error indicates GitHub accepted the
+// request with a 202 and is processing it asynchronously. Callers should
+// treat such a response as an operation in progress rather than a failure.
+func IsAccepted(err error) bool {
+	var ae *github.AcceptedError
+	return errors.As(err, &ae)
+}
+
+// IsNotFound returns true if the supplied error indicates that the requested
+// GitHub resource does not exist.
+func IsNotFound(err error) bool {
+	var er *github.ErrorResponse
+	return errors.As(err, &er) && er.Response != nil && er.Response.StatusCode == http.StatusNotFound
+}
+
+// RequestID returns the GitHub request ID of the response that caused the
+// supplied error, or an empty string if there is none.
+func RequestID(err error) string {
+	var (
+		er  *github.ErrorResponse
+		rl  *github.RateLimitError
+		arl *github.AbuseRateLimitError
+		res *http.Response
+	)
+	switch {
+	case errors.As(err, &er):
+		res = er.Response
+	case errors.As(err, &rl):
+		res = rl.Response
+	case errors.As(err, &arl):
+		res = arl.Response
+	}
+	if res == nil {
+		return ""
+	}
+	return res.Header.Get(headerRequestID)
+}
+
+// Wrap an error returned by the GitHub API with the supplied message, like
+// errors.Wrap, appending the GitHub request ID when there is one so that
+// users can quote it to GitHub support. Wrap returns nil if err is nil.
+func Wrap(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	if id := RequestID(err); id != "" {
+		return errors.Wrapf(err, "%s (GitHub request ID %s)", message, id)
+	}
+	return errors.Wrap(err, message)
+}
+
+// Wrapf is like Wrap, but formats the message according to a format
+// specifier.
+func Wrapf(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return Wrap(err, fmt.Sprintf(format, args...))
+}
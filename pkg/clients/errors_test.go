@@ -0,0 +1,190 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+)
+
+var errBoom = errors.New("boom")
+
+// errorResponse returns the error go-github returns for a response with the
+// supplied status and GitHub request ID.
+func errorResponse(status int, id string) *github.ErrorResponse {
+	res := &http.Response{StatusCode: status, Header: http.Header{}, Request: &http.Request{Method: http.MethodGet}}
+	if id != "" {
+		res.Header.Set(headerRequestID, id)
+	}
+	return &github.ErrorResponse{Response: res, Message: "Not Found"}
+}
+
+func TestRequestID(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   string
+	}{
+		"ErrorResponse": {
+			reason: "The request ID of an error response should be returned.",
+			err:    errorResponse(http.StatusNotFound, "CAFE:1234"),
+			want:   "CAFE:1234",
+		},
+		"Wrapped": {
+			reason: "The request ID of a wrapped error response should be returned.",
+			err:    errors.Wrap(errorResponse(http.StatusNotFound, "CAFE:1234"), "cannot get"),
+			want:   "CAFE:1234",
+		},
+		"RateLimitError": {
+			reason: "The request ID of a rate limit error should be returned.",
+			err:    &github.RateLimitError{Response: errorResponse(http.StatusForbidden, "CAFE:5678").Response},
+			want:   "CAFE:5678",
+		},
+		"NoHeader": {
+			reason: "A response without a request ID should have none.",
+			err:    errorResponse(http.StatusNotFound, ""),
+			want:   "",
+		},
+		"NoResponse": {
+			reason: "An error response without a response should have no request ID.",
+			err:    &github.ErrorResponse{},
+			want:   "",
+		},
+		"OtherError": {
+			reason: "An error that is not from GitHub should have no request ID.",
+			err:    errBoom,
+			want:   "",
+		},
+		"Nil": {
+			reason: "A nil error should have no request ID.",
+			want:   "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := RequestID(tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nRequestID(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	withID := errorResponse(http.StatusNotFound, "CAFE:1234")
+
+	type want struct {
+		msg   string
+		cause error
+	}
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"RequestID": {
+			reason: "The request ID should be appended to the message of a wrapped error response.",
+			err:    withID,
+			want:   want{msg: "cannot get thing (GitHub request ID CAFE:1234): " + withID.Error(), cause: withID},
+		},
+		"NoResponse": {
+			reason: "An error without a response, such as a failure to connect, should be wrapped without a request ID.",
+			err:    errBoom,
+			want:   want{msg: "cannot get thing: boom", cause: errBoom},
+		},
+		"Nil": {
+			reason: "A nil error should not be wrapped.",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Wrap(tc.err, "cannot get thing")
+			if tc.want.cause == nil {
+				if err != nil {
+					t.Errorf("\n%s\nWrap(...): want nil, got %v", tc.reason, err)
+				}
+				return
+			}
+			if diff := cmp.Diff(tc.want.msg, err.Error()); diff != "" {
+				t.Errorf("\n%s\nWrap(...): -want message, +got message:\n%s\n", tc.reason, diff)
+			}
+			if errors.Cause(err) != tc.want.cause {
+				t.Errorf("\n%s\nWrap(...): want cause %v, got %v", tc.reason, tc.want.cause, errors.Cause(err))
+			}
+		})
+	}
+}
+
+func TestWrapf(t *testing.T) {
+	err := Wrapf(errorResponse(http.StatusNotFound, "CAFE:1234"), "cannot get organization %q", "crossplane")
+	want := `cannot get organization "crossplane" (GitHub request ID CAFE:1234)`
+	if got := err.Error(); len(got) < len(want) || got[:len(want)] != want {
+		t.Errorf("Wrapf(...): want message starting %q, got %q", want, got)
+	}
+	if err := Wrapf(nil, "cannot get organization %q", "crossplane"); err != nil {
+		t.Errorf("Wrapf(...): want nil, got %v", err)
+	}
+}
+
+func TestIsAccepted(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   bool
+	}{
+		"Accepted": {
+			reason: "An accepted error should be detected.",
+			err:    &github.AcceptedError{},
+			want:   true,
+		},
+		"Wrapped": {
+			reason: "A wrapped accepted error should be detected.",
+			err:    Wrap(&github.AcceptedError{Raw: []byte(`{}`)}, "cannot fork repository"),
+			want:   true,
+		},
+		"ErrorResponse": {
+			reason: "An error response should not be an accepted error.",
+			err:    errorResponse(http.StatusNotFound, ""),
+			want:   false,
+		},
+		"OtherError": {
+			reason: "Other errors should not be accepted errors.",
+			err:    errBoom,
+			want:   false,
+		},
+		"Nil": {
+			reason: "A nil error should not be an accepted error.",
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsAccepted(tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsAccepted(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
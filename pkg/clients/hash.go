@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// hashVersion identifies how VersionedHash hashes data. It must change if the
+// hashing does, so that hashes recorded by earlier versions are recognized.
+const hashVersion = "v1"
+
+const hashVersionSeparator = ":"
+
+// Hash returns the hex encoded SHA-256 hash of the supplied data. It is used
+// to detect changes to write-only values, such as secrets, that GitHub never
+// returns.
+func Hash(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+// VersionedHash returns the hash of the supplied data prefixed with the
+// version of the hashing used, for example "v1:<hex>".
+func VersionedHash(data []byte) string {
+	return hashVersion + hashVersionSeparator + Hash(data)
+}
+
+// HashMatches returns true if the supplied recorded hash is the hash of the
+// supplied data. The recorded hash may be versioned, as returned by
+// VersionedHash, or a legacy unversioned hash as returned by Hash. Hashes of
+// an unknown version never match.
+func HashMatches(recorded string, data []byte) bool {
+	i := strings.Index(recorded, hashVersionSeparator)
+	if i < 0 {
+		return recorded == Hash(data)
+	}
+	switch recorded[:i] {
+	case hashVersion:
+		return recorded[i+1:] == Hash(data)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// legacyHash is the unversioned hash of "s3cret", as recorded before hashes
+// were versioned.
+const legacyHash = "1ec1c26b50d5d3c58d9583181af8076655fe00756bf7285940ba3670f99fcba0"
+
+func TestVersionedHash(t *testing.T) {
+	want := "v1:" + legacyHash
+	if diff := cmp.Diff(want, VersionedHash([]byte("s3cret"))); diff != "" {
+		t.Errorf("VersionedHash(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestHashMatches(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		recorded string
+		data     string
+		want     bool
+	}{
+		"Versioned": {
+			reason:   "A versioned hash of the data should match.",
+			recorded: "v1:" + legacyHash,
+			data:     "s3cret",
+			want:     true,
+		},
+		"VersionedChanged": {
+			reason:   "A versioned hash of different data should not match.",
+			recorded: "v1:" + legacyHash,
+			data:     "changed",
+			want:     false,
+		},
+		"Legacy": {
+			reason:   "A legacy unversioned hash of the data should match.",
+			recorded: legacyHash,
+			data:     "s3cret",
+			want:     true,
+		},
+		"LegacyChanged": {
+			reason:   "A legacy unversioned hash of different data should not match.",
+			recorded: legacyHash,
+			data:     "changed",
+			want:     false,
+		},
+		"UnknownVersion": {
+			reason:   "A hash of an unknown version should never match.",
+			recorded: "v2:" + legacyHash,
+			data:     "s3cret",
+			want:     false,
+		},
+		"Empty": {
+			reason:   "A value that was never recorded should not match.",
+			recorded: "",
+			data:     "s3cret",
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := HashMatches(tc.recorded, []byte(tc.data))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nHashMatches(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
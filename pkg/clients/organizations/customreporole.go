@@ -0,0 +1,208 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizations
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane-contrib/provider-github/apis/organizations/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+// CustomRepoRole is a custom repository role of an organization.
+type CustomRepoRole struct {
+	ID          *int64   `json:"id,omitempty"`
+	Name        *string  `json:"name,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	BaseRole    *string  `json:"base_role,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// CustomRepoRoleOptions is the request to create or update a custom
+// repository role.
+type CustomRepoRoleOptions struct {
+	Name        *string  `json:"name,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	BaseRole    *string  `json:"base_role,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+
+type customRepoRoles struct {
+	TotalCount      int               `json:"total_count"`
+	CustomRepoRoles []*CustomRepoRole `json:"custom_roles"`
+}
+
+// CustomRepoRoleService defines the operations used to manage the custom
+// repository roles of an organization. go-github v33 does not wrap the custom
+// repository roles API, which only gained equivalent methods in v45, so the
+// service builds those requests itself.
+type CustomRepoRoleService interface {
+	ListCustomRepoRoles(ctx context.Context, org string) ([]*CustomRepoRole, *github.Response, error)
+	CreateCustomRepoRole(ctx context.Context, org string, opts *CustomRepoRoleOptions) (*CustomRepoRole, *github.Response, error)
+	UpdateCustomRepoRole(ctx context.Context, org string, roleID int64, opts *CustomRepoRoleOptions) (*CustomRepoRole, *github.Response, error)
+	DeleteCustomRepoRole(ctx context.Context, org string, roleID int64) (*github.Response, error)
+}
+
+// NewCustomRepoRoleService creates a new CustomRepoRoleService backed by a
+// GitHub client built from the supplied Config.
+func NewCustomRepoRoleService(cfg *ghclient.Config) (CustomRepoRoleService, error) {
+	c, err := ghclient.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &customRepoRoleService{client: c}, nil
+}
+
+type customRepoRoleService struct {
+	client *github.Client
+}
+
+func customRepoRolesPath(org string) string {
+	return fmt.Sprintf("orgs/%v/custom-repository-roles", org)
+}
+
+func (s *customRepoRoleService) ListCustomRepoRoles(ctx context.Context, org string) ([]*CustomRepoRole, *github.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, customRepoRolesPath(org), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	roles := &customRepoRoles{}
+	res, err := s.client.Do(ctx, req, roles)
+	if err != nil {
+		return nil, res, err
+	}
+	return roles.CustomRepoRoles, res, nil
+}
+
+func (s *customRepoRoleService) CreateCustomRepoRole(ctx context.Context, org string, opts *CustomRepoRoleOptions) (*CustomRepoRole, *github.Response, error) {
+	req, err := s.client.NewRequest(http.MethodPost, customRepoRolesPath(org), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	role := &CustomRepoRole{}
+	res, err := s.client.Do(ctx, req, role)
+	if err != nil {
+		return nil, res, err
+	}
+	return role, res, nil
+}
+
+func (s *customRepoRoleService) UpdateCustomRepoRole(ctx context.Context, org string, roleID int64, opts *CustomRepoRoleOptions) (*CustomRepoRole, *github.Response, error) {
+	req, err := s.client.NewRequest(http.MethodPatch, fmt.Sprintf("%v/%v", customRepoRolesPath(org), roleID), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	role := &CustomRepoRole{}
+	res, err := s.client.Do(ctx, req, role)
+	if err != nil {
+		return nil, res, err
+	}
+	return role, res, nil
+}
+
+func (s *customRepoRoleService) DeleteCustomRepoRole(ctx context.Context, org string, roleID int64) (*github.Response, error) {
+	req, err := s.client.NewRequest(http.MethodDelete, fmt.Sprintf("%v/%v", customRepoRolesPath(org), roleID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, req, nil)
+}
+
+// FindCustomRepoRole returns the role with the supplied ID among the supplied
+// roles, or nil if there is none.
+func FindCustomRepoRole(roles []*CustomRepoRole, id int64) *CustomRepoRole {
+	for _, r := range roles {
+		if r.GetID() == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// GetID returns the ID of the role, or zero if it is not set.
+func (r *CustomRepoRole) GetID() int64 {
+	if r == nil || r.ID == nil {
+		return 0
+	}
+	return *r.ID
+}
+
+// GenerateCustomRepoRoleOptions produces the request to create or update a
+// custom repository role from the supplied OrgCustomRepoRoleParameters.
+func GenerateCustomRepoRoleOptions(p v1alpha1.OrgCustomRepoRoleParameters) *CustomRepoRoleOptions {
+	return &CustomRepoRoleOptions{
+		Name:        github.String(p.Name),
+		Description: p.Description,
+		BaseRole:    github.String(p.BaseRole),
+		Permissions: p.Permissions,
+	}
+}
+
+// GenerateCustomRepoRoleObservation produces an OrgCustomRepoRoleObservation
+// from the supplied role.
+func GenerateCustomRepoRoleObservation(r *CustomRepoRole) v1alpha1.OrgCustomRepoRoleObservation {
+	return v1alpha1.OrgCustomRepoRoleObservation{
+		ID:          r.ID,
+		BaseRole:    r.BaseRole,
+		Permissions: r.Permissions,
+	}
+}
+
+// LateInitializeCustomRepoRole fills the unset fields of the supplied
+// OrgCustomRepoRoleParameters with the values of the supplied role.
+func LateInitializeCustomRepoRole(p *v1alpha1.OrgCustomRepoRoleParameters, r *CustomRepoRole) {
+	if p.Description == nil {
+		p.Description = r.Description
+	}
+}
+
+// IsCustomRepoRoleUpToDate returns true if the supplied role matches the
+// supplied OrgCustomRepoRoleParameters. Permissions are compared regardless
+// of their order.
+func IsCustomRepoRoleUpToDate(p v1alpha1.OrgCustomRepoRoleParameters, r *CustomRepoRole) bool {
+	if r.Name == nil || p.Name != *r.Name {
+		return false
+	}
+	if r.BaseRole == nil || p.BaseRole != *r.BaseRole {
+		return false
+	}
+	if p.Description != nil && (r.Description == nil || *p.Description != *r.Description) {
+		return false
+	}
+	return equalSets(p.Permissions, r.Permissions)
+}
+
+func equalSets(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
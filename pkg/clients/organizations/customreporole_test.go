@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizations
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane-contrib/provider-github/apis/organizations/v1alpha1"
+)
+
+// newTestService returns a CustomRepoRoleService whose requests are served
+// by the supplied handler.
+func newTestService(t *testing.T, h http.HandlerFunc) CustomRepoRoleService {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	c := github.NewClient(srv.Client())
+	u, err := url.Parse(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.BaseURL = u
+	return &customRepoRoleService{client: c}
+}
+
+func TestListCustomRepoRoles(t *testing.T) {
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/orgs/crossplane/custom-repository-roles" {
+			t.Errorf("ListCustomRepoRoles(...): unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"total_count": 1, "custom_roles": [{"id": 8030, "name": "security-reviewer", "base_role": "read", "permissions": ["delete_alerts_code_scanning"]}]}`))
+	})
+	roles, _, err := s.ListCustomRepoRoles(context.Background(), "crossplane")
+	if err != nil {
+		t.Fatalf("ListCustomRepoRoles(...): %v", err)
+	}
+	want := []*CustomRepoRole{{
+		ID:          github.Int64(8030),
+		Name:        github.String("security-reviewer"),
+		BaseRole:    github.String("read"),
+		Permissions: []string{"delete_alerts_code_scanning"},
+	}}
+	if diff := cmp.Diff(want, roles); diff != "" {
+		t.Errorf("ListCustomRepoRoles(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestCreateCustomRepoRole(t *testing.T) {
+	var got map[string]interface{}
+	s := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/orgs/crossplane/custom-repository-roles" {
+			t.Errorf("CreateCustomRepoRole(...): unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Errorf("CreateCustomRepoRole(...): cannot decode request: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 8030}`))
+	})
+	p := v1alpha1.OrgCustomRepoRoleParameters{
+		Organization: "crossplane",
+		Name:         "security-reviewer",
+		BaseRole:     "read",
+		Permissions:  []string{"delete_alerts_code_scanning"},
+	}
+	r, _, err := s.CreateCustomRepoRole(context.Background(), "crossplane", GenerateCustomRepoRoleOptions(p))
+	if err != nil {
+		t.Fatalf("CreateCustomRepoRole(...): %v", err)
+	}
+	if r.GetID() != 8030 {
+		t.Errorf("CreateCustomRepoRole(...): want ID 8030, got %d", r.GetID())
+	}
+	want := map[string]interface{}{
+		"name":        "security-reviewer",
+		"base_role":   "read",
+		"permissions": []interface{}{"delete_alerts_code_scanning"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CreateCustomRepoRole(...): -want request, +got request:\n%s", diff)
+	}
+}
+
+func TestIsCustomRepoRoleUpToDate(t *testing.T) {
+	params := func(base string, perms ...string) v1alpha1.OrgCustomRepoRoleParameters {
+		return v1alpha1.OrgCustomRepoRoleParameters{Organization: "crossplane", Name: "security-reviewer", BaseRole: base, Permissions: perms}
+	}
+	role := func(base string, perms ...string) *CustomRepoRole {
+		return &CustomRepoRole{ID: github.Int64(8030), Name: github.String("security-reviewer"), BaseRole: github.String(base), Permissions: perms}
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.OrgCustomRepoRoleParameters
+		r      *CustomRepoRole
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A role with the desired base role and permissions should be up to date.",
+			p:      params("read", "delete_alerts_code_scanning", "add_label"),
+			r:      role("read", "delete_alerts_code_scanning", "add_label"),
+			want:   true,
+		},
+		"PermissionsReordered": {
+			reason: "Permissions should be compared regardless of their order.",
+			p:      params("read", "add_label", "delete_alerts_code_scanning"),
+			r:      role("read", "delete_alerts_code_scanning", "add_label"),
+			want:   true,
+		},
+		"PermissionAdded": {
+			reason: "A role missing a desired permission should not be up to date.",
+			p:      params("read", "add_label", "delete_alerts_code_scanning"),
+			r:      role("read", "add_label"),
+			want:   false,
+		},
+		"PermissionReplaced": {
+			reason: "A role with a different permission should not be up to date.",
+			p:      params("read", "add_label"),
+			r:      role("read", "remove_label"),
+			want:   false,
+		},
+		"BaseRoleChanged": {
+			reason: "A role with a different base role should not be up to date.",
+			p:      params("write", "add_label"),
+			r:      role("read", "add_label"),
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsCustomRepoRoleUpToDate(tc.p, tc.r)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsCustomRepoRoleUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
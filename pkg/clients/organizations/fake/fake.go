@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides fakes of the services used to manage organization
+// resources.
+package fake
+
+import (
+	"context"
+
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane-contrib/provider-github/pkg/clients/organizations"
+)
+
+// MockCustomRepoRoleService is a mock CustomRepoRoleService.
+type MockCustomRepoRoleService struct {
+	MockListCustomRepoRoles  func(ctx context.Context, org string) ([]*organizations.CustomRepoRole, *github.Response, error)
+	MockCreateCustomRepoRole func(ctx context.Context, org string, opts *organizations.CustomRepoRoleOptions) (*organizations.CustomRepoRole, *github.Response, error)
+	MockUpdateCustomRepoRole func(ctx context.Context, org string, roleID int64, opts *organizations.CustomRepoRoleOptions) (*organizations.CustomRepoRole, *github.Response, error)
+	MockDeleteCustomRepoRole func(ctx context.Context, org string, roleID int64) (*github.Response, error)
+}
+
+// ListCustomRepoRoles calls MockListCustomRepoRoles.
+func (m *MockCustomRepoRoleService) ListCustomRepoRoles(ctx context.Context, org string) ([]*organizations.CustomRepoRole, *github.Response, error) {
+	return m.MockListCustomRepoRoles(ctx, org)
+}
+
+// CreateCustomRepoRole calls MockCreateCustomRepoRole.
+func (m *MockCustomRepoRoleService) CreateCustomRepoRole(ctx context.Context, org string, opts *organizations.CustomRepoRoleOptions) (*organizations.CustomRepoRole, *github.Response, error) {
+	return m.MockCreateCustomRepoRole(ctx, org, opts)
+}
+
+// UpdateCustomRepoRole calls MockUpdateCustomRepoRole.
+func (m *MockCustomRepoRoleService) UpdateCustomRepoRole(ctx context.Context, org string, roleID int64, opts *organizations.CustomRepoRoleOptions) (*organizations.CustomRepoRole, *github.Response, error) {
+	return m.MockUpdateCustomRepoRole(ctx, org, roleID, opts)
+}
+
+// DeleteCustomRepoRole calls MockDeleteCustomRepoRole.
+func (m *MockCustomRepoRoleService) DeleteCustomRepoRole(ctx context.Context, org string, roleID int64) (*github.Response, error) {
+	return m.MockDeleteCustomRepoRole(ctx, org, roleID)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"github.com/google/go-github/v33/github"
+)
+
+// MaxPerPage is the largest page size GitHub's list endpoints accept.
+const MaxPerPage = 100
+
+// ListAll calls list once for each page of results, starting with the page
+// set in the supplied options, until GitHub reports there are no more pages.
+// list must use the supplied options for its request and collect the items
+// of each page itself, and may return a nil Response to stop before the last
+// page. The page size defaults to MaxPerPage.
+func ListAll(opts *github.ListOptions, list func(opts *github.ListOptions) (*github.Response, error)) error {
+	if opts.PerPage == 0 {
+		opts.PerPage = MaxPerPage
+	}
+	for {
+		res, err := list(opts)
+		if err != nil {
+			return err
+		}
+		if res == nil || res.NextPage == 0 {
+			return nil
+		}
+		opts.Page = res.NextPage
+	}
+}
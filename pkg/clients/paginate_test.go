@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestListAll(t *testing.T) {
+	// pages are the items GitHub returns on each page, which it numbers from
+	// one.
+	pages := [][]string{{"a", "b"}, {"c"}}
+
+	type want struct {
+		items []string
+		pages []int
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		stop   string
+		err    error
+		want   want
+	}{
+		"AllPages": {
+			reason: "The items of every page should be collected until there is no next page.",
+			want:   want{items: []string{"a", "b", "c"}, pages: []int{0, 2}},
+		},
+		"StopEarly": {
+			reason: "A nil response should stop listing before the last page.",
+			stop:   "b",
+			want:   want{items: []string{"a", "b"}, pages: []int{0}},
+		},
+		"Error": {
+			reason: "Errors listing a page should be returned as is.",
+			err:    errBoom,
+			want:   want{pages: []int{0}, err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var items []string
+			var requested []int
+			err := ListAll(&github.ListOptions{}, func(opts *github.ListOptions) (*github.Response, error) {
+				requested = append(requested, opts.Page)
+				if opts.PerPage != MaxPerPage {
+					t.Errorf("ListAll(...): want %d items per page, got %d", MaxPerPage, opts.PerPage)
+				}
+				if tc.err != nil {
+					return nil, tc.err
+				}
+				page := opts.Page
+				if page == 0 {
+					page = 1
+				}
+				res := &github.Response{}
+				if page < len(pages) {
+					res.NextPage = page + 1
+				}
+				for _, i := range pages[page-1] {
+					items = append(items, i)
+					if i == tc.stop {
+						return nil, nil
+					}
+				}
+				return res, nil
+			})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nListAll(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.items, items); diff != "" {
+				t.Errorf("\n%s\nListAll(...): -want items, +got items:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pages, requested); diff != "" {
+				t.Errorf("\n%s\nListAll(...): -want pages requested, +got pages requested:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestListAllPageSize(t *testing.T) {
+	var got int
+	_ = ListAll(&github.ListOptions{PerPage: 10}, func(opts *github.ListOptions) (*github.Response, error) {
+		got = opts.PerPage
+		return &github.Response{}, nil
+	})
+	if diff := cmp.Diff(10, got); diff != "" {
+		t.Errorf("ListAll(...): a set page size should be kept: -want, +got:\n%s\n", diff)
+	}
+}
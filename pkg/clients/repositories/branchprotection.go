@@ -0,0 +1,201 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+// BranchProtectionService defines the Repositories operations used to manage
+// a BranchProtection.
+type BranchProtectionService interface {
+	GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error)
+	UpdateBranchProtection(ctx context.Context, owner, repo, branch string, preq *github.ProtectionRequest) (*github.Protection, *github.Response, error)
+	RemoveBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Response, error)
+}
+
+// NewBranchProtectionService creates a new BranchProtectionService backed by
+// the Repositories service of a GitHub client built from the supplied Config.
+func NewBranchProtectionService(cfg *ghclient.Config) (BranchProtectionService, error) {
+	c, err := ghclient.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c.Repositories, nil
+}
+
+// GenerateBranchProtectionObservation produces a BranchProtectionObservation
+// from the supplied protection.
+func GenerateBranchProtectionObservation(pr *github.Protection) v1alpha1.BranchProtectionObservation {
+	o := v1alpha1.BranchProtectionObservation{}
+	if pr.EnforceAdmins != nil {
+		o.EnforceAdmins = pr.EnforceAdmins.Enabled
+	}
+	if pr.RequiredStatusChecks != nil {
+		o.RequiredStatusChecks = pr.RequiredStatusChecks.Contexts
+	}
+	if pr.RequiredPullRequestReviews != nil {
+		o.RequiredApprovingReviewCount = &pr.RequiredPullRequestReviews.RequiredApprovingReviewCount
+	}
+	return o
+}
+
+// LateInitializeBranchProtection fills the unset fields of the supplied
+// BranchProtectionParameters with the values of the supplied protection.
+func LateInitializeBranchProtection(p *v1alpha1.BranchProtectionParameters, pr *github.Protection) {
+	if p.RequireLinearHistory == nil && pr.RequireLinearHistory != nil {
+		p.RequireLinearHistory = &pr.RequireLinearHistory.Enabled
+	}
+	if p.AllowForcePushes == nil && pr.AllowForcePushes != nil {
+		p.AllowForcePushes = &pr.AllowForcePushes.Enabled
+	}
+	if p.AllowDeletions == nil && pr.AllowDeletions != nil {
+		p.AllowDeletions = &pr.AllowDeletions.Enabled
+	}
+}
+
+// IsBranchProtectionUpToDate returns true if the supplied protection matches
+// the supplied BranchProtectionParameters. Lists are compared without regard
+// to order, and fields GitHub populates, such as URLs, are ignored.
+func IsBranchProtectionUpToDate(p v1alpha1.BranchProtectionParameters, pr *github.Protection) bool {
+	p = *p.DeepCopy()
+	if rv := p.RequiredPullRequestReviews; rv != nil && isEmptyDismissalRestrictions(rv.DismissalRestrictions) {
+		// GitHub reports no dismissal restrictions when none are listed.
+		rv.DismissalRestrictions = nil
+	}
+	observed := generateBranchProtectionParameters(pr)
+	observed.Owner, observed.Repository, observed.Branch = p.Owner, p.Repository, p.Branch
+	if p.RequireLinearHistory == nil {
+		observed.RequireLinearHistory = nil
+	}
+	if p.AllowForcePushes == nil {
+		observed.AllowForcePushes = nil
+	}
+	if p.AllowDeletions == nil {
+		observed.AllowDeletions = nil
+	}
+	return cmp.Equal(p, observed, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
+
+// generateBranchProtectionParameters produces the BranchProtectionParameters
+// that describe the supplied protection, leaving the branch unset.
+func generateBranchProtectionParameters(pr *github.Protection) v1alpha1.BranchProtectionParameters {
+	p := v1alpha1.BranchProtectionParameters{}
+	if c := pr.RequiredStatusChecks; c != nil {
+		p.RequiredStatusChecks = &v1alpha1.StatusChecks{Strict: c.Strict, Contexts: c.Contexts}
+	}
+	if r := pr.RequiredPullRequestReviews; r != nil {
+		p.RequiredPullRequestReviews = &v1alpha1.PullRequestReviews{
+			DismissStaleReviews:          r.DismissStaleReviews,
+			RequireCodeOwnerReviews:      r.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: r.RequiredApprovingReviewCount,
+		}
+		if d := r.DismissalRestrictions; d != nil {
+			dr := &v1alpha1.DismissalRestrictions{Users: userLogins(d.Users), Teams: teamSlugs(d.Teams)}
+			if !isEmptyDismissalRestrictions(dr) {
+				p.RequiredPullRequestReviews.DismissalRestrictions = dr
+			}
+		}
+	}
+	if pr.EnforceAdmins != nil {
+		p.EnforceAdmins = pr.EnforceAdmins.Enabled
+	}
+	if r := pr.Restrictions; r != nil {
+		p.Restrictions = &v1alpha1.BranchRestrictions{Users: userLogins(r.Users), Teams: teamSlugs(r.Teams)}
+		for _, a := range r.Apps {
+			p.Restrictions.Apps = append(p.Restrictions.Apps, a.GetSlug())
+		}
+	}
+	if pr.RequireLinearHistory != nil {
+		p.RequireLinearHistory = &pr.RequireLinearHistory.Enabled
+	}
+	if pr.AllowForcePushes != nil {
+		p.AllowForcePushes = &pr.AllowForcePushes.Enabled
+	}
+	if pr.AllowDeletions != nil {
+		p.AllowDeletions = &pr.AllowDeletions.Enabled
+	}
+	return p
+}
+
+// GenerateProtectionRequest produces the request that protects a branch as
+// described by the supplied BranchProtectionParameters.
+func GenerateProtectionRequest(p v1alpha1.BranchProtectionParameters) *github.ProtectionRequest {
+	r := &github.ProtectionRequest{
+		EnforceAdmins:        p.EnforceAdmins,
+		RequireLinearHistory: p.RequireLinearHistory,
+		AllowForcePushes:     p.AllowForcePushes,
+		AllowDeletions:       p.AllowDeletions,
+	}
+	if c := p.RequiredStatusChecks; c != nil {
+		r.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: c.Strict, Contexts: nonNil(c.Contexts)}
+	}
+	if rv := p.RequiredPullRequestReviews; rv != nil {
+		r.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          rv.DismissStaleReviews,
+			RequireCodeOwnerReviews:      rv.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: rv.RequiredApprovingReviewCount,
+		}
+		if d := rv.DismissalRestrictions; d != nil {
+			users, teams := nonNil(d.Users), nonNil(d.Teams)
+			r.RequiredPullRequestReviews.DismissalRestrictionsRequest = &github.DismissalRestrictionsRequest{Users: &users, Teams: &teams}
+		}
+	}
+	if rs := p.Restrictions; rs != nil {
+		r.Restrictions = &github.BranchRestrictionsRequest{Users: nonNil(rs.Users), Teams: nonNil(rs.Teams), Apps: rs.Apps}
+	}
+	return r
+}
+
+// isEmptyDismissalRestrictions returns true if the supplied dismissal
+// restrictions list neither users nor teams, which GitHub treats the same as
+// no dismissal restrictions.
+func isEmptyDismissalRestrictions(d *v1alpha1.DismissalRestrictions) bool {
+	return d == nil || (len(d.Users) == 0 && len(d.Teams) == 0)
+}
+
+func userLogins(users []*github.User) []string {
+	logins := make([]string, 0, len(users))
+	for _, u := range users {
+		logins = append(logins, u.GetLogin())
+	}
+	return logins
+}
+
+func teamSlugs(teams []*github.Team) []string {
+	slugs := make([]string, 0, len(teams))
+	for _, t := range teams {
+		slugs = append(slugs, t.GetSlug())
+	}
+	return slugs
+}
+
+// nonNil returns s, or an empty slice if s is nil. GitHub requires some lists
+// to be present even when empty.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+)
+
+func TestIsBranchProtectionUpToDate(t *testing.T) {
+	protection := func() *github.Protection {
+		return &github.Protection{
+			RequiredStatusChecks: &github.RequiredStatusChecks{Strict: true, Contexts: []string{"build", "test"}},
+			RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+				RequiredApprovingReviewCount: 1,
+			},
+			EnforceAdmins: &github.AdminEnforcement{URL: github.String("https://api.github.com/enforce_admins"), Enabled: false},
+		}
+	}
+	params := func() v1alpha1.BranchProtectionParameters {
+		return v1alpha1.BranchProtectionParameters{
+			Owner:                      "crossplane",
+			Repository:                 "provider-github",
+			Branch:                     "main",
+			RequiredStatusChecks:       &v1alpha1.StatusChecks{Strict: true, Contexts: []string{"test", "build"}},
+			RequiredPullRequestReviews: &v1alpha1.PullRequestReviews{RequiredApprovingReviewCount: 1},
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      func() v1alpha1.BranchProtectionParameters
+		pr     func() *github.Protection
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "Contexts in a different order and server populated URLs should not cause drift.",
+			p:      params,
+			pr:     protection,
+			want:   true,
+		},
+		"EnforceAdminsToggled": {
+			reason: "Enforcing the protection for admins should cause drift.",
+			p: func() v1alpha1.BranchProtectionParameters {
+				p := params()
+				p.EnforceAdmins = true
+				return p
+			},
+			pr:   protection,
+			want: false,
+		},
+		"ContextAdded": {
+			reason: "Requiring an additional status check should cause drift.",
+			p: func() v1alpha1.BranchProtectionParameters {
+				p := params()
+				p.RequiredStatusChecks.Contexts = append(p.RequiredStatusChecks.Contexts, "lint")
+				return p
+			},
+			pr:   protection,
+			want: false,
+		},
+		"ContextRemoved": {
+			reason: "No longer requiring a status check should cause drift.",
+			p: func() v1alpha1.BranchProtectionParameters {
+				p := params()
+				p.RequiredStatusChecks.Contexts = []string{"build"}
+				return p
+			},
+			pr:   protection,
+			want: false,
+		},
+		"EmptyDismissalRestrictions": {
+			reason: "Empty dismissal restrictions should be equivalent to none.",
+			p: func() v1alpha1.BranchProtectionParameters {
+				p := params()
+				p.RequiredPullRequestReviews.DismissalRestrictions = &v1alpha1.DismissalRestrictions{Users: []string{}}
+				return p
+			},
+			pr:   protection,
+			want: true,
+		},
+		"ObservedEmptyDismissalRestrictions": {
+			reason: "Dismissal restrictions GitHub reports with no users or teams should be equivalent to none.",
+			p:      params,
+			pr: func() *github.Protection {
+				pr := protection()
+				pr.RequiredPullRequestReviews.DismissalRestrictions = &github.DismissalRestrictions{}
+				return pr
+			},
+			want: true,
+		},
+		"DismissalRestrictionsAdded": {
+			reason: "Restricting who can dismiss reviews should cause drift.",
+			p: func() v1alpha1.BranchProtectionParameters {
+				p := params()
+				p.RequiredPullRequestReviews.DismissalRestrictions = &v1alpha1.DismissalRestrictions{Teams: []string{"admins"}}
+				return p
+			},
+			pr:   protection,
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsBranchProtectionUpToDate(tc.p(), tc.pr()); got != tc.want {
+				t.Errorf("\n%s\nIsBranchProtectionUpToDate(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+// Permission levels GitHub reports collaborators as having.
+const (
+	PermissionLevelRead  = "read"
+	PermissionLevelWrite = "write"
+	PermissionLevelAdmin = "admin"
+
+	defaultCollaboratorPermission = "push"
+)
+
+// CollaboratorService defines the Repositories operations used to manage a
+// Collaborator.
+type CollaboratorService interface {
+	IsCollaborator(ctx context.Context, owner, repo, user string) (bool, *github.Response, error)
+	ListCollaborators(ctx context.Context, owner, repo string, opts *github.ListCollaboratorsOptions) ([]*github.User, *github.Response, error)
+	GetPermissionLevel(ctx context.Context, owner, repo, user string) (*github.RepositoryPermissionLevel, *github.Response, error)
+	AddCollaborator(ctx context.Context, owner, repo, user string, opts *github.RepositoryAddCollaboratorOptions) (*github.CollaboratorInvitation, *github.Response, error)
+	RemoveCollaborator(ctx context.Context, owner, repo, user string) (*github.Response, error)
+	ListInvitations(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryInvitation, *github.Response, error)
+	UpdateInvitation(ctx context.Context, owner, repo string, invitationID int64, permissions string) (*github.RepositoryInvitation, *github.Response, error)
+	DeleteInvitation(ctx context.Context, owner, repo string, invitationID int64) (*github.Response, error)
+}
+
+// NewCollaboratorService creates a new CollaboratorService backed by the
+// Repositories service of a GitHub client built from the supplied Config.
+func NewCollaboratorService(cfg *ghclient.Config) (CollaboratorService, error) {
+	c, err := ghclient.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c.Repositories, nil
+}
+
+// CollaboratorPermission returns the permission the supplied
+// CollaboratorParameters grant.
+func CollaboratorPermission(p v1alpha1.CollaboratorParameters) string {
+	if p.Permission == nil {
+		return defaultCollaboratorPermission
+	}
+	return *p.Permission
+}
+
+// PermissionLevel returns the level GitHub reports for a collaborator with
+// the supplied permission, which may be a permission such as "push" or a
+// level such as "write".
+func PermissionLevel(permission string) string {
+	switch strings.ToLower(permission) {
+	case "admin":
+		return PermissionLevelAdmin
+	case "push", "maintain", "write":
+		return PermissionLevelWrite
+	case "pull", "triage", "read":
+		return PermissionLevelRead
+	}
+	return permission
+}
+
+// roles are the roles a collaborator may have, from the one granting the most
+// access to the one granting the least.
+var roles = []string{"admin", "maintain", "push", "triage", "pull"}
+
+// Role returns the role granted by the supplied permission, which may be a
+// role such as "maintain" or the permission of an invitation such as
+// "write". Unlike a permission level, a role tells "maintain" from "push"
+// and "triage" from "pull".
+func Role(permission string) string {
+	switch p := strings.ToLower(permission); p {
+	case PermissionLevelRead:
+		return "pull"
+	case PermissionLevelWrite:
+		return "push"
+	default:
+		return p
+	}
+}
+
+// CollaboratorRole returns the highest role of the supplied collaborator, as
+// listed by ListCollaborators, or an empty string if GitHub did not report
+// the collaborator's permissions.
+func CollaboratorRole(u *github.User) string {
+	perms := u.GetPermissions()
+	for _, r := range roles {
+		if perms[r] {
+			return r
+		}
+	}
+	return ""
+}
+
+// roleRank returns the rank of the supplied permission, which may be a role
+// or a level. Higher ranks grant more access.
+func roleRank(permission string) int {
+	r := Role(permission)
+	for i, role := range roles {
+		if role == r {
+			return len(roles) - i
+		}
+	}
+	return 0
+}
+
+// ExceedsPermission returns true if the supplied role or permission level
+// grants more access than the supplied permission.
+func ExceedsPermission(level, permission string) bool {
+	return roleRank(level) > roleRank(permission)
+}
+
+// InvitationPermission returns the permission of a repository invitation
+// corresponding to the supplied collaborator permission.
+func InvitationPermission(permission string) string {
+	switch permission {
+	case "pull":
+		return PermissionLevelRead
+	case "push":
+		return PermissionLevelWrite
+	}
+	return permission
+}
+
+// IsCollaboratorUpToDate returns true if a collaborator or invitation with
+// the supplied role has the permission granted by the supplied
+// CollaboratorParameters.
+func IsCollaboratorUpToDate(p v1alpha1.CollaboratorParameters, role string) bool {
+	return Role(CollaboratorPermission(p)) == Role(role)
+}
+
+// HasPermissionLevel returns true if a collaborator with the supplied
+// permission level has the level of the permission granted by the supplied
+// CollaboratorParameters. It is only used when GitHub reports no more than
+// the level, which doesn't tell "maintain" from "push" or "triage" from
+// "pull".
+func HasPermissionLevel(p v1alpha1.CollaboratorParameters, level string) bool {
+	return PermissionLevel(CollaboratorPermission(p)) == PermissionLevel(level)
+}
+
+// FindInvitation returns the invitation of the supplied user among the
+// supplied invitations, or nil if the user is not invited.
+func FindInvitation(invs []*github.RepositoryInvitation, user string) *github.RepositoryInvitation {
+	for _, i := range invs {
+		if strings.EqualFold(i.GetInvitee().GetLogin(), user) {
+			return i
+		}
+	}
+	return nil
+}
+
+// FindCollaborator returns the supplied user among the supplied collaborators,
+// or nil if the user is not one of them.
+func FindCollaborator(users []*github.User, user string) *github.User {
+	for _, u := range users {
+		if strings.EqualFold(u.GetLogin(), user) {
+			return u
+		}
+	}
+	return nil
+}
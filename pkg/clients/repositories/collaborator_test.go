@@ -0,0 +1,149 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+)
+
+func TestCollaboratorRole(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		u      *github.User
+		want   string
+	}{
+		"Maintain": {
+			reason: "The highest role a collaborator has should be returned.",
+			u:      &github.User{Permissions: &map[string]bool{"pull": true, "triage": true, "push": true, "maintain": true, "admin": false}},
+			want:   "maintain",
+		},
+		"Triage": {
+			reason: "Triage should be told apart from pull.",
+			u:      &github.User{Permissions: &map[string]bool{"pull": true, "triage": true, "push": false}},
+			want:   "triage",
+		},
+		"NoPermissions": {
+			reason: "A collaborator without reported permissions should have no role.",
+			u:      &github.User{},
+			want:   "",
+		},
+		"NotCollaborator": {
+			reason: "A user that was not found should have no role.",
+			want:   "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := CollaboratorRole(tc.u)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nCollaboratorRole(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsCollaboratorUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		permission string
+		role       string
+		want       bool
+	}{
+		"Push": {
+			reason:     "A collaborator with the desired role should be up to date.",
+			permission: "push",
+			role:       "push",
+			want:       true,
+		},
+		"InvitationWrite": {
+			reason:     "An invitation to write grants push.",
+			permission: "push",
+			role:       "write",
+			want:       true,
+		},
+		"PushToMaintain": {
+			reason:     "A collaborator who pushes should not be up to date if they should maintain.",
+			permission: "maintain",
+			role:       "push",
+			want:       false,
+		},
+		"PullToTriage": {
+			reason:     "A collaborator who pulls should not be up to date if they should triage.",
+			permission: "triage",
+			role:       "read",
+			want:       false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsCollaboratorUpToDate(v1alpha1.CollaboratorParameters{Permission: &tc.permission}, tc.role)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsCollaboratorUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestExceedsPermission(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		level      string
+		permission string
+		want       bool
+	}{
+		"MaintainExceedsPush": {
+			reason:     "Maintain grants more access than push.",
+			level:      "maintain",
+			permission: "push",
+			want:       true,
+		},
+		"WriteExceedsTriage": {
+			reason:     "The write level grants more access than triage.",
+			level:      "write",
+			permission: "triage",
+			want:       true,
+		},
+		"WriteDoesNotExceedMaintain": {
+			reason:     "The write level may be maintain, so it should not exceed it.",
+			level:      "write",
+			permission: "maintain",
+			want:       false,
+		},
+		"Equal": {
+			reason:     "A permission should not exceed itself.",
+			level:      "admin",
+			permission: "admin",
+			want:       false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ExceedsPermission(tc.level, tc.permission)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nExceedsPermission(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
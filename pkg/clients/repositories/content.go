@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+const (
+	errTemplateKind   = "exactly one of license and gitignore must be set in template"
+	errGetLicense     = "cannot get license template"
+	errGetGitignore   = "cannot get .gitignore template"
+	fmtTemplateMarker = "[%s]"
+)
+
+// ContentService defines the operations used to manage a Content.
+type ContentService interface {
+	GetContents(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error)
+	CreateFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error)
+	UpdateFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error)
+	DeleteFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error)
+	ListCommits(ctx context.Context, owner, repo string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error)
+	GetLicense(ctx context.Context, key string) (*github.License, *github.Response, error)
+	GetGitignore(ctx context.Context, name string) (*github.Gitignore, *github.Response, error)
+}
+
+// NewContentService creates a new ContentService backed by the Repositories,
+// Licenses and Gitignores services of a GitHub client built from the supplied
+// Config.
+func NewContentService(cfg *ghclient.Config) (ContentService, error) {
+	c, err := ghclient.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &contentService{RepositoriesService: c.Repositories, licenses: c.Licenses, gitignores: c.Gitignores}, nil
+}
+
+type contentService struct {
+	*github.RepositoriesService
+	licenses   *github.LicensesService
+	gitignores *github.GitignoresService
+}
+
+func (s *contentService) GetLicense(ctx context.Context, key string) (*github.License, *github.Response, error) {
+	return s.licenses.Get(ctx, key)
+}
+
+func (s *contentService) GetGitignore(ctx context.Context, name string) (*github.Gitignore, *github.Response, error) {
+	return s.gitignores.Get(ctx, name)
+}
+
+// GetDesiredContent returns the content the file described by the supplied
+// ContentParameters should have, fetching its template if it has one.
+func GetDesiredContent(ctx context.Context, s ContentService, p v1alpha1.ContentParameters) (string, error) {
+	t := p.Template
+	if t == nil {
+		return p.Content, nil
+	}
+
+	var body string
+	switch {
+	case t.License != nil && t.Gitignore == nil:
+		l, _, err := s.GetLicense(ctx, *t.License)
+		if err != nil {
+			return "", ghclient.Wrap(err, errGetLicense)
+		}
+		body = l.GetBody()
+	case t.Gitignore != nil && t.License == nil:
+		g, _, err := s.GetGitignore(ctx, *t.Gitignore)
+		if err != nil {
+			return "", ghclient.Wrap(err, errGetGitignore)
+		}
+		body = g.GetSource()
+	default:
+		return "", errors.New(errTemplateKind)
+	}
+	for k, v := range t.Replacements {
+		body = strings.ReplaceAll(body, fmt.Sprintf(fmtTemplateMarker, k), v)
+	}
+	return body, nil
+}
+
+type cachedTemplate struct {
+	content string
+	expires time.Time
+}
+
+// A TemplateCache holds the rendered content of templated files, so that
+// observing a templated file doesn't fetch its template from GitHub on every
+// poll. Rendered content is reused until its TTL expires, after which the
+// template is fetched again so that changes GitHub makes to its templates are
+// still picked up.
+type TemplateCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu sync.Mutex
+	m  map[string]cachedTemplate
+}
+
+// NewTemplateCache returns a TemplateCache that reuses rendered content for
+// the supplied TTL.
+func NewTemplateCache(ttl time.Duration) *TemplateCache {
+	return &TemplateCache{ttl: ttl, now: time.Now, m: map[string]cachedTemplate{}}
+}
+
+// GetDesiredContent returns the content the file described by the supplied
+// ContentParameters should have, like the GetDesiredContent function, reusing
+// the rendered content of a template with the same replacements from the same
+// GitHub API if it is cached. The supplied base URL identifies the GitHub API
+// the ContentService talks to.
+func (c *TemplateCache) GetDesiredContent(ctx context.Context, s ContentService, baseURL string, p v1alpha1.ContentParameters) (string, error) {
+	if p.Template == nil {
+		return p.Content, nil
+	}
+	// Maps are marshalled with sorted keys, so equal templates share a key.
+	t, err := json.Marshal(p.Template)
+	if err != nil {
+		return "", err
+	}
+	key := baseURL + " " + string(t)
+
+	c.mu.Lock()
+	e, ok := c.m[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(e.expires) {
+		return e.content, nil
+	}
+
+	content, err := GetDesiredContent(ctx, s, p)
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	c.m[key] = cachedTemplate{content: content, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+	return content, nil
+}
+
+// GenerateContentObservation produces a ContentObservation from the supplied
+// file.
+func GenerateContentObservation(f *github.RepositoryContent) v1alpha1.ContentObservation {
+	return v1alpha1.ContentObservation{
+		URL:     f.URL,
+		HTMLURL: f.HTMLURL,
+		SHA:     f.SHA,
+	}
+}
+
+// GenerateFileOptions produces the options used to commit the file described
+// by the supplied ContentParameters, whose Content must already hold the
+// desired content of a templated file. The SHA of the existing file must be
+// supplied to update it, and may be nil to create it.
+func GenerateFileOptions(p v1alpha1.ContentParameters, sha *string) *github.RepositoryContentFileOptions {
+	return &github.RepositoryContentFileOptions{
+		Message: github.String(p.Message),
+		Content: []byte(p.Content),
+		SHA:     sha,
+		Branch:  p.Branch,
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+// licenses is a ContentService that serves license templates, counting how
+// often it is asked for one.
+type licenses struct {
+	ContentService
+	body  string
+	err   error
+	calls int
+}
+
+func (l *licenses) GetLicense(_ context.Context, _ string) (*github.License, *github.Response, error) {
+	l.calls++
+	return &github.License{Body: github.String(l.body)}, nil, l.err
+}
+
+func mit(replacements map[string]string) v1alpha1.ContentParameters {
+	return v1alpha1.ContentParameters{Template: &v1alpha1.ContentTemplate{License: github.String("mit"), Replacements: replacements}}
+}
+
+func TestTemplateCache(t *testing.T) {
+	errBoom := errors.New("boom")
+	epoch := time.Unix(1614600000, 0)
+
+	type call struct {
+		baseURL string
+		params  v1alpha1.ContentParameters
+		after   time.Duration
+	}
+	type want struct {
+		content string
+		err     error
+		calls   int
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *licenses
+		calls  []call
+		want   want
+	}{
+		"NotTemplated": {
+			reason: "The content of a file without a template should be returned without asking GitHub.",
+			svc:    &licenses{},
+			calls:  []call{{params: v1alpha1.ContentParameters{Content: "hello"}}},
+			want:   want{content: "hello"},
+		},
+		"Hit": {
+			reason: "A template rendered with the same replacements should be reused.",
+			svc:    &licenses{body: "Copyright [year]"},
+			calls: []call{
+				{params: mit(map[string]string{"year": "2021"})},
+				{params: mit(map[string]string{"year": "2021"}), after: 59 * time.Minute},
+			},
+			want: want{content: "Copyright 2021", calls: 1},
+		},
+		"DifferentReplacements": {
+			reason: "A template rendered with different replacements should be fetched again.",
+			svc:    &licenses{body: "Copyright [year]"},
+			calls: []call{
+				{params: mit(map[string]string{"year": "2020"})},
+				{params: mit(map[string]string{"year": "2021"})},
+			},
+			want: want{content: "Copyright 2021", calls: 2},
+		},
+		"DifferentBaseURL": {
+			reason: "A template rendered from another GitHub API should be fetched again.",
+			svc:    &licenses{body: "Copyright [year]"},
+			calls: []call{
+				{params: mit(map[string]string{"year": "2021"})},
+				{baseURL: "https://github.example.org/api/v3/", params: mit(map[string]string{"year": "2021"})},
+			},
+			want: want{content: "Copyright 2021", calls: 2},
+		},
+		"Expired": {
+			reason: "A template should be fetched again once its TTL has expired.",
+			svc:    &licenses{body: "Copyright [year]"},
+			calls: []call{
+				{params: mit(map[string]string{"year": "2021"})},
+				{params: mit(map[string]string{"year": "2021"}), after: time.Hour},
+			},
+			want: want{content: "Copyright 2021", calls: 2},
+		},
+		"ErrorNotCached": {
+			reason: "A template that could not be fetched should not be cached.",
+			svc:    &licenses{err: errBoom},
+			calls: []call{
+				{params: mit(nil)},
+				{params: mit(nil)},
+			},
+			want: want{err: ghclient.Wrap(errBoom, errGetLicense), calls: 2},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewTemplateCache(time.Hour)
+			var content string
+			var err error
+			for _, cl := range tc.calls {
+				c.now = func() time.Time { return epoch.Add(cl.after) }
+				content, err = c.GetDesiredContent(context.Background(), tc.svc, cl.baseURL, cl.params)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetDesiredContent(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.content, content); diff != "" {
+				t.Errorf("\n%s\nGetDesiredContent(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, tc.svc.calls); diff != "" {
+				t.Errorf("\n%s\nGetDesiredContent(...): -want template fetches, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
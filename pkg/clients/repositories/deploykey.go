@@ -0,0 +1,102 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v33/github"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+// DeployKeyService defines the Repositories operations used to manage a
+// DeployKey.
+type DeployKeyService interface {
+	CreateKey(ctx context.Context, owner, repo string, key *github.Key) (*github.Key, *github.Response, error)
+	GetKey(ctx context.Context, owner, repo string, id int64) (*github.Key, *github.Response, error)
+	DeleteKey(ctx context.Context, owner, repo string, id int64) (*github.Response, error)
+}
+
+// NewDeployKeyService creates a new DeployKeyService backed by the
+// Repositories service of a GitHub client built from the supplied Config.
+func NewDeployKeyService(cfg *ghclient.Config) (DeployKeyService, error) {
+	c, err := ghclient.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c.Repositories, nil
+}
+
+// GenerateKey produces the deploy key described by the supplied
+// DeployKeyParameters and public key. Keys are read only unless the
+// parameters say otherwise.
+func GenerateKey(p v1alpha1.DeployKeyParameters, key string) *github.Key {
+	readOnly := true
+	if p.ReadOnly != nil {
+		readOnly = *p.ReadOnly
+	}
+	return &github.Key{Title: &p.Title, Key: &key, ReadOnly: &readOnly}
+}
+
+// GenerateDeployKeyObservation produces a DeployKeyObservation from the
+// supplied deploy key.
+func GenerateDeployKeyObservation(k *github.Key) v1alpha1.DeployKeyObservation {
+	o := v1alpha1.DeployKeyObservation{
+		ID:  k.ID,
+		URL: k.URL,
+	}
+	if k.CreatedAt != nil {
+		t := metav1.NewTime(k.CreatedAt.Time)
+		o.CreatedAt = &t
+	}
+	return o
+}
+
+// LateInitializeDeployKey fills the unset fields of the supplied
+// DeployKeyParameters with the values of the supplied deploy key.
+func LateInitializeDeployKey(p *v1alpha1.DeployKeyParameters, k *github.Key) {
+	if p.ReadOnly == nil {
+		p.ReadOnly = k.ReadOnly
+	}
+}
+
+// IsDeployKeyUpToDate returns true if the supplied deploy key matches the
+// supplied DeployKeyParameters and public key. GitHub drops the comment of
+// a key, so only the key type and material are compared.
+func IsDeployKeyUpToDate(p v1alpha1.DeployKeyParameters, key string, k *github.Key) bool {
+	if p.Title != k.GetTitle() {
+		return false
+	}
+	if p.ReadOnly != nil && *p.ReadOnly != k.GetReadOnly() {
+		return false
+	}
+	return keyMaterial(key) == keyMaterial(k.GetKey())
+}
+
+// keyMaterial returns the type and base64 encoded material of the supplied
+// authorized_keys formatted public key, without its comment.
+func keyMaterial(key string) string {
+	f := strings.Fields(key)
+	if len(f) > 2 {
+		f = f[:2]
+	}
+	return strings.Join(f, " ")
+}
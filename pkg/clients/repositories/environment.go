@@ -0,0 +1,261 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+// Types of environment protection rules.
+const (
+	protectionRuleWaitTimer         = "wait_timer"
+	protectionRuleRequiredReviewers = "required_reviewers"
+)
+
+// An Environment is a deployment environment as returned by the GitHub API.
+type Environment struct {
+	ID                     *int64                  `json:"id,omitempty"`
+	NodeID                 *string                 `json:"node_id,omitempty"`
+	Name                   *string                 `json:"name,omitempty"`
+	URL                    *string                 `json:"url,omitempty"`
+	HTMLURL                *string                 `json:"html_url,omitempty"`
+	ProtectionRules        []*ProtectionRule       `json:"protection_rules,omitempty"`
+	DeploymentBranchPolicy *DeploymentBranchPolicy `json:"deployment_branch_policy,omitempty"`
+}
+
+// A ProtectionRule of an Environment.
+type ProtectionRule struct {
+	Type      string      `json:"type"`
+	WaitTimer *int        `json:"wait_timer,omitempty"`
+	Reviewers []*Reviewer `json:"reviewers,omitempty"`
+}
+
+// A Reviewer of an Environment's required reviewers protection rule.
+type Reviewer struct {
+	Type     string `json:"type"`
+	Reviewer struct {
+		ID int64 `json:"id"`
+	} `json:"reviewer"`
+}
+
+// A DeploymentBranchPolicy of an Environment.
+type DeploymentBranchPolicy struct {
+	ProtectedBranches    bool `json:"protected_branches"`
+	CustomBranchPolicies bool `json:"custom_branch_policies"`
+}
+
+// CreateUpdateEnvironment is the request used to create or update an
+// Environment. Omitted reviewers and a nil deployment branch policy remove
+// them from the environment.
+type CreateUpdateEnvironment struct {
+	WaitTimer              *int                    `json:"wait_timer,omitempty"`
+	Reviewers              []EnvReviewer           `json:"reviewers"`
+	DeploymentBranchPolicy *DeploymentBranchPolicy `json:"deployment_branch_policy"`
+}
+
+// An EnvReviewer of a CreateUpdateEnvironment request.
+type EnvReviewer struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id"`
+}
+
+// EnvironmentService defines the operations used to manage an Environment.
+type EnvironmentService interface {
+	GetEnvironment(ctx context.Context, owner, repo, name string) (*Environment, *github.Response, error)
+	CreateUpdateEnvironment(ctx context.Context, owner, repo, name string, env *CreateUpdateEnvironment) (*Environment, *github.Response, error)
+	DeleteEnvironment(ctx context.Context, owner, repo, name string) (*github.Response, error)
+}
+
+// NewEnvironmentService creates a new EnvironmentService backed by a GitHub
+// client built from the supplied Config. go-github does not wrap the
+// environments API, so the service builds its requests itself.
+func NewEnvironmentService(cfg *ghclient.Config) (EnvironmentService, error) {
+	c, err := ghclient.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &environmentService{client: c}, nil
+}
+
+type environmentService struct {
+	client *github.Client
+}
+
+func environmentPath(owner, repo, name string) string {
+	return fmt.Sprintf("repos/%v/%v/environments/%v", owner, repo, url.PathEscape(name))
+}
+
+func (s *environmentService) GetEnvironment(ctx context.Context, owner, repo, name string) (*Environment, *github.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, environmentPath(owner, repo, name), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	env := &Environment{}
+	res, err := s.client.Do(ctx, req, env)
+	if err != nil {
+		return nil, res, err
+	}
+	return env, res, nil
+}
+
+func (s *environmentService) CreateUpdateEnvironment(ctx context.Context, owner, repo, name string, e *CreateUpdateEnvironment) (*Environment, *github.Response, error) {
+	req, err := s.client.NewRequest(http.MethodPut, environmentPath(owner, repo, name), e)
+	if err != nil {
+		return nil, nil, err
+	}
+	env := &Environment{}
+	res, err := s.client.Do(ctx, req, env)
+	if err != nil {
+		return nil, res, err
+	}
+	return env, res, nil
+}
+
+func (s *environmentService) DeleteEnvironment(ctx context.Context, owner, repo, name string) (*github.Response, error) {
+	req, err := s.client.NewRequest(http.MethodDelete, environmentPath(owner, repo, name), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, req, nil)
+}
+
+// GenerateEnvironmentObservation produces an EnvironmentObservation from the
+// supplied environment.
+func GenerateEnvironmentObservation(e *Environment) v1alpha1.EnvironmentObservation {
+	return v1alpha1.EnvironmentObservation{
+		ID:      e.ID,
+		NodeID:  e.NodeID,
+		URL:     e.URL,
+		HTMLURL: e.HTMLURL,
+	}
+}
+
+// GenerateCreateUpdateEnvironment produces the request to create or update
+// the environment described by the supplied EnvironmentParameters.
+func GenerateCreateUpdateEnvironment(p v1alpha1.EnvironmentParameters) *CreateUpdateEnvironment {
+	e := &CreateUpdateEnvironment{WaitTimer: p.WaitTimer, Reviewers: []EnvReviewer{}}
+	for _, r := range p.Reviewers {
+		e.Reviewers = append(e.Reviewers, EnvReviewer{Type: r.Type, ID: r.ID})
+	}
+	if bp := p.DeploymentBranchPolicy; !isNoBranchPolicy(bp) {
+		e.DeploymentBranchPolicy = &DeploymentBranchPolicy{
+			ProtectedBranches:    bp.ProtectedBranches,
+			CustomBranchPolicies: bp.CustomBranchPolicies,
+		}
+	}
+	return e
+}
+
+// LateInitializeEnvironment fills the unset fields of the supplied
+// EnvironmentParameters with the values of the supplied environment.
+func LateInitializeEnvironment(p *v1alpha1.EnvironmentParameters, e *Environment) {
+	if p.WaitTimer == nil {
+		w := waitTimer(e)
+		p.WaitTimer = &w
+	}
+	if p.Reviewers == nil {
+		p.Reviewers = reviewers(e)
+	}
+	if p.DeploymentBranchPolicy == nil && e.DeploymentBranchPolicy != nil {
+		p.DeploymentBranchPolicy = &v1alpha1.DeploymentBranchPolicy{
+			ProtectedBranches:    e.DeploymentBranchPolicy.ProtectedBranches,
+			CustomBranchPolicies: e.DeploymentBranchPolicy.CustomBranchPolicies,
+		}
+	}
+}
+
+// IsEnvironmentUpToDate returns true if the supplied environment matches the
+// supplied EnvironmentParameters. Reviewers are compared regardless of
+// order.
+func IsEnvironmentUpToDate(p v1alpha1.EnvironmentParameters, e *Environment) bool {
+	if p.WaitTimer != nil && *p.WaitTimer != waitTimer(e) {
+		return false
+	}
+	if !equalReviewers(p.Reviewers, reviewers(e)) {
+		return false
+	}
+	switch bp, got := p.DeploymentBranchPolicy, e.DeploymentBranchPolicy; {
+	case isNoBranchPolicy(bp) || got == nil:
+		return isNoBranchPolicy(bp) && got == nil
+	default:
+		return bp.ProtectedBranches == got.ProtectedBranches && bp.CustomBranchPolicies == got.CustomBranchPolicies
+	}
+}
+
+// isNoBranchPolicy returns true if the supplied DeploymentBranchPolicy
+// means the environment should have no policy, which is expressed by setting
+// both of its fields to false.
+func isNoBranchPolicy(bp *v1alpha1.DeploymentBranchPolicy) bool {
+	return bp == nil || (!bp.ProtectedBranches && !bp.CustomBranchPolicies)
+}
+
+// waitTimer returns the wait timer of the supplied environment, which is
+// zero if it has none.
+func waitTimer(e *Environment) int {
+	for _, r := range e.ProtectionRules {
+		if r.Type == protectionRuleWaitTimer && r.WaitTimer != nil {
+			return *r.WaitTimer
+		}
+	}
+	return 0
+}
+
+// reviewers returns the required reviewers of the supplied environment.
+func reviewers(e *Environment) []v1alpha1.EnvironmentReviewer {
+	out := []v1alpha1.EnvironmentReviewer{}
+	for _, r := range e.ProtectionRules {
+		if r.Type != protectionRuleRequiredReviewers {
+			continue
+		}
+		for _, rv := range r.Reviewers {
+			out = append(out, v1alpha1.EnvironmentReviewer{Type: rv.Type, ID: rv.Reviewer.ID})
+		}
+	}
+	return out
+}
+
+func equalReviewers(a, b []v1alpha1.EnvironmentReviewer) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sorted := func(r []v1alpha1.EnvironmentReviewer) []v1alpha1.EnvironmentReviewer {
+		s := append([]v1alpha1.EnvironmentReviewer{}, r...)
+		sort.Slice(s, func(i, j int) bool {
+			if s[i].Type != s[j].Type {
+				return s[i].Type < s[j].Type
+			}
+			return s[i].ID < s[j].ID
+		})
+		return s
+	}
+	sa, sb := sorted(a), sorted(b)
+	for i := range sa {
+		if sa[i] != sb[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+)
+
+func intPtr(i int) *int { return &i }
+
+// environment returns an environment with the supplied wait timer, branch
+// policy and reviewers.
+func environment(wait int, bp *DeploymentBranchPolicy, rv ...v1alpha1.EnvironmentReviewer) *Environment {
+	e := &Environment{DeploymentBranchPolicy: bp}
+	if wait > 0 {
+		e.ProtectionRules = append(e.ProtectionRules, &ProtectionRule{Type: protectionRuleWaitTimer, WaitTimer: intPtr(wait)})
+	}
+	if len(rv) > 0 {
+		r := &ProtectionRule{Type: protectionRuleRequiredReviewers}
+		for _, v := range rv {
+			gr := &Reviewer{Type: v.Type}
+			gr.Reviewer.ID = v.ID
+			r.Reviewers = append(r.Reviewers, gr)
+		}
+		e.ProtectionRules = append(e.ProtectionRules, r)
+	}
+	return e
+}
+
+var (
+	reviewerUser = v1alpha1.EnvironmentReviewer{Type: "User", ID: 1}
+	reviewerTeam = v1alpha1.EnvironmentReviewer{Type: "Team", ID: 2}
+)
+
+func TestIsEnvironmentUpToDate(t *testing.T) {
+	protected := &DeploymentBranchPolicy{ProtectedBranches: true}
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.EnvironmentParameters
+		e      *Environment
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "An environment matching every parameter should be up to date.",
+			p: v1alpha1.EnvironmentParameters{
+				WaitTimer:              intPtr(30),
+				Reviewers:              []v1alpha1.EnvironmentReviewer{reviewerUser, reviewerTeam},
+				DeploymentBranchPolicy: &v1alpha1.DeploymentBranchPolicy{ProtectedBranches: true},
+			},
+			e:    environment(30, protected, reviewerUser, reviewerTeam),
+			want: true,
+		},
+		"WaitTimerChanged": {
+			reason: "An environment with a different wait timer should not be up to date.",
+			p:      v1alpha1.EnvironmentParameters{WaitTimer: intPtr(30)},
+			e:      environment(10, nil),
+			want:   false,
+		},
+		"WaitTimerRemoved": {
+			reason: "A zero wait timer should match an environment without one.",
+			p:      v1alpha1.EnvironmentParameters{WaitTimer: intPtr(0)},
+			e:      environment(0, nil),
+			want:   true,
+		},
+		"ReviewersReordered": {
+			reason: "Reviewers should be compared regardless of their order.",
+			p:      v1alpha1.EnvironmentParameters{Reviewers: []v1alpha1.EnvironmentReviewer{reviewerTeam, reviewerUser}},
+			e:      environment(0, nil, reviewerUser, reviewerTeam),
+			want:   true,
+		},
+		"ReviewerChanged": {
+			reason: "An environment with a different reviewer should not be up to date.",
+			p:      v1alpha1.EnvironmentParameters{Reviewers: []v1alpha1.EnvironmentReviewer{reviewerUser}},
+			e:      environment(0, nil, reviewerTeam),
+			want:   false,
+		},
+		"ReviewersRemoved": {
+			reason: "An environment with reviewers should not be up to date when none are wanted.",
+			p:      v1alpha1.EnvironmentParameters{Reviewers: []v1alpha1.EnvironmentReviewer{}},
+			e:      environment(0, nil, reviewerUser),
+			want:   false,
+		},
+		"BranchPolicyChanged": {
+			reason: "An environment with a different branch policy should not be up to date.",
+			p:      v1alpha1.EnvironmentParameters{DeploymentBranchPolicy: &v1alpha1.DeploymentBranchPolicy{CustomBranchPolicies: true}},
+			e:      environment(0, protected),
+			want:   false,
+		},
+		"BranchPolicyToRemove": {
+			reason: "An environment with a branch policy should not be up to date when an empty policy asks for none.",
+			p:      v1alpha1.EnvironmentParameters{DeploymentBranchPolicy: &v1alpha1.DeploymentBranchPolicy{}},
+			e:      environment(0, protected),
+			want:   false,
+		},
+		"BranchPolicyRemoved": {
+			reason: "An environment without a branch policy should be up to date when an empty policy asks for none.",
+			p:      v1alpha1.EnvironmentParameters{DeploymentBranchPolicy: &v1alpha1.DeploymentBranchPolicy{}},
+			e:      environment(0, nil),
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsEnvironmentUpToDate(tc.p, tc.e)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsEnvironmentUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateCreateUpdateEnvironment(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.EnvironmentParameters
+		want   *CreateUpdateEnvironment
+	}{
+		"Full": {
+			reason: "Every parameter should be sent.",
+			p: v1alpha1.EnvironmentParameters{
+				WaitTimer:              intPtr(30),
+				Reviewers:              []v1alpha1.EnvironmentReviewer{reviewerUser},
+				DeploymentBranchPolicy: &v1alpha1.DeploymentBranchPolicy{ProtectedBranches: true},
+			},
+			want: &CreateUpdateEnvironment{
+				WaitTimer:              intPtr(30),
+				Reviewers:              []EnvReviewer{{Type: "User", ID: 1}},
+				DeploymentBranchPolicy: &DeploymentBranchPolicy{ProtectedBranches: true},
+			},
+		},
+		"EmptyBranchPolicy": {
+			reason: "An empty branch policy should be sent as no policy, which removes it.",
+			p:      v1alpha1.EnvironmentParameters{DeploymentBranchPolicy: &v1alpha1.DeploymentBranchPolicy{}},
+			want:   &CreateUpdateEnvironment{Reviewers: []EnvReviewer{}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateCreateUpdateEnvironment(tc.p)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateCreateUpdateEnvironment(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeEnvironment(t *testing.T) {
+	p := v1alpha1.EnvironmentParameters{}
+	LateInitializeEnvironment(&p, environment(30, &DeploymentBranchPolicy{ProtectedBranches: true}, reviewerUser))
+	want := v1alpha1.EnvironmentParameters{
+		WaitTimer:              intPtr(30),
+		Reviewers:              []v1alpha1.EnvironmentReviewer{reviewerUser},
+		DeploymentBranchPolicy: &v1alpha1.DeploymentBranchPolicy{ProtectedBranches: true},
+	}
+	if diff := cmp.Diff(want, p); diff != "" {
+		t.Errorf("LateInitializeEnvironment(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides fakes of the services used to manage repository
+// resources.
+package fake
+
+import (
+	"context"
+
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories"
+)
+
+// MockWebhookService is a mock WebhookService.
+type MockWebhookService struct {
+	MockCreateHook func(ctx context.Context, owner, repo string, hook *github.Hook) (*github.Hook, *github.Response, error)
+	MockGetHook    func(ctx context.Context, owner, repo string, id int64) (*github.Hook, *github.Response, error)
+	MockEditHook   func(ctx context.Context, owner, repo string, id int64, hook *github.Hook) (*github.Hook, *github.Response, error)
+	MockDeleteHook func(ctx context.Context, owner, repo string, id int64) (*github.Response, error)
+}
+
+// CreateHook calls MockCreateHook.
+func (m *MockWebhookService) CreateHook(ctx context.Context, owner, repo string, hook *github.Hook) (*github.Hook, *github.Response, error) {
+	return m.MockCreateHook(ctx, owner, repo, hook)
+}
+
+// GetHook calls MockGetHook.
+func (m *MockWebhookService) GetHook(ctx context.Context, owner, repo string, id int64) (*github.Hook, *github.Response, error) {
+	return m.MockGetHook(ctx, owner, repo, id)
+}
+
+// EditHook calls MockEditHook.
+func (m *MockWebhookService) EditHook(ctx context.Context, owner, repo string, id int64, hook *github.Hook) (*github.Hook, *github.Response, error) {
+	return m.MockEditHook(ctx, owner, repo, id, hook)
+}
+
+// DeleteHook calls MockDeleteHook.
+func (m *MockWebhookService) DeleteHook(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	return m.MockDeleteHook(ctx, owner, repo, id)
+}
+
+// MockBranchProtectionService is a mock BranchProtectionService.
+type MockBranchProtectionService struct {
+	MockGetBranchProtection    func(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error)
+	MockUpdateBranchProtection func(ctx context.Context, owner, repo, branch string, preq *github.ProtectionRequest) (*github.Protection, *github.Response, error)
+	MockRemoveBranchProtection func(ctx context.Context, owner, repo, branch string) (*github.Response, error)
+}
+
+// GetBranchProtection calls MockGetBranchProtection.
+func (m *MockBranchProtectionService) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error) {
+	return m.MockGetBranchProtection(ctx, owner, repo, branch)
+}
+
+// UpdateBranchProtection calls MockUpdateBranchProtection.
+func (m *MockBranchProtectionService) UpdateBranchProtection(ctx context.Context, owner, repo, branch string, preq *github.ProtectionRequest) (*github.Protection, *github.Response, error) {
+	return m.MockUpdateBranchProtection(ctx, owner, repo, branch, preq)
+}
+
+// RemoveBranchProtection calls MockRemoveBranchProtection.
+func (m *MockBranchProtectionService) RemoveBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Response, error) {
+	return m.MockRemoveBranchProtection(ctx, owner, repo, branch)
+}
+
+// MockRequiredStatusChecksService is a mock RequiredStatusChecksService.
+type MockRequiredStatusChecksService struct {
+	MockGetRequiredStatusChecks    func(ctx context.Context, owner, repo, branch string) (*github.RequiredStatusChecks, *github.Response, error)
+	MockUpdateRequiredStatusChecks func(ctx context.Context, owner, repo, branch string, sreq *github.RequiredStatusChecksRequest) (*github.RequiredStatusChecks, *github.Response, error)
+	MockRemoveRequiredStatusChecks func(ctx context.Context, owner, repo, branch string) (*github.Response, error)
+	MockGetBranchProtection        func(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error)
+	MockUpdateBranchProtection     func(ctx context.Context, owner, repo, branch string, preq *github.ProtectionRequest) (*github.Protection, *github.Response, error)
+}
+
+// GetRequiredStatusChecks calls MockGetRequiredStatusChecks.
+func (m *MockRequiredStatusChecksService) GetRequiredStatusChecks(ctx context.Context, owner, repo, branch string) (*github.RequiredStatusChecks, *github.Response, error) {
+	return m.MockGetRequiredStatusChecks(ctx, owner, repo, branch)
+}
+
+// UpdateRequiredStatusChecks calls MockUpdateRequiredStatusChecks.
+func (m *MockRequiredStatusChecksService) UpdateRequiredStatusChecks(ctx context.Context, owner, repo, branch string, sreq *github.RequiredStatusChecksRequest) (*github.RequiredStatusChecks, *github.Response, error) {
+	return m.MockUpdateRequiredStatusChecks(ctx, owner, repo, branch, sreq)
+}
+
+// RemoveRequiredStatusChecks calls MockRemoveRequiredStatusChecks.
+func (m *MockRequiredStatusChecksService) RemoveRequiredStatusChecks(ctx context.Context, owner, repo, branch string) (*github.Response, error) {
+	return m.MockRemoveRequiredStatusChecks(ctx, owner, repo, branch)
+}
+
+// GetBranchProtection calls MockGetBranchProtection.
+func (m *MockRequiredStatusChecksService) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error) {
+	return m.MockGetBranchProtection(ctx, owner, repo, branch)
+}
+
+// UpdateBranchProtection calls MockUpdateBranchProtection.
+func (m *MockRequiredStatusChecksService) UpdateBranchProtection(ctx context.Context, owner, repo, branch string, preq *github.ProtectionRequest) (*github.Protection, *github.Response, error) {
+	return m.MockUpdateBranchProtection(ctx, owner, repo, branch, preq)
+}
+
+// MockDeployKeyService is a mock DeployKeyService.
+type MockDeployKeyService struct {
+	MockCreateKey func(ctx context.Context, owner, repo string, key *github.Key) (*github.Key, *github.Response, error)
+	MockGetKey    func(ctx context.Context, owner, repo string, id int64) (*github.Key, *github.Response, error)
+	MockDeleteKey func(ctx context.Context, owner, repo string, id int64) (*github.Response, error)
+}
+
+// CreateKey calls MockCreateKey.
+func (m *MockDeployKeyService) CreateKey(ctx context.Context, owner, repo string, key *github.Key) (*github.Key, *github.Response, error) {
+	return m.MockCreateKey(ctx, owner, repo, key)
+}
+
+// GetKey calls MockGetKey.
+func (m *MockDeployKeyService) GetKey(ctx context.Context, owner, repo string, id int64) (*github.Key, *github.Response, error) {
+	return m.MockGetKey(ctx, owner, repo, id)
+}
+
+// DeleteKey calls MockDeleteKey.
+func (m *MockDeployKeyService) DeleteKey(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	return m.MockDeleteKey(ctx, owner, repo, id)
+}
+
+// MockEnvironmentService is a mock EnvironmentService.
+type MockEnvironmentService struct {
+	MockGetEnvironment          func(ctx context.Context, owner, repo, name string) (*repositories.Environment, *github.Response, error)
+	MockCreateUpdateEnvironment func(ctx context.Context, owner, repo, name string, env *repositories.CreateUpdateEnvironment) (*repositories.Environment, *github.Response, error)
+	MockDeleteEnvironment       func(ctx context.Context, owner, repo, name string) (*github.Response, error)
+}
+
+// GetEnvironment calls MockGetEnvironment.
+func (m *MockEnvironmentService) GetEnvironment(ctx context.Context, owner, repo, name string) (*repositories.Environment, *github.Response, error) {
+	return m.MockGetEnvironment(ctx, owner, repo, name)
+}
+
+// CreateUpdateEnvironment calls MockCreateUpdateEnvironment.
+func (m *MockEnvironmentService) CreateUpdateEnvironment(ctx context.Context, owner, repo, name string, env *repositories.CreateUpdateEnvironment) (*repositories.Environment, *github.Response, error) {
+	return m.MockCreateUpdateEnvironment(ctx, owner, repo, name, env)
+}
+
+// DeleteEnvironment calls MockDeleteEnvironment.
+func (m *MockEnvironmentService) DeleteEnvironment(ctx context.Context, owner, repo, name string) (*github.Response, error) {
+	return m.MockDeleteEnvironment(ctx, owner, repo, name)
+}
+
+// MockContentService is a mock ContentService.
+type MockContentService struct {
+	MockGetContents  func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error)
+	MockCreateFile   func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error)
+	MockUpdateFile   func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error)
+	MockDeleteFile   func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error)
+	MockListCommits  func(ctx context.Context, owner, repo string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error)
+	MockGetLicense   func(ctx context.Context, key string) (*github.License, *github.Response, error)
+	MockGetGitignore func(ctx context.Context, name string) (*github.Gitignore, *github.Response, error)
+}
+
+// GetContents calls MockGetContents.
+func (m *MockContentService) GetContents(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+	return m.MockGetContents(ctx, owner, repo, path, opts)
+}
+
+// CreateFile calls MockCreateFile.
+func (m *MockContentService) CreateFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	return m.MockCreateFile(ctx, owner, repo, path, opts)
+}
+
+// UpdateFile calls MockUpdateFile.
+func (m *MockContentService) UpdateFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	return m.MockUpdateFile(ctx, owner, repo, path, opts)
+}
+
+// DeleteFile calls MockDeleteFile.
+func (m *MockContentService) DeleteFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	return m.MockDeleteFile(ctx, owner, repo, path, opts)
+}
+
+// ListCommits calls MockListCommits.
+func (m *MockContentService) ListCommits(ctx context.Context, owner, repo string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+	return m.MockListCommits(ctx, owner, repo, opts)
+}
+
+// GetLicense calls MockGetLicense.
+func (m *MockContentService) GetLicense(ctx context.Context, key string) (*github.License, *github.Response, error) {
+	return m.MockGetLicense(ctx, key)
+}
+
+// GetGitignore calls MockGetGitignore.
+func (m *MockContentService) GetGitignore(ctx context.Context, name string) (*github.Gitignore, *github.Response, error) {
+	return m.MockGetGitignore(ctx, name)
+}
+
+// MockCollaboratorService is a mock CollaboratorService.
+type MockCollaboratorService struct {
+	MockIsCollaborator     func(ctx context.Context, owner, repo, user string) (bool, *github.Response, error)
+	MockListCollaborators  func(ctx context.Context, owner, repo string, opts *github.ListCollaboratorsOptions) ([]*github.User, *github.Response, error)
+	MockGetPermissionLevel func(ctx context.Context, owner, repo, user string) (*github.RepositoryPermissionLevel, *github.Response, error)
+	MockAddCollaborator    func(ctx context.Context, owner, repo, user string, opts *github.RepositoryAddCollaboratorOptions) (*github.CollaboratorInvitation, *github.Response, error)
+	MockRemoveCollaborator func(ctx context.Context, owner, repo, user string) (*github.Response, error)
+	MockListInvitations    func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryInvitation, *github.Response, error)
+	MockUpdateInvitation   func(ctx context.Context, owner, repo string, invitationID int64, permissions string) (*github.RepositoryInvitation, *github.Response, error)
+	MockDeleteInvitation   func(ctx context.Context, owner, repo string, invitationID int64) (*github.Response, error)
+}
+
+// IsCollaborator calls MockIsCollaborator.
+func (m *MockCollaboratorService) IsCollaborator(ctx context.Context, owner, repo, user string) (bool, *github.Response, error) {
+	return m.MockIsCollaborator(ctx, owner, repo, user)
+}
+
+// ListCollaborators calls MockListCollaborators.
+func (m *MockCollaboratorService) ListCollaborators(ctx context.Context, owner, repo string, opts *github.ListCollaboratorsOptions) ([]*github.User, *github.Response, error) {
+	return m.MockListCollaborators(ctx, owner, repo, opts)
+}
+
+// GetPermissionLevel calls MockGetPermissionLevel.
+func (m *MockCollaboratorService) GetPermissionLevel(ctx context.Context, owner, repo, user string) (*github.RepositoryPermissionLevel, *github.Response, error) {
+	return m.MockGetPermissionLevel(ctx, owner, repo, user)
+}
+
+// AddCollaborator calls MockAddCollaborator.
+func (m *MockCollaboratorService) AddCollaborator(ctx context.Context, owner, repo, user string, opts *github.RepositoryAddCollaboratorOptions) (*github.CollaboratorInvitation, *github.Response, error) {
+	return m.MockAddCollaborator(ctx, owner, repo, user, opts)
+}
+
+// RemoveCollaborator calls MockRemoveCollaborator.
+func (m *MockCollaboratorService) RemoveCollaborator(ctx context.Context, owner, repo, user string) (*github.Response, error) {
+	return m.MockRemoveCollaborator(ctx, owner, repo, user)
+}
+
+// ListInvitations calls MockListInvitations.
+func (m *MockCollaboratorService) ListInvitations(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryInvitation, *github.Response, error) {
+	return m.MockListInvitations(ctx, owner, repo, opts)
+}
+
+// UpdateInvitation calls MockUpdateInvitation.
+func (m *MockCollaboratorService) UpdateInvitation(ctx context.Context, owner, repo string, invitationID int64, permissions string) (*github.RepositoryInvitation, *github.Response, error) {
+	return m.MockUpdateInvitation(ctx, owner, repo, invitationID, permissions)
+}
+
+// DeleteInvitation calls MockDeleteInvitation.
+func (m *MockCollaboratorService) DeleteInvitation(ctx context.Context, owner, repo string, invitationID int64) (*github.Response, error) {
+	return m.MockDeleteInvitation(ctx, owner, repo, invitationID)
+}
+
+// MockLabelService is a mock LabelService.
+type MockLabelService struct {
+	MockGetLabel    func(ctx context.Context, owner, repo, name string) (*github.Label, *github.Response, error)
+	MockCreateLabel func(ctx context.Context, owner, repo string, label *github.Label) (*github.Label, *github.Response, error)
+	MockEditLabel   func(ctx context.Context, owner, repo, name string, label *github.Label) (*github.Label, *github.Response, error)
+	MockDeleteLabel func(ctx context.Context, owner, repo, name string) (*github.Response, error)
+}
+
+// GetLabel calls MockGetLabel.
+func (m *MockLabelService) GetLabel(ctx context.Context, owner, repo, name string) (*github.Label, *github.Response, error) {
+	return m.MockGetLabel(ctx, owner, repo, name)
+}
+
+// CreateLabel calls MockCreateLabel.
+func (m *MockLabelService) CreateLabel(ctx context.Context, owner, repo string, label *github.Label) (*github.Label, *github.Response, error) {
+	return m.MockCreateLabel(ctx, owner, repo, label)
+}
+
+// EditLabel calls MockEditLabel.
+func (m *MockLabelService) EditLabel(ctx context.Context, owner, repo, name string, label *github.Label) (*github.Label, *github.Response, error) {
+	return m.MockEditLabel(ctx, owner, repo, name, label)
+}
+
+// DeleteLabel calls MockDeleteLabel.
+func (m *MockLabelService) DeleteLabel(ctx context.Context, owner, repo, name string) (*github.Response, error) {
+	return m.MockDeleteLabel(ctx, owner, repo, name)
+}
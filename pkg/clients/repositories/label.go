@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+// LabelService defines the Issues operations used to manage a Label.
+type LabelService interface {
+	GetLabel(ctx context.Context, owner, repo, name string) (*github.Label, *github.Response, error)
+	CreateLabel(ctx context.Context, owner, repo string, label *github.Label) (*github.Label, *github.Response, error)
+	EditLabel(ctx context.Context, owner, repo, name string, label *github.Label) (*github.Label, *github.Response, error)
+	DeleteLabel(ctx context.Context, owner, repo, name string) (*github.Response, error)
+}
+
+// NewLabelService creates a new LabelService backed by the Issues service of
+// a GitHub client built from the supplied Config.
+func NewLabelService(cfg *ghclient.Config) (LabelService, error) {
+	c, err := ghclient.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c.Issues, nil
+}
+
+// GenerateLabel produces the label described by the supplied LabelParameters.
+// It returns an error if the color is not a valid hexadecimal color.
+func GenerateLabel(p v1alpha1.LabelParameters) (*github.Label, error) {
+	l := &github.Label{Name: &p.Name, Description: p.Description}
+	if p.Color != nil {
+		c, err := ghclient.NormalizeColor(*p.Color)
+		if err != nil {
+			return nil, err
+		}
+		l.Color = &c
+	}
+	return l, nil
+}
+
+// GenerateLabelObservation produces a LabelObservation from the supplied
+// label.
+func GenerateLabelObservation(l *github.Label) v1alpha1.LabelObservation {
+	return v1alpha1.LabelObservation{
+		ID:      l.ID,
+		Name:    l.GetName(),
+		URL:     l.URL,
+		Default: l.Default,
+	}
+}
+
+// LateInitializeLabel fills the unset fields of the supplied LabelParameters
+// with the values of the supplied label.
+func LateInitializeLabel(p *v1alpha1.LabelParameters, l *github.Label) {
+	if p.Color == nil {
+		p.Color = l.Color
+	}
+	if p.Description == nil {
+		p.Description = l.Description
+	}
+}
+
+// IsLabelUpToDate returns true if the supplied label matches the supplied
+// LabelParameters. Colors are compared in the normalized form GitHub stores
+// them in, so "#FFF" matches "ffffff".
+func IsLabelUpToDate(p v1alpha1.LabelParameters, l *github.Label) bool {
+	if p.Name != l.GetName() {
+		return false
+	}
+	if p.Color != nil {
+		c, err := ghclient.NormalizeColor(*p.Color)
+		if err != nil || c != l.GetColor() {
+			return false
+		}
+	}
+	return p.Description == nil || *p.Description == l.GetDescription()
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+)
+
+func TestGenerateLabel(t *testing.T) {
+	type want struct {
+		l   *github.Label
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.LabelParameters
+		want   want
+	}{
+		"NameOnly": {
+			reason: "A label without a color should leave the color to GitHub.",
+			p:      v1alpha1.LabelParameters{Name: "bug"},
+			want:   want{l: &github.Label{Name: github.String("bug")}},
+		},
+		"NormalizedColor": {
+			reason: "The color should be sent in the form GitHub stores it in.",
+			p:      v1alpha1.LabelParameters{Name: "bug", Color: github.String("#D73"), Description: github.String("Something is broken")},
+			want:   want{l: &github.Label{Name: github.String("bug"), Color: github.String("dd7733"), Description: github.String("Something is broken")}},
+		},
+		"InvalidColor": {
+			reason: "A color that is not hexadecimal should be an error.",
+			p:      v1alpha1.LabelParameters{Name: "bug", Color: github.String("red")},
+			want:   want{err: errors.New(`invalid color "red": must be a 3 or 6 digit hexadecimal value, optionally prefixed with #`)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			l, err := GenerateLabel(tc.p)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGenerateLabel(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.l, l); diff != "" {
+				t.Errorf("\n%s\nGenerateLabel(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeLabel(t *testing.T) {
+	l := &github.Label{Name: github.String("bug"), Color: github.String("d73a4a"), Description: github.String("Something is broken")}
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.LabelParameters
+		want   v1alpha1.LabelParameters
+	}{
+		"Unset": {
+			reason: "Unset fields should be filled from the label.",
+			p:      v1alpha1.LabelParameters{Name: "bug"},
+			want:   v1alpha1.LabelParameters{Name: "bug", Color: github.String("d73a4a"), Description: github.String("Something is broken")},
+		},
+		"Set": {
+			reason: "Set fields should not be overwritten.",
+			p:      v1alpha1.LabelParameters{Name: "bug", Color: github.String("#FFF"), Description: github.String("")},
+			want:   v1alpha1.LabelParameters{Name: "bug", Color: github.String("#FFF"), Description: github.String("")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeLabel(&tc.p, l)
+			if diff := cmp.Diff(tc.want, tc.p); diff != "" {
+				t.Errorf("\n%s\nLateInitializeLabel(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsLabelUpToDate(t *testing.T) {
+	l := &github.Label{Name: github.String("bug"), Color: github.String("ffffff"), Description: github.String("Something is broken")}
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.LabelParameters
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A label matching every parameter should be up to date.",
+			p:      v1alpha1.LabelParameters{Name: "bug", Color: github.String("ffffff"), Description: github.String("Something is broken")},
+			want:   true,
+		},
+		"Unset": {
+			reason: "Unset parameters should match any label with the same name.",
+			p:      v1alpha1.LabelParameters{Name: "bug"},
+			want:   true,
+		},
+		"ShortColor": {
+			reason: "A color should match the label's color in any form GitHub normalizes to it.",
+			p:      v1alpha1.LabelParameters{Name: "bug", Color: github.String("#FFF")},
+			want:   true,
+		},
+		"Renamed": {
+			reason: "A label with a different name should not be up to date.",
+			p:      v1alpha1.LabelParameters{Name: "defect"},
+			want:   false,
+		},
+		"ColorChanged": {
+			reason: "A label with a different color should not be up to date.",
+			p:      v1alpha1.LabelParameters{Name: "bug", Color: github.String("000000")},
+			want:   false,
+		},
+		"InvalidColor": {
+			reason: "An invalid color should never be up to date.",
+			p:      v1alpha1.LabelParameters{Name: "bug", Color: github.String("white")},
+			want:   false,
+		},
+		"DescriptionChanged": {
+			reason: "A label with a different description should not be up to date.",
+			p:      v1alpha1.LabelParameters{Name: "bug", Description: github.String("")},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsLabelUpToDate(tc.p, l)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsLabelUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
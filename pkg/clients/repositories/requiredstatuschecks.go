@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+// RequiredStatusChecksService defines the Repositories operations used to
+// manage a RequiredStatusChecks.
+type RequiredStatusChecksService interface {
+	GetRequiredStatusChecks(ctx context.Context, owner, repo, branch string) (*github.RequiredStatusChecks, *github.Response, error)
+	UpdateRequiredStatusChecks(ctx context.Context, owner, repo, branch string, sreq *github.RequiredStatusChecksRequest) (*github.RequiredStatusChecks, *github.Response, error)
+	RemoveRequiredStatusChecks(ctx context.Context, owner, repo, branch string) (*github.Response, error)
+	GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, *github.Response, error)
+	UpdateBranchProtection(ctx context.Context, owner, repo, branch string, preq *github.ProtectionRequest) (*github.Protection, *github.Response, error)
+}
+
+// NewRequiredStatusChecksService creates a new RequiredStatusChecksService
+// backed by the Repositories service of a GitHub client built from the
+// supplied Config. go-github does not wrap removing the required status
+// checks of a branch, so the service builds that request itself.
+func NewRequiredStatusChecksService(cfg *ghclient.Config) (RequiredStatusChecksService, error) {
+	c, err := ghclient.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &requiredStatusChecksService{RepositoriesService: c.Repositories, client: c}, nil
+}
+
+type requiredStatusChecksService struct {
+	*github.RepositoriesService
+	client *github.Client
+}
+
+// RemoveRequiredStatusChecks removes only the required status checks of the
+// supplied branch, leaving the rest of its protection in place.
+func (s *requiredStatusChecksService) RemoveRequiredStatusChecks(ctx context.Context, owner, repo, branch string) (*github.Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/branches/%v/protection/required_status_checks", owner, repo, branch)
+	req, err := s.client.NewRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, req, nil)
+}
+
+// GenerateRequiredStatusChecksRequest produces the request that sets the
+// required status checks described by the supplied
+// RequiredStatusChecksParameters.
+func GenerateRequiredStatusChecksRequest(p v1alpha1.RequiredStatusChecksParameters) *github.RequiredStatusChecksRequest {
+	return &github.RequiredStatusChecksRequest{
+		Strict:   p.Strict,
+		Contexts: p.Contexts,
+	}
+}
+
+// GenerateProtectionRequestWithStatusChecks produces the request that
+// protects a branch currently protected as described by the supplied
+// protection, which is nil for an unprotected branch, with the required status
+// checks described by the supplied RequiredStatusChecksParameters. The rest of
+// the branch's protection is left as it is.
+func GenerateProtectionRequestWithStatusChecks(pr *github.Protection, p v1alpha1.RequiredStatusChecksParameters) *github.ProtectionRequest {
+	bp := v1alpha1.BranchProtectionParameters{}
+	if pr != nil {
+		bp = generateBranchProtectionParameters(pr)
+	}
+	bp.RequiredStatusChecks = &v1alpha1.StatusChecks{Contexts: p.Contexts}
+	if p.Strict != nil {
+		bp.RequiredStatusChecks.Strict = *p.Strict
+	}
+	return GenerateProtectionRequest(bp)
+}
+
+// GenerateRequiredStatusChecksObservation produces a
+// RequiredStatusChecksObservation from the supplied required status checks.
+func GenerateRequiredStatusChecksObservation(c *github.RequiredStatusChecks) v1alpha1.RequiredStatusChecksObservation {
+	return v1alpha1.RequiredStatusChecksObservation{
+		Strict:   c.Strict,
+		Contexts: c.Contexts,
+	}
+}
+
+// IsRequiredStatusChecksUpToDate returns true if the supplied required status
+// checks match the supplied RequiredStatusChecksParameters. Contexts are
+// compared without regard to order.
+func IsRequiredStatusChecksUpToDate(p v1alpha1.RequiredStatusChecksParameters, c *github.RequiredStatusChecks) bool {
+	return ghclient.EqualStringSets(p.Contexts, c.Contexts) && (p.Strict == nil || *p.Strict == c.Strict)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+)
+
+// newTestClient returns a GitHub client whose requests are served by the
+// supplied handler.
+func newTestClient(t *testing.T, h http.HandlerFunc) *github.Client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	c := github.NewClient(srv.Client())
+	u, err := url.Parse(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.BaseURL = u
+	return c
+}
+
+func TestRemoveRequiredStatusChecks(t *testing.T) {
+	var method, path string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s := &requiredStatusChecksService{RepositoriesService: c.Repositories, client: c}
+	if _, err := s.RemoveRequiredStatusChecks(context.Background(), "crossplane", "provider-github", "main"); err != nil {
+		t.Fatalf("RemoveRequiredStatusChecks(...): %v", err)
+	}
+	if method != http.MethodDelete || path != "/repos/crossplane/provider-github/branches/main/protection/required_status_checks" {
+		t.Errorf("RemoveRequiredStatusChecks(...): unexpected request %s %s", method, path)
+	}
+}
+
+func TestIsRequiredStatusChecksUpToDate(t *testing.T) {
+	strict := true
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.RequiredStatusChecksParameters
+		c      *github.RequiredStatusChecks
+		want   bool
+	}{
+		"Reordered": {
+			reason: "Contexts should be compared without regard to order.",
+			p:      v1alpha1.RequiredStatusChecksParameters{Contexts: []string{"a", "b"}},
+			c:      &github.RequiredStatusChecks{Contexts: []string{"b", "a"}},
+			want:   true,
+		},
+		"ContextAdded": {
+			reason: "A missing context should cause drift.",
+			p:      v1alpha1.RequiredStatusChecksParameters{Contexts: []string{"a", "b"}},
+			c:      &github.RequiredStatusChecks{Contexts: []string{"a"}},
+			want:   false,
+		},
+		"ContextRemoved": {
+			reason: "An extra context should cause drift.",
+			p:      v1alpha1.RequiredStatusChecksParameters{Contexts: []string{"a"}},
+			c:      &github.RequiredStatusChecks{Contexts: []string{"a", "b"}},
+			want:   false,
+		},
+		"StrictUnset": {
+			reason: "Strictness should be ignored when it is not set.",
+			p:      v1alpha1.RequiredStatusChecksParameters{Contexts: []string{"a"}},
+			c:      &github.RequiredStatusChecks{Strict: true, Contexts: []string{"a"}},
+			want:   true,
+		},
+		"StrictChanged": {
+			reason: "A change in strictness should cause drift.",
+			p:      v1alpha1.RequiredStatusChecksParameters{Strict: &strict, Contexts: []string{"a"}},
+			c:      &github.RequiredStatusChecks{Contexts: []string{"a"}},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsRequiredStatusChecksUpToDate(tc.p, tc.c); got != tc.want {
+				t.Errorf("\n%s\nIsRequiredStatusChecksUpToDate(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGenerateProtectionRequestWithStatusChecks(t *testing.T) {
+	pr := &github.Protection{
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{DismissStaleReviews: true, RequiredApprovingReviewCount: 1},
+		Restrictions:               &github.BranchRestrictions{Teams: []*github.Team{{Slug: github.String("admins")}}},
+	}
+	want := &github.ProtectionRequest{
+		RequiredStatusChecks:       &github.RequiredStatusChecks{Contexts: []string{"build"}},
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcementRequest{DismissStaleReviews: true, RequiredApprovingReviewCount: 1},
+		Restrictions:               &github.BranchRestrictionsRequest{Users: []string{}, Teams: []string{"admins"}},
+	}
+	got := GenerateProtectionRequestWithStatusChecks(pr, v1alpha1.RequiredStatusChecksParameters{Contexts: []string{"build"}})
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateProtectionRequestWithStatusChecks(...): -want, +got:\n%s", diff)
+	}
+}
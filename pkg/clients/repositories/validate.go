@@ -0,0 +1,203 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+)
+
+const (
+	errFmtInvalidFile     = "invalid %s"
+	errParseYAML          = "cannot parse YAML"
+	errEmptyFile          = "file is empty"
+	errFmtUnknownPlatform = "unknown funding platform %q"
+	errFmtTooManyAccounts = "%s lists %d entries, at most %d are allowed"
+	errFmtNotString       = "%s must be a string"
+	errFmtNotStringOrList = "%s must be a string or a list of strings"
+	errNoTrigger          = "workflow has no on key defining the events that trigger it"
+	errNoJobs             = "workflow has no jobs"
+	errFmtJobNotMapping   = "job %q must be a mapping"
+	errFmtJobNoRunner     = "job %q must set runs-on, or uses to call a reusable workflow"
+	errFmtStepsNotList    = "steps of job %q must be a list"
+	errFmtStepInvalid     = "step %d of job %q must be a mapping that sets run or uses"
+	maxFundingAccounts    = 4
+	fundingPlatformGitHub = "github"
+	fundingPlatformCustom = "custom"
+)
+
+// fundingPlatforms are the keys GitHub recognizes in a FUNDING.yml file.
+var fundingPlatforms = map[string]bool{
+	fundingPlatformGitHub: true,
+	fundingPlatformCustom: true,
+	"patreon":             true,
+	"open_collective":     true,
+	"ko_fi":               true,
+	"tidelift":            true,
+	"community_bridge":    true,
+	"liberapay":           true,
+	"issuehunt":           true,
+	"otechie":             true,
+	"lfx_crowdfunding":    true,
+	"polar":               true,
+	"buy_me_a_coffee":     true,
+	"thanks_dev":          true,
+}
+
+// A contentValidator validates the content of the files it matches.
+type contentValidator struct {
+	matches  func(path string) bool
+	validate func(content string) error
+}
+
+var contentValidators = []contentValidator{
+	{matches: isPath(".github/FUNDING.yml"), validate: validateFunding},
+	{matches: isPath("SECURITY.md", ".github/SECURITY.md", "docs/SECURITY.md"), validate: validateNotEmpty},
+	{matches: isWorkflow, validate: validateWorkflow},
+}
+
+// ValidateContent returns an error if the supplied ContentParameters describe
+// a known file, such as .github/FUNDING.yml, whose content is invalid. Files
+// that are not known are always valid.
+func ValidateContent(p v1alpha1.ContentParameters) error {
+	if p.SkipValidation != nil && *p.SkipValidation {
+		return nil
+	}
+	for _, v := range contentValidators {
+		if !v.matches(p.Path) {
+			continue
+		}
+		if err := v.validate(p.Content); err != nil {
+			return errors.Wrapf(err, errFmtInvalidFile, p.Path)
+		}
+	}
+	return nil
+}
+
+func isPath(paths ...string) func(string) bool {
+	return func(path string) bool {
+		path = strings.TrimPrefix(path, "/")
+		for _, p := range paths {
+			if path == p {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// isWorkflow returns true if the supplied path is a GitHub Actions workflow.
+func isWorkflow(p string) bool {
+	p = strings.TrimPrefix(p, "/")
+	ext := path.Ext(p)
+	return path.Dir(p) == ".github/workflows" && (ext == ".yml" || ext == ".yaml")
+}
+
+func validateNotEmpty(content string) error {
+	if strings.TrimSpace(content) == "" {
+		return errors.New(errEmptyFile)
+	}
+	return nil
+}
+
+// validateFunding validates a FUNDING.yml file. The github and custom
+// platforms accept up to four entries; every other platform accepts one.
+func validateFunding(content string) error {
+	f := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(content), &f); err != nil {
+		return errors.Wrap(err, errParseYAML)
+	}
+	for platform, v := range f {
+		if !fundingPlatforms[platform] {
+			return errors.Errorf(errFmtUnknownPlatform, platform)
+		}
+		switch t := v.(type) {
+		case nil, string:
+		case []interface{}:
+			if platform != fundingPlatformGitHub && platform != fundingPlatformCustom {
+				return errors.Errorf(errFmtNotString, platform)
+			}
+			if len(t) > maxFundingAccounts {
+				return errors.Errorf(errFmtTooManyAccounts, platform, len(t), maxFundingAccounts)
+			}
+			for _, e := range t {
+				if _, ok := e.(string); !ok {
+					return errors.Errorf(errFmtNotStringOrList, platform)
+				}
+			}
+		default:
+			if platform == fundingPlatformGitHub || platform == fundingPlatformCustom {
+				return errors.Errorf(errFmtNotStringOrList, platform)
+			}
+			return errors.Errorf(errFmtNotString, platform)
+		}
+	}
+	return nil
+}
+
+// validateWorkflow validates the basic structure of a GitHub Actions
+// workflow: it must say what triggers it, and each of its jobs must say what
+// runs it and consist of steps that each run a command or use an action.
+func validateWorkflow(content string) error {
+	w := map[interface{}]interface{}{}
+	if err := yaml.Unmarshal([]byte(content), &w); err != nil {
+		return errors.Wrap(err, errParseYAML)
+	}
+	// YAML 1.1 parses an unquoted on key as the boolean true.
+	_, on := w["on"]
+	_, onBool := w[true]
+	if !on && !onBool {
+		return errors.New(errNoTrigger)
+	}
+	jobs, ok := w["jobs"].(map[interface{}]interface{})
+	if !ok || len(jobs) == 0 {
+		return errors.New(errNoJobs)
+	}
+	for id, j := range jobs {
+		job, ok := j.(map[interface{}]interface{})
+		if !ok {
+			return errors.Errorf(errFmtJobNotMapping, id)
+		}
+		_, runsOn := job["runs-on"]
+		_, uses := job["uses"]
+		if !runsOn && !uses {
+			return errors.Errorf(errFmtJobNoRunner, id)
+		}
+		s, ok := job["steps"]
+		if !ok {
+			continue
+		}
+		steps, ok := s.([]interface{})
+		if !ok {
+			return errors.Errorf(errFmtStepsNotList, id)
+		}
+		for i, st := range steps {
+			step, ok := st.(map[interface{}]interface{})
+			_, run := step["run"]
+			_, uses := step["uses"]
+			if !ok || (!run && !uses) {
+				return errors.Errorf(errFmtStepInvalid, i+1, id)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+)
+
+const workflow = `
+on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+    - uses: actions/checkout@v2
+    - run: make test
+  release:
+    uses: crossplane/build/.github/workflows/release.yml@main
+`
+
+func TestValidateContent(t *testing.T) {
+	invalidYAML := "github: [octocat"
+	errYAML := yaml.Unmarshal([]byte(invalidYAML), &map[string]interface{}{})
+	invalid := func(path string, err error) error { return errors.Wrapf(err, errFmtInvalidFile, path) }
+
+	cases := map[string]struct {
+		reason string
+		p      v1alpha1.ContentParameters
+		want   error
+	}{
+		"UnknownFile": {
+			reason: "Files that are not known should always be valid.",
+			p:      v1alpha1.ContentParameters{Path: "README.md", Content: ""},
+		},
+		"SkipValidation": {
+			reason: "Known files should not be validated when validation is skipped.",
+			p:      v1alpha1.ContentParameters{Path: ".github/FUNDING.yml", Content: "paypal: octocat", SkipValidation: github.Bool(true)},
+		},
+		"Funding": {
+			reason: "A FUNDING.yml listing known platforms should be valid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/FUNDING.yml", Content: "github: [octocat, hubot]\npatreon: octocat\ncustom: https://example.org\nko_fi:\n"},
+		},
+		"FundingLeadingSlash": {
+			reason: "Known files should be matched with a leading slash.",
+			p:      v1alpha1.ContentParameters{Path: "/.github/FUNDING.yml", Content: "paypal: octocat"},
+			want:   invalid("/.github/FUNDING.yml", errors.Errorf(errFmtUnknownPlatform, "paypal")),
+		},
+		"FundingInvalidYAML": {
+			reason: "A FUNDING.yml that is not YAML should be invalid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/FUNDING.yml", Content: invalidYAML},
+			want:   invalid(".github/FUNDING.yml", errors.Wrap(errYAML, errParseYAML)),
+		},
+		"FundingUnknownPlatform": {
+			reason: "A FUNDING.yml listing an unknown platform should be invalid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/FUNDING.yml", Content: "paypal: octocat"},
+			want:   invalid(".github/FUNDING.yml", errors.Errorf(errFmtUnknownPlatform, "paypal")),
+		},
+		"FundingTooManyAccounts": {
+			reason: "A FUNDING.yml listing more than four GitHub sponsors should be invalid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/FUNDING.yml", Content: "github: [a, b, c, d, e]"},
+			want:   invalid(".github/FUNDING.yml", errors.Errorf(errFmtTooManyAccounts, "github", 5, maxFundingAccounts)),
+		},
+		"FundingListForSingleAccountPlatform": {
+			reason: "A FUNDING.yml listing several accounts of a platform that accepts one should be invalid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/FUNDING.yml", Content: "patreon: [a, b]"},
+			want:   invalid(".github/FUNDING.yml", errors.Errorf(errFmtNotString, "patreon")),
+		},
+		"FundingNotString": {
+			reason: "A FUNDING.yml whose GitHub sponsors are not strings should be invalid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/FUNDING.yml", Content: "github: [{name: octocat}]"},
+			want:   invalid(".github/FUNDING.yml", errors.Errorf(errFmtNotStringOrList, "github")),
+		},
+		"FundingMapping": {
+			reason: "A FUNDING.yml whose account is a mapping should be invalid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/FUNDING.yml", Content: "tidelift: {name: octocat}"},
+			want:   invalid(".github/FUNDING.yml", errors.Errorf(errFmtNotString, "tidelift")),
+		},
+		"Security": {
+			reason: "A SECURITY.md with content should be valid.",
+			p:      v1alpha1.ContentParameters{Path: "docs/SECURITY.md", Content: "Report vulnerabilities to security@example.org."},
+		},
+		"SecurityEmpty": {
+			reason: "A SECURITY.md that is blank should be invalid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/SECURITY.md", Content: " \n\t"},
+			want:   invalid(".github/SECURITY.md", errors.New(errEmptyFile)),
+		},
+		"Workflow": {
+			reason: "A workflow with a trigger and jobs that run steps or call a reusable workflow should be valid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/workflows/ci.yml", Content: workflow},
+		},
+		"WorkflowNotInWorkflows": {
+			reason: "YAML files outside .github/workflows should not be validated as workflows.",
+			p:      v1alpha1.ContentParameters{Path: ".github/workflows/nested/ci.yml", Content: "jobs: {}"},
+		},
+		"WorkflowNoTrigger": {
+			reason: "A workflow without an on key should be invalid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/workflows/ci.yaml", Content: "jobs: {test: {runs-on: ubuntu-latest}}"},
+			want:   invalid(".github/workflows/ci.yaml", errors.New(errNoTrigger)),
+		},
+		"WorkflowQuotedTrigger": {
+			reason: "A workflow whose on key is quoted should be valid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/workflows/ci.yml", Content: "\"on\": push\njobs: {test: {runs-on: ubuntu-latest}}"},
+		},
+		"WorkflowNoJobs": {
+			reason: "A workflow without jobs should be invalid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/workflows/ci.yml", Content: "on: push\njobs: {}"},
+			want:   invalid(".github/workflows/ci.yml", errors.New(errNoJobs)),
+		},
+		"WorkflowJobNotMapping": {
+			reason: "A workflow whose job is not a mapping should be invalid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/workflows/ci.yml", Content: "on: push\njobs: {test: make}"},
+			want:   invalid(".github/workflows/ci.yml", errors.Errorf(errFmtJobNotMapping, "test")),
+		},
+		"WorkflowJobNoRunner": {
+			reason: "A workflow whose job neither sets runs-on nor uses a reusable workflow should be invalid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/workflows/ci.yml", Content: "on: push\njobs: {test: {steps: [{run: make}]}}"},
+			want:   invalid(".github/workflows/ci.yml", errors.Errorf(errFmtJobNoRunner, "test")),
+		},
+		"WorkflowStepsNotList": {
+			reason: "A workflow whose steps are not a list should be invalid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/workflows/ci.yml", Content: "on: push\njobs: {test: {runs-on: ubuntu-latest, steps: {run: make}}}"},
+			want:   invalid(".github/workflows/ci.yml", errors.Errorf(errFmtStepsNotList, "test")),
+		},
+		"WorkflowStepInvalid": {
+			reason: "A workflow whose step neither runs a command nor uses an action should be invalid.",
+			p:      v1alpha1.ContentParameters{Path: ".github/workflows/ci.yml", Content: "on: push\njobs: {test: {runs-on: ubuntu-latest, steps: [{run: make}, {name: nothing}]}}"},
+			want:   invalid(".github/workflows/ci.yml", errors.Errorf(errFmtStepInvalid, 2, "test")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateContent(tc.p)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateContent(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+// Keys of a webhook's config.
+const (
+	hookConfigURL         = "url"
+	hookConfigContentType = "content_type"
+	hookConfigInsecureSSL = "insecure_ssl"
+	hookConfigSecret      = "secret"
+
+	insecureSSLEnabled  = "1"
+	insecureSSLDisabled = "0"
+)
+
+// WebhookService defines the Repositories operations used to manage a
+// Webhook.
+type WebhookService interface {
+	CreateHook(ctx context.Context, owner, repo string, hook *github.Hook) (*github.Hook, *github.Response, error)
+	GetHook(ctx context.Context, owner, repo string, id int64) (*github.Hook, *github.Response, error)
+	EditHook(ctx context.Context, owner, repo string, id int64, hook *github.Hook) (*github.Hook, *github.Response, error)
+	DeleteHook(ctx context.Context, owner, repo string, id int64) (*github.Response, error)
+}
+
+// NewWebhookService creates a new WebhookService backed by the Repositories
+// service of a GitHub client built from the supplied Config.
+func NewWebhookService(cfg *ghclient.Config) (WebhookService, error) {
+	c, err := ghclient.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c.Repositories, nil
+}
+
+// GenerateHook produces the webhook described by the supplied
+// WebhookParameters. The secret is omitted from the config when nil.
+func GenerateHook(p v1alpha1.WebhookParameters, secret *string) *github.Hook {
+	cfg := map[string]interface{}{hookConfigURL: p.URL}
+	if p.ContentType != nil {
+		cfg[hookConfigContentType] = *p.ContentType
+	}
+	if p.InsecureSSL != nil {
+		cfg[hookConfigInsecureSSL] = insecureSSLDisabled
+		if *p.InsecureSSL {
+			cfg[hookConfigInsecureSSL] = insecureSSLEnabled
+		}
+	}
+	if secret != nil {
+		cfg[hookConfigSecret] = *secret
+	}
+	return &github.Hook{Config: cfg, Events: p.Events, Active: p.Active}
+}
+
+// GenerateWebhookObservation produces a WebhookObservation from the supplied
+// webhook. The secret hash is not observable and must be carried over by the
+// caller.
+func GenerateWebhookObservation(h *github.Hook) v1alpha1.WebhookObservation {
+	return v1alpha1.WebhookObservation{
+		ID:  h.ID,
+		URL: h.URL,
+	}
+}
+
+// WebhookHasSecret returns true if the supplied webhook has a secret. GitHub
+// masks the value of the secret, but reports that there is one.
+func WebhookHasSecret(h *github.Hook) bool {
+	s, _ := h.Config[hookConfigSecret].(string)
+	return s != ""
+}
+
+// LateInitializeWebhook fills the unset fields of the supplied
+// WebhookParameters with the values of the supplied webhook.
+func LateInitializeWebhook(p *v1alpha1.WebhookParameters, h *github.Hook) {
+	if p.ContentType == nil {
+		if ct, ok := h.Config[hookConfigContentType].(string); ok {
+			p.ContentType = &ct
+		}
+	}
+	if p.InsecureSSL == nil {
+		if s, ok := h.Config[hookConfigInsecureSSL].(string); ok {
+			insecure := s == insecureSSLEnabled
+			p.InsecureSSL = &insecure
+		}
+	}
+	if p.Events == nil {
+		p.Events = h.Events
+	}
+	if p.Active == nil {
+		p.Active = h.Active
+	}
+}
+
+// IsWebhookUpToDate returns true if the supplied webhook matches the supplied
+// WebhookParameters. The secret is write-only, so it is not compared here.
+func IsWebhookUpToDate(p v1alpha1.WebhookParameters, h *github.Hook) bool {
+	if url, _ := h.Config[hookConfigURL].(string); url != p.URL {
+		return false
+	}
+	if ct, _ := h.Config[hookConfigContentType].(string); p.ContentType != nil && *p.ContentType != ct {
+		return false
+	}
+	if s, _ := h.Config[hookConfigInsecureSSL].(string); p.InsecureSSL != nil && *p.InsecureSSL != (s == insecureSSLEnabled) {
+		return false
+	}
+	if p.Active != nil && *p.Active != h.GetActive() {
+		return false
+	}
+	return p.Events == nil || ghclient.EqualStringSets(p.Events, h.Events)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides fakes of the services used to manage secret
+// resources.
+package fake
+
+import (
+	"context"
+
+	"github.com/google/go-github/v33/github"
+)
+
+// MockService is a mock Service.
+type MockService struct {
+	MockGetOrgPublicKey               func(ctx context.Context, org string) (*github.PublicKey, *github.Response, error)
+	MockGetOrgSecret                  func(ctx context.Context, org, name string) (*github.Secret, *github.Response, error)
+	MockCreateOrUpdateOrgSecret       func(ctx context.Context, org string, eSecret *github.EncryptedSecret) (*github.Response, error)
+	MockListSelectedReposForOrgSecret func(ctx context.Context, org, name string, opts *github.ListOptions) (*github.SelectedReposList, *github.Response, error)
+	MockDeleteOrgSecret               func(ctx context.Context, org, name string) (*github.Response, error)
+	MockGetRepositoryID               func(ctx context.Context, owner, repo string) (int64, *github.Response, error)
+	MockGetEnvPublicKey               func(ctx context.Context, repoID int64, env string) (*github.PublicKey, *github.Response, error)
+	MockGetEnvSecret                  func(ctx context.Context, repoID int64, env, name string) (*github.Secret, *github.Response, error)
+	MockCreateOrUpdateEnvSecret       func(ctx context.Context, repoID int64, env string, eSecret *github.EncryptedSecret) (*github.Response, error)
+	MockDeleteEnvSecret               func(ctx context.Context, repoID int64, env, name string) (*github.Response, error)
+}
+
+// GetOrgPublicKey calls MockGetOrgPublicKey.
+func (m *MockService) GetOrgPublicKey(ctx context.Context, org string) (*github.PublicKey, *github.Response, error) {
+	return m.MockGetOrgPublicKey(ctx, org)
+}
+
+// GetOrgSecret calls MockGetOrgSecret.
+func (m *MockService) GetOrgSecret(ctx context.Context, org, name string) (*github.Secret, *github.Response, error) {
+	return m.MockGetOrgSecret(ctx, org, name)
+}
+
+// CreateOrUpdateOrgSecret calls MockCreateOrUpdateOrgSecret.
+func (m *MockService) CreateOrUpdateOrgSecret(ctx context.Context, org string, eSecret *github.EncryptedSecret) (*github.Response, error) {
+	return m.MockCreateOrUpdateOrgSecret(ctx, org, eSecret)
+}
+
+// ListSelectedReposForOrgSecret calls MockListSelectedReposForOrgSecret.
+func (m *MockService) ListSelectedReposForOrgSecret(ctx context.Context, org, name string, opts *github.ListOptions) (*github.SelectedReposList, *github.Response, error) {
+	return m.MockListSelectedReposForOrgSecret(ctx, org, name, opts)
+}
+
+// DeleteOrgSecret calls MockDeleteOrgSecret.
+func (m *MockService) DeleteOrgSecret(ctx context.Context, org, name string) (*github.Response, error) {
+	return m.MockDeleteOrgSecret(ctx, org, name)
+}
+
+// GetRepositoryID calls MockGetRepositoryID.
+func (m *MockService) GetRepositoryID(ctx context.Context, owner, repo string) (int64, *github.Response, error) {
+	return m.MockGetRepositoryID(ctx, owner, repo)
+}
+
+// GetEnvPublicKey calls MockGetEnvPublicKey.
+func (m *MockService) GetEnvPublicKey(ctx context.Context, repoID int64, env string) (*github.PublicKey, *github.Response, error) {
+	return m.MockGetEnvPublicKey(ctx, repoID, env)
+}
+
+// GetEnvSecret calls MockGetEnvSecret.
+func (m *MockService) GetEnvSecret(ctx context.Context, repoID int64, env, name string) (*github.Secret, *github.Response, error) {
+	return m.MockGetEnvSecret(ctx, repoID, env, name)
+}
+
+// CreateOrUpdateEnvSecret calls MockCreateOrUpdateEnvSecret.
+func (m *MockService) CreateOrUpdateEnvSecret(ctx context.Context, repoID int64, env string, eSecret *github.EncryptedSecret) (*github.Response, error) {
+	return m.MockCreateOrUpdateEnvSecret(ctx, repoID, env, eSecret)
+}
+
+// DeleteEnvSecret calls MockDeleteEnvSecret.
+func (m *MockService) DeleteEnvSecret(ctx context.Context, repoID int64, env, name string) (*github.Response, error) {
+	return m.MockDeleteEnvSecret(ctx, repoID, env, name)
+}
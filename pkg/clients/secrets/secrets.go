@@ -0,0 +1,332 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/nacl/box"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane-contrib/provider-github/apis/secrets/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+const (
+	errDecodePublicKey = "cannot decode public key"
+	errPublicKeyLength = "public key is not 32 bytes long"
+	errEncryptSecret   = "cannot encrypt secret"
+	errFmtNameHyphen   = "secret name %q must not contain hyphens: set spec.forProvider.name or the " + meta.AnnotationKeyExternalName + " annotation to a name with underscores instead"
+	errFmtNameInvalid  = "secret name %q must contain only letters, digits and underscores, and must not start with a digit"
+	errFmtNameReserved = "secret name %q is invalid: secret names cannot start with " + reservedNamePrefix
+
+	reservedNamePrefix = "GITHUB_"
+
+	// VisibilitySelected limits an organization secret to selected repositories.
+	VisibilitySelected = "selected"
+)
+
+// Service defines the Actions operations used to manage secrets.
+type Service interface {
+	GetOrgPublicKey(ctx context.Context, org string) (*github.PublicKey, *github.Response, error)
+	GetOrgSecret(ctx context.Context, org, name string) (*github.Secret, *github.Response, error)
+	CreateOrUpdateOrgSecret(ctx context.Context, org string, eSecret *github.EncryptedSecret) (*github.Response, error)
+	ListSelectedReposForOrgSecret(ctx context.Context, org, name string, opts *github.ListOptions) (*github.SelectedReposList, *github.Response, error)
+	DeleteOrgSecret(ctx context.Context, org, name string) (*github.Response, error)
+
+	GetRepositoryID(ctx context.Context, owner, repo string) (int64, *github.Response, error)
+	GetEnvPublicKey(ctx context.Context, repoID int64, env string) (*github.PublicKey, *github.Response, error)
+	GetEnvSecret(ctx context.Context, repoID int64, env, name string) (*github.Secret, *github.Response, error)
+	CreateOrUpdateEnvSecret(ctx context.Context, repoID int64, env string, eSecret *github.EncryptedSecret) (*github.Response, error)
+	DeleteEnvSecret(ctx context.Context, repoID int64, env, name string) (*github.Response, error)
+}
+
+// NewService creates a new Service backed by the Actions service of a GitHub
+// client built from the supplied Config. go-github does not wrap the
+// environment secrets API, nor page through the repositories selected for an
+// organization secret, so the service builds those requests itself.
+func NewService(cfg *ghclient.Config) (Service, error) {
+	c, err := ghclient.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &service{ActionsService: c.Actions, client: c}, nil
+}
+
+type service struct {
+	*github.ActionsService
+	client *github.Client
+}
+
+func (s *service) GetRepositoryID(ctx context.Context, owner, repo string) (int64, *github.Response, error) {
+	r, res, err := s.client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return 0, res, err
+	}
+	return r.GetID(), res, nil
+}
+
+// ListSelectedReposForOrgSecret lists the page of the repositories selected
+// for an organization secret that is described by the supplied options.
+func (s *service) ListSelectedReposForOrgSecret(ctx context.Context, org, name string, opts *github.ListOptions) (*github.SelectedReposList, *github.Response, error) {
+	q := url.Values{}
+	q.Set("page", strconv.Itoa(opts.Page))
+	q.Set("per_page", strconv.Itoa(opts.PerPage))
+	u := fmt.Sprintf("orgs/%v/actions/secrets/%v/repositories?%s", org, name, q.Encode())
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	l := &github.SelectedReposList{}
+	res, err := s.client.Do(ctx, req, l)
+	if err != nil {
+		return nil, res, err
+	}
+	return l, res, nil
+}
+
+func envSecretsPath(repoID int64, env string) string {
+	return fmt.Sprintf("repositories/%v/environments/%v/secrets", repoID, url.PathEscape(env))
+}
+
+func (s *service) GetEnvPublicKey(ctx context.Context, repoID int64, env string) (*github.PublicKey, *github.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, envSecretsPath(repoID, env)+"/public-key", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	key := &github.PublicKey{}
+	res, err := s.client.Do(ctx, req, key)
+	if err != nil {
+		return nil, res, err
+	}
+	return key, res, nil
+}
+
+func (s *service) GetEnvSecret(ctx context.Context, repoID int64, env, name string) (*github.Secret, *github.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, envSecretsPath(repoID, env)+"/"+name, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	secret := &github.Secret{}
+	res, err := s.client.Do(ctx, req, secret)
+	if err != nil {
+		return nil, res, err
+	}
+	return secret, res, nil
+}
+
+func (s *service) CreateOrUpdateEnvSecret(ctx context.Context, repoID int64, env string, eSecret *github.EncryptedSecret) (*github.Response, error) {
+	req, err := s.client.NewRequest(http.MethodPut, envSecretsPath(repoID, env)+"/"+eSecret.Name, eSecret)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, req, nil)
+}
+
+func (s *service) DeleteEnvSecret(ctx context.Context, repoID int64, env, name string) (*github.Response, error) {
+	req, err := s.client.NewRequest(http.MethodDelete, envSecretsPath(repoID, env)+"/"+name, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(ctx, req, nil)
+}
+
+var validName = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+// NormalizeName returns the supplied secret name in the canonical form GitHub
+// stores secret names in, which is uppercase. An error describing the problem
+// is returned for names GitHub would reject, rather than a name that could
+// never be created.
+func NormalizeName(name string) (string, error) {
+	if strings.Contains(name, "-") {
+		return "", errors.Errorf(errFmtNameHyphen, name)
+	}
+	n := strings.ToUpper(name)
+	if !validName.MatchString(n) {
+		return "", errors.Errorf(errFmtNameInvalid, name)
+	}
+	if strings.HasPrefix(n, reservedNamePrefix) {
+		return "", errors.Errorf(errFmtNameReserved, name)
+	}
+	return n, nil
+}
+
+// SecretName returns the normalized name of a secret. It is the supplied name
+// when one is set, and the supplied external name otherwise. Kubernetes
+// resource names cannot contain underscores, so a name like MY_SECRET must be
+// set explicitly.
+func SecretName(name *string, externalName string) (string, error) {
+	if name != nil {
+		return NormalizeName(*name)
+	}
+	return NormalizeName(externalName)
+}
+
+// EncryptSecret encrypts the supplied value with the supplied base64 encoded
+// public key, as GitHub requires secret values to be, using a NaCl sealed
+// box. It returns the base64 encoded encrypted value.
+func EncryptSecret(publicKey, value string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return "", errors.Wrap(err, errDecodePublicKey)
+	}
+	if len(b) != 32 {
+		return "", errors.New(errPublicKeyLength)
+	}
+	var pk [32]byte
+	copy(pk[:], b)
+	enc, err := box.SealAnonymous(nil, []byte(value), &pk, rand.Reader)
+	if err != nil {
+		return "", errors.Wrap(err, errEncryptSecret)
+	}
+	return base64.StdEncoding.EncodeToString(enc), nil
+}
+
+// GenerateHash returns the versioned hash of a secret value that is stored in
+// the observation to detect changes to the value.
+func GenerateHash(value string) string {
+	return ghclient.VersionedHash([]byte(value))
+}
+
+// IsOrgSecretUpToDate returns true if the supplied organization secret, whose
+// repositories are the supplied selected repositories, matches the supplied
+// OrgSecretParameters and the supplied value. The value is compared by hash
+// with the value recorded in the supplied observation, which may be a legacy
+// unversioned hash, and the secret must not have been updated since this
+// resource last wrote it.
+func IsOrgSecretUpToDate(p v1alpha1.OrgSecretParameters, o v1alpha1.OrgSecretObservation, s *github.Secret, selected []int64, value string) bool {
+	if o.LastUpdate == nil || !o.LastUpdate.Time.Equal(s.UpdatedAt.Time) {
+		return false
+	}
+	if !ghclient.HashMatches(o.EncryptValue, []byte(value)) {
+		return false
+	}
+	if p.Visibility != s.Visibility {
+		return false
+	}
+	return p.Visibility != VisibilitySelected || equalIDSets(p.SelectedRepositoryIDs, selected)
+}
+
+// IsEnvSecretUpToDate returns true if the supplied environment secret matches
+// the supplied value. Like an organization secret, the value is compared by
+// hash with the value recorded in the supplied observation, and the secret
+// must not have been updated since this resource last wrote it.
+func IsEnvSecretUpToDate(o v1alpha1.EnvironmentSecretObservation, s *github.Secret, value string) bool {
+	return isWrittenValue(o.EncryptValue, o.LastUpdate, s, value)
+}
+
+// IsEnvSecretWriteUpToDate returns true if the supplied environment secret,
+// which a MultiEnvSecret last wrote as the supplied write records, matches
+// the supplied value.
+func IsEnvSecretWriteUpToDate(w v1alpha1.EnvironmentSecretWrite, s *github.Secret, value string) bool {
+	return isWrittenValue(w.EncryptValue, w.LastUpdate, s, value)
+}
+
+func isWrittenValue(hash string, lastUpdate *metav1.Time, s *github.Secret, value string) bool {
+	if lastUpdate == nil || !lastUpdate.Time.Equal(s.UpdatedAt.Time) {
+		return false
+	}
+	return ghclient.HashMatches(hash, []byte(value))
+}
+
+// EnvironmentWrite returns the write recorded for the supplied environment in
+// the supplied observation, and whether one is recorded.
+func EnvironmentWrite(o v1alpha1.MultiEnvSecretObservation, env string) (v1alpha1.EnvironmentSecretWrite, bool) {
+	for _, w := range o.Environments {
+		if w.Environment == env {
+			return w, true
+		}
+	}
+	return v1alpha1.EnvironmentSecretWrite{}, false
+}
+
+// StaleEnvironments returns the environments the supplied observation records
+// a write to that are no longer in the supplied MultiEnvSecretParameters.
+func StaleEnvironments(p v1alpha1.MultiEnvSecretParameters, o v1alpha1.MultiEnvSecretObservation) []string {
+	want := make(map[string]bool, len(p.Environments))
+	for _, env := range p.Environments {
+		want[env] = true
+	}
+	var stale []string
+	for _, w := range o.Environments {
+		if !want[w.Environment] {
+			stale = append(stale, w.Environment)
+		}
+	}
+	return stale
+}
+
+// SelectedRepositoryIDs returns the IDs of the supplied repositories.
+func SelectedRepositoryIDs(repos []*github.Repository) []int64 {
+	ids := make([]int64, 0, len(repos))
+	for _, r := range repos {
+		ids = append(ids, r.GetID())
+	}
+	return ids
+}
+
+// GenerateOrgSecret produces the request to create or update the organization
+// secret described by the supplied OrgSecretParameters.
+func GenerateOrgSecret(name string, p v1alpha1.OrgSecretParameters, keyID, encrypted string) *github.EncryptedSecret {
+	s := &github.EncryptedSecret{
+		Name:           name,
+		KeyID:          keyID,
+		EncryptedValue: encrypted,
+		Visibility:     p.Visibility,
+	}
+	if p.Visibility == VisibilitySelected {
+		s.SelectedRepositoryIDs = p.SelectedRepositoryIDs
+	}
+	return s
+}
+
+// GenerateEnvSecret produces the request to create or update an environment
+// secret.
+func GenerateEnvSecret(name, keyID, encrypted string) *github.EncryptedSecret {
+	return &github.EncryptedSecret{
+		Name:           name,
+		KeyID:          keyID,
+		EncryptedValue: encrypted,
+	}
+}
+
+func equalIDSets(a, b []int64) bool {
+	set := make(map[int64]bool, len(a))
+	for _, id := range a {
+		set[id] = true
+	}
+	seen := make(map[int64]bool, len(b))
+	for _, id := range b {
+		if !set[id] {
+			return false
+		}
+		seen[id] = true
+	}
+	return len(seen) == len(set)
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/secrets/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+func TestNormalizeName(t *testing.T) {
+	type want struct {
+		name string
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		name   string
+		want   want
+	}{
+		"Uppercase": {
+			reason: "An uppercase name should be returned unchanged.",
+			name:   "MY_SECRET",
+			want:   want{name: "MY_SECRET"},
+		},
+		"MixedCase": {
+			reason: "A mixed case name should be uppercased, as GitHub stores it.",
+			name:   "My_Secret2",
+			want:   want{name: "MY_SECRET2"},
+		},
+		"Lowercase": {
+			reason: "A lowercase resource name should be a valid secret name.",
+			name:   "mysecret",
+			want:   want{name: "MYSECRET"},
+		},
+		"Hyphen": {
+			reason: "A name with hyphens, like most resource names, should explain how to set a valid one.",
+			name:   "my-secret",
+			want:   want{err: errors.Errorf(errFmtNameHyphen, "my-secret")},
+		},
+		"LeadingDigit": {
+			reason: "A name starting with a digit should be rejected.",
+			name:   "1secret",
+			want:   want{err: errors.Errorf(errFmtNameInvalid, "1secret")},
+		},
+		"Reserved": {
+			reason: "A name with the reserved GITHUB_ prefix should be rejected, whatever its case.",
+			name:   "github_token",
+			want:   want{err: errors.Errorf(errFmtNameReserved, "github_token")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := NormalizeName(tc.name)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nNormalizeName(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.name, got); diff != "" {
+				t.Errorf("\n%s\nNormalizeName(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecretName(t *testing.T) {
+	cases := map[string]struct {
+		reason       string
+		name         *string
+		externalName string
+		want         string
+	}{
+		"ExternalName": {
+			reason:       "The external name should be used when no name is set.",
+			externalName: "mysecret",
+			want:         "MYSECRET",
+		},
+		"Name": {
+			reason:       "A set name should be used instead of the external name.",
+			name:         github.String("my_secret"),
+			externalName: "my-secret",
+			want:         "MY_SECRET",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := SecretName(tc.name, tc.externalName)
+			if err != nil {
+				t.Fatalf("\n%s\nSecretName(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nSecretName(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsEnvSecretUpToDate(t *testing.T) {
+	written := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	lastUpdate := metav1.NewTime(written)
+	secret := &github.Secret{Name: "TOKEN", UpdatedAt: github.Timestamp{Time: written}}
+
+	cases := map[string]struct {
+		reason string
+		o      v1alpha1.EnvironmentSecretObservation
+		s      *github.Secret
+		value  string
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A secret last written by this resource with the current value should be up to date.",
+			o:      v1alpha1.EnvironmentSecretObservation{EncryptValue: GenerateHash("s3cret"), LastUpdate: &lastUpdate},
+			s:      secret,
+			value:  "s3cret",
+			want:   true,
+		},
+		"LegacyHash": {
+			reason: "A legacy unversioned hash of the current value should match.",
+			o:      v1alpha1.EnvironmentSecretObservation{EncryptValue: ghclient.Hash([]byte("s3cret")), LastUpdate: &lastUpdate},
+			s:      secret,
+			value:  "s3cret",
+			want:   true,
+		},
+		"ValueChanged": {
+			reason: "A secret whose value changed should not be up to date.",
+			o:      v1alpha1.EnvironmentSecretObservation{EncryptValue: GenerateHash("s3cret"), LastUpdate: &lastUpdate},
+			s:      secret,
+			value:  "changed",
+			want:   false,
+		},
+		"NeverWritten": {
+			reason: "A secret this resource never wrote should not be up to date.",
+			o:      v1alpha1.EnvironmentSecretObservation{EncryptValue: GenerateHash("s3cret")},
+			s:      secret,
+			value:  "s3cret",
+			want:   false,
+		},
+		"UpdatedElsewhere": {
+			reason: "A secret updated since this resource last wrote it should not be up to date.",
+			o:      v1alpha1.EnvironmentSecretObservation{EncryptValue: GenerateHash("s3cret"), LastUpdate: &lastUpdate},
+			s:      &github.Secret{Name: "TOKEN", UpdatedAt: github.Timestamp{Time: written.Add(time.Minute)}},
+			value:  "s3cret",
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsEnvSecretUpToDate(tc.o, tc.s, tc.value)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsEnvSecretUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestListSelectedReposForOrgSecret(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/crossplane/actions/secrets/TOKEN/repositories" {
+			t.Errorf("ListSelectedReposForOrgSecret(...): unexpected path %s", r.URL.Path)
+		}
+		query = r.URL.Query()
+		w.Header().Set("Link", `<https://api.github.com/orgs/crossplane/actions/secrets/TOKEN/repositories?page=3&per_page=100>; rel="next"`)
+		_, _ = w.Write([]byte(`{"total_count":201,"repositories":[{"id":1}]}`))
+	}))
+	defer srv.Close()
+
+	c := github.NewClient(nil)
+	c.BaseURL, _ = url.Parse(srv.URL + "/")
+	s := &service{ActionsService: c.Actions, client: c}
+
+	l, res, err := s.ListSelectedReposForOrgSecret(context.Background(), "crossplane", "TOKEN", &github.ListOptions{Page: 2, PerPage: 100})
+	if err != nil {
+		t.Fatalf("ListSelectedReposForOrgSecret(...): %v", err)
+	}
+	if diff := cmp.Diff(url.Values{"page": {"2"}, "per_page": {"100"}}, query); diff != "" {
+		t.Errorf("ListSelectedReposForOrgSecret(...): -want query, +got query:\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]int64{1}, SelectedRepositoryIDs(l.Repositories)); diff != "" {
+		t.Errorf("ListSelectedReposForOrgSecret(...): -want repositories, +got repositories:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(3, res.NextPage); diff != "" {
+		t.Errorf("ListSelectedReposForOrgSecret(...): -want next page, +got next page:\n%s\n", diff)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides fakes of the services used to manage team resources.
+package fake
+
+import (
+	"context"
+
+	"github.com/google/go-github/v33/github"
+)
+
+// MockService is a mock Service.
+type MockService struct {
+	MockGetTeamBySlug    func(ctx context.Context, org, slug string) (*github.Team, *github.Response, error)
+	MockGetTeamByID      func(ctx context.Context, orgID, teamID int64) (*github.Team, *github.Response, error)
+	MockCreateTeam       func(ctx context.Context, org string, team github.NewTeam) (*github.Team, *github.Response, error)
+	MockEditTeamBySlug   func(ctx context.Context, org, slug string, team github.NewTeam, removeParent bool) (*github.Team, *github.Response, error)
+	MockDeleteTeamBySlug func(ctx context.Context, org, slug string) (*github.Response, error)
+}
+
+// GetTeamBySlug calls MockGetTeamBySlug.
+func (m *MockService) GetTeamBySlug(ctx context.Context, org, slug string) (*github.Team, *github.Response, error) {
+	return m.MockGetTeamBySlug(ctx, org, slug)
+}
+
+// GetTeamByID calls MockGetTeamByID.
+func (m *MockService) GetTeamByID(ctx context.Context, orgID, teamID int64) (*github.Team, *github.Response, error) {
+	return m.MockGetTeamByID(ctx, orgID, teamID)
+}
+
+// CreateTeam calls MockCreateTeam.
+func (m *MockService) CreateTeam(ctx context.Context, org string, team github.NewTeam) (*github.Team, *github.Response, error) {
+	return m.MockCreateTeam(ctx, org, team)
+}
+
+// EditTeamBySlug calls MockEditTeamBySlug.
+func (m *MockService) EditTeamBySlug(ctx context.Context, org, slug string, team github.NewTeam, removeParent bool) (*github.Team, *github.Response, error) {
+	return m.MockEditTeamBySlug(ctx, org, slug, team, removeParent)
+}
+
+// DeleteTeamBySlug calls MockDeleteTeamBySlug.
+func (m *MockService) DeleteTeamBySlug(ctx context.Context, org, slug string) (*github.Response, error) {
+	return m.MockDeleteTeamBySlug(ctx, org, slug)
+}
+
+// MockMembershipService is a mock MembershipService.
+type MockMembershipService struct {
+	MockGetTeamMembershipBySlug    func(ctx context.Context, org, slug, user string) (*github.Membership, *github.Response, error)
+	MockAddTeamMembershipBySlug    func(ctx context.Context, org, slug, user string, opts *github.TeamAddTeamMembershipOptions) (*github.Membership, *github.Response, error)
+	MockRemoveTeamMembershipBySlug func(ctx context.Context, org, slug, user string) (*github.Response, error)
+}
+
+// GetTeamMembershipBySlug calls MockGetTeamMembershipBySlug.
+func (m *MockMembershipService) GetTeamMembershipBySlug(ctx context.Context, org, slug, user string) (*github.Membership, *github.Response, error) {
+	return m.MockGetTeamMembershipBySlug(ctx, org, slug, user)
+}
+
+// AddTeamMembershipBySlug calls MockAddTeamMembershipBySlug.
+func (m *MockMembershipService) AddTeamMembershipBySlug(ctx context.Context, org, slug, user string, opts *github.TeamAddTeamMembershipOptions) (*github.Membership, *github.Response, error) {
+	return m.MockAddTeamMembershipBySlug(ctx, org, slug, user, opts)
+}
+
+// RemoveTeamMembershipBySlug calls MockRemoveTeamMembershipBySlug.
+func (m *MockMembershipService) RemoveTeamMembershipBySlug(ctx context.Context, org, slug, user string) (*github.Response, error) {
+	return m.MockRemoveTeamMembershipBySlug(ctx, org, slug, user)
+}
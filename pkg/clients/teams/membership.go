@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teams
+
+import (
+	"context"
+
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane-contrib/provider-github/apis/teams/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+// MembershipService defines the Teams operations used to manage a
+// TeamMembership.
+type MembershipService interface {
+	GetTeamMembershipBySlug(ctx context.Context, org, slug, user string) (*github.Membership, *github.Response, error)
+	AddTeamMembershipBySlug(ctx context.Context, org, slug, user string, opts *github.TeamAddTeamMembershipOptions) (*github.Membership, *github.Response, error)
+	RemoveTeamMembershipBySlug(ctx context.Context, org, slug, user string) (*github.Response, error)
+}
+
+// NewMembershipService creates a new MembershipService backed by the Teams
+// service of a GitHub client built from the supplied Config.
+func NewMembershipService(cfg *ghclient.Config) (MembershipService, error) {
+	c, err := ghclient.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c.Teams, nil
+}
+
+// GenerateMembershipObservation produces a TeamMembershipObservation from the
+// supplied membership.
+func GenerateMembershipObservation(m *github.Membership) v1alpha1.TeamMembershipObservation {
+	return v1alpha1.TeamMembershipObservation{
+		State: m.State,
+		Role:  m.Role,
+	}
+}
+
+// LateInitializeMembership fills the unset fields of the supplied
+// TeamMembershipParameters with the values of the supplied membership.
+func LateInitializeMembership(p *v1alpha1.TeamMembershipParameters, m *github.Membership) {
+	if p.Role == nil {
+		p.Role = m.Role
+	}
+}
+
+// IsMembershipUpToDate returns true if the supplied membership matches the
+// supplied TeamMembershipParameters.
+func IsMembershipUpToDate(p v1alpha1.TeamMembershipParameters, m *github.Membership) bool {
+	return p.Role == nil || *p.Role == m.GetRole()
+}
+
+// GenerateAddMembershipOptions produces the options used to add, or change
+// the role of, a team member from the supplied TeamMembershipParameters.
+func GenerateAddMembershipOptions(p v1alpha1.TeamMembershipParameters) *github.TeamAddTeamMembershipOptions {
+	o := &github.TeamAddTeamMembershipOptions{}
+	if p.Role != nil {
+		o.Role = *p.Role
+	}
+	return o
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teams
+
+import (
+	"context"
+
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane-contrib/provider-github/apis/teams/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+// Service defines the Teams operations used to manage a Team.
+type Service interface {
+	GetTeamBySlug(ctx context.Context, org, slug string) (*github.Team, *github.Response, error)
+	GetTeamByID(ctx context.Context, orgID, teamID int64) (*github.Team, *github.Response, error)
+	CreateTeam(ctx context.Context, org string, team github.NewTeam) (*github.Team, *github.Response, error)
+	EditTeamBySlug(ctx context.Context, org, slug string, team github.NewTeam, removeParent bool) (*github.Team, *github.Response, error)
+	DeleteTeamBySlug(ctx context.Context, org, slug string) (*github.Response, error)
+}
+
+// NewService creates a new Service backed by the Teams service of a GitHub
+// client built from the supplied Config.
+func NewService(cfg *ghclient.Config) (Service, error) {
+	c, err := ghclient.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c.Teams, nil
+}
+
+// GenerateObservation produces a TeamObservation from the supplied team.
+func GenerateObservation(t *github.Team) v1alpha1.TeamObservation {
+	o := v1alpha1.TeamObservation{
+		ID:     t.ID,
+		NodeID: t.NodeID,
+		Slug:   t.Slug,
+	}
+	if t.Organization != nil {
+		o.OrganizationID = t.Organization.ID
+	}
+	return o
+}
+
+// LateInitialize fills the unset fields of the supplied TeamParameters with
+// the values of the supplied team.
+func LateInitialize(p *v1alpha1.TeamParameters, t *github.Team) {
+	if p.Description == nil {
+		p.Description = t.Description
+	}
+	if p.Privacy == nil {
+		p.Privacy = t.Privacy
+	}
+	if p.ParentTeam == nil && t.Parent != nil {
+		p.ParentTeam = t.Parent.Slug
+	}
+}
+
+// IsUpToDate returns true if the supplied team matches the supplied
+// TeamParameters.
+func IsUpToDate(p v1alpha1.TeamParameters, t *github.Team) bool {
+	if p.Name != t.GetName() {
+		return false
+	}
+	if p.Description != nil && *p.Description != t.GetDescription() {
+		return false
+	}
+	if p.Privacy != nil && *p.Privacy != t.GetPrivacy() {
+		return false
+	}
+	if p.ParentTeam != nil && *p.ParentTeam != t.GetParent().GetSlug() {
+		return false
+	}
+	return true
+}
+
+// GenerateNewTeam produces the request to create or edit a team from the
+// supplied TeamParameters. The parent team is identified by its ID, which
+// the caller resolves from ParentTeam.
+func GenerateNewTeam(p v1alpha1.TeamParameters, parentID *int64) github.NewTeam {
+	return github.NewTeam{
+		Name:         p.Name,
+		Description:  p.Description,
+		Privacy:      p.Privacy,
+		ParentTeamID: parentID,
+	}
+}
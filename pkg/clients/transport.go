@@ -0,0 +1,240 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+// limiters holds one token bucket per ProviderConfig so that every client
+// built from the same ProviderConfig shares a single request budget.
+var limiters = struct {
+	sync.Mutex
+	m map[string]*rate.Limiter
+}{m: map[string]*rate.Limiter{}}
+
+// limiterFor returns the shared limiter for the named ProviderConfig, updating
+// its rate if the configured value changed. It returns nil when rps is not
+// positive, meaning requests are not throttled.
+func limiterFor(name string, rps int) *rate.Limiter {
+	limiters.Lock()
+	defer limiters.Unlock()
+
+	if rps <= 0 {
+		delete(limiters.m, name)
+		return nil
+	}
+	l, ok := limiters.m[name]
+	if !ok {
+		l = rate.NewLimiter(rate.Limit(rps), rps)
+		limiters.m[name] = l
+		return l
+	}
+	if l.Limit() != rate.Limit(rps) {
+		l.SetLimit(rate.Limit(rps))
+		l.SetBurst(rps)
+	}
+	return l
+}
+
+// throttledTransport delays requests until the limiter permits them.
+type throttledTransport struct {
+	limiter *rate.Limiter
+	base    http.RoundTripper
+}
+
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+type loggerKey struct{}
+
+// WithLogger returns a copy of the supplied context that carries the supplied
+// logger. Clients are shared by every controller that uses the same
+// ProviderConfig, so warnings about a request, such as GitHub reporting that
+// its endpoint is deprecated, are logged to the logger of the context it was
+// made with.
+func WithLogger(ctx context.Context, l logging.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// loggerFrom returns the logger carried by the supplied context, or a logger
+// that discards everything if it carries none.
+func loggerFrom(ctx context.Context) logging.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(logging.Logger); ok {
+		return l
+	}
+	return logging.NewNopLogger()
+}
+
+// warnedEndpoints records the endpoints for which a deprecation warning has
+// already been logged, so that each is only reported once per process.
+var warnedEndpoints sync.Map
+
+// deprecationTransport logs a warning the first time GitHub reports that an
+// endpoint is deprecated or scheduled for removal, to the logger of the
+// request's context.
+type deprecationTransport struct {
+	base http.RoundTripper
+}
+
+func (t *deprecationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		return res, err
+	}
+	deprecation, sunset := res.Header.Get("Deprecation"), res.Header.Get("Sunset")
+	if deprecation == "" && sunset == "" {
+		return res, nil
+	}
+	endpoint := req.Method + " " + req.URL.Path
+	if _, warned := warnedEndpoints.LoadOrStore(endpoint, true); !warned {
+		loggerFrom(req.Context()).Info("Warning: GitHub API endpoint is deprecated",
+			"endpoint", endpoint,
+			"deprecation", deprecation,
+			"sunset", sunset,
+			"link", res.Header.Get("Link"))
+	}
+	return res, nil
+}
+
+// maxRetryAfter is the longest a rate limited request waits to be retried.
+// Requests that GitHub asks to wait longer for fail instead, rather than block
+// a reconcile.
+const maxRetryAfter = time.Minute
+
+// retryTransport retries requests that fail with a network error, a 5xx
+// response or a rate limit response, waiting an exponentially increasing
+// backoff between attempts. Rate limited requests wait as long as GitHub asks
+// instead.
+type retryTransport struct {
+	maxRetries    int
+	backoff       time.Duration
+	nonIdempotent bool
+	base          http.RoundTripper
+
+	// after returns a channel that receives once the supplied delay has
+	// passed. Nil means time.After.
+	after func(time.Duration) <-chan time.Time
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	after := t.after
+	if after == nil {
+		after = time.After
+	}
+	wait := t.backoff
+	for attempt := 0; ; attempt++ {
+		res, err := t.base.RoundTrip(req)
+		if attempt >= t.maxRetries || !t.retryable(req) || !transient(res, err) {
+			return res, err
+		}
+		// Only waits chosen by the backoff grow it. Waits GitHub asked for
+		// don't, so a rate limited attempt doesn't lengthen the backoff of
+		// the attempts after it.
+		delay, limited := retryAfter(res, time.Now())
+		if limited && delay > maxRetryAfter {
+			return res, err
+		}
+		if !limited {
+			delay = wait
+			wait *= 2
+		}
+		if res != nil {
+			// Drain the body so the underlying connection can be reused.
+			_, _ = io.Copy(ioutil.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-after(delay):
+		}
+
+		if req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// retryable returns true if the supplied request may safely be sent again.
+func (t *retryTransport) retryable(req *http.Request) bool {
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return t.nonIdempotent
+}
+
+// transient returns true if the supplied result of a round trip indicates an
+// error that may succeed if retried.
+func transient(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	if res.StatusCode == http.StatusForbidden || res.StatusCode == http.StatusTooManyRequests {
+		_, limited := retryAfter(res, time.Now())
+		return limited
+	}
+	return res.StatusCode >= http.StatusInternalServerError
+}
+
+// retryAfter returns how long GitHub asked the client to wait before sending
+// the request that produced the supplied rate limited response again. It
+// returns false if the response does not ask the client to wait.
+func retryAfter(res *http.Response, now time.Time) (time.Duration, bool) {
+	if res == nil || (res.StatusCode != http.StatusForbidden && res.StatusCode != http.StatusTooManyRequests) {
+		return 0, false
+	}
+	if v := res.Header.Get("Retry-After"); v != "" {
+		if s, err := strconv.Atoi(v); err == nil {
+			return time.Duration(s) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return t.Sub(now), true
+		}
+	}
+	if res.Header.Get("X-RateLimit-Remaining") != "0" {
+		return 0, false
+	}
+	reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Unix(reset, 0).Sub(now), true
+}
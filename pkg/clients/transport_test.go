@@ -0,0 +1,329 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+// reply is a scripted response of a test server.
+type reply struct {
+	status     int
+	retryAfter string
+}
+
+// scriptedServer returns a server that answers each request with the next of
+// the supplied replies, and the body of each request it received.
+func scriptedServer(replies ...reply) (*httptest.Server, *[]string) {
+	bodies := &[]string{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		*bodies = append(*bodies, string(b))
+
+		rp := replies[len(*bodies)-1]
+		if rp.retryAfter != "" {
+			w.Header().Set("Retry-After", rp.retryAfter)
+		}
+		w.WriteHeader(rp.status)
+	})), bodies
+}
+
+func TestRetryTransport(t *testing.T) {
+	type want struct {
+		status int
+		bodies []string
+		delays []time.Duration
+	}
+
+	cases := map[string]struct {
+		reason        string
+		method        string
+		body          string
+		maxRetries    int
+		nonIdempotent bool
+		replies       []reply
+		want          want
+	}{
+		"RetriedUnavailable": {
+			reason:     "A request that fails with a 503 should be retried after the backoff.",
+			method:     http.MethodGet,
+			maxRetries: 3,
+			replies:    []reply{{status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			want:       want{status: http.StatusOK, bodies: []string{"", ""}, delays: []time.Duration{time.Second}},
+		},
+		"BackoffDoubles": {
+			reason:     "Each retry chosen by the backoff should wait twice as long as the last.",
+			method:     http.MethodGet,
+			maxRetries: 3,
+			replies:    []reply{{status: http.StatusBadGateway}, {status: http.StatusServiceUnavailable}, {status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			want:       want{status: http.StatusOK, bodies: []string{"", "", "", ""}, delays: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}},
+		},
+		"RetryAfterDoesNotGrowBackoff": {
+			reason:     "Waiting as long as GitHub asked should not lengthen the backoff of later retries.",
+			method:     http.MethodGet,
+			maxRetries: 3,
+			replies:    []reply{{status: http.StatusTooManyRequests, retryAfter: "5"}, {status: http.StatusServiceUnavailable}, {status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			want:       want{status: http.StatusOK, bodies: []string{"", "", "", ""}, delays: []time.Duration{5 * time.Second, time.Second, 2 * time.Second}},
+		},
+		"RetryAfterTooLong": {
+			reason:     "A rate limited request GitHub asks to wait too long for should fail rather than block.",
+			method:     http.MethodGet,
+			maxRetries: 3,
+			replies:    []reply{{status: http.StatusTooManyRequests, retryAfter: "3600"}},
+			want:       want{status: http.StatusTooManyRequests, bodies: []string{""}},
+		},
+		"RetriesExhausted": {
+			reason:     "The last response should be returned once the retries are exhausted.",
+			method:     http.MethodGet,
+			maxRetries: 1,
+			replies:    []reply{{status: http.StatusServiceUnavailable}, {status: http.StatusServiceUnavailable}},
+			want:       want{status: http.StatusServiceUnavailable, bodies: []string{"", ""}, delays: []time.Duration{time.Second}},
+		},
+		"NotTransient": {
+			reason:     "A request that fails with a client error should not be retried.",
+			method:     http.MethodGet,
+			maxRetries: 3,
+			replies:    []reply{{status: http.StatusNotFound}},
+			want:       want{status: http.StatusNotFound, bodies: []string{""}},
+		},
+		"NonIdempotent": {
+			reason:     "A POST should not be retried unless non-idempotent retries are enabled.",
+			method:     http.MethodPost,
+			body:       `{"name":"a"}`,
+			maxRetries: 3,
+			replies:    []reply{{status: http.StatusServiceUnavailable}},
+			want:       want{status: http.StatusServiceUnavailable, bodies: []string{`{"name":"a"}`}},
+		},
+		"NonIdempotentEnabled": {
+			reason:        "A POST should be retried with its body when non-idempotent retries are enabled.",
+			method:        http.MethodPost,
+			body:          `{"name":"a"}`,
+			maxRetries:    3,
+			nonIdempotent: true,
+			replies:       []reply{{status: http.StatusServiceUnavailable}, {status: http.StatusCreated}},
+			want:          want{status: http.StatusCreated, bodies: []string{`{"name":"a"}`, `{"name":"a"}`}, delays: []time.Duration{time.Second}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, bodies := scriptedServer(tc.replies...)
+			defer srv.Close()
+
+			var delays []time.Duration
+			rt := &retryTransport{
+				maxRetries:    tc.maxRetries,
+				backoff:       time.Second,
+				nonIdempotent: tc.nonIdempotent,
+				base:          http.DefaultTransport,
+				after: func(d time.Duration) <-chan time.Time {
+					delays = append(delays, d)
+					c := make(chan time.Time, 1)
+					c <- time.Time{}
+					return c
+				},
+			}
+
+			req, _ := http.NewRequest(tc.method, srv.URL, nil)
+			if tc.body != "" {
+				req, _ = http.NewRequest(tc.method, srv.URL, strings.NewReader(tc.body))
+			}
+			res, err := rt.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("\n%s\nRoundTrip(...): %v", tc.reason, err)
+			}
+			_ = res.Body.Close()
+
+			if diff := cmp.Diff(tc.want.status, res.StatusCode); diff != "" {
+				t.Errorf("\n%s\nRoundTrip(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.bodies, *bodies); diff != "" {
+				t.Errorf("\n%s\nRoundTrip(...): -want requests, +got requests:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.delays, delays); diff != "" {
+				t.Errorf("\n%s\nRoundTrip(...): -want delays, +got delays:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// roundTripperFunc is an http.RoundTripper implemented by a function.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// okTransport is a round tripper that answers every request with a 200.
+var okTransport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+})
+
+// forgetLimiters removes the limiters of the named ProviderConfigs when the
+// test ends, so they don't leak into other tests.
+func forgetLimiters(t *testing.T, names ...string) {
+	t.Cleanup(func() {
+		for _, n := range names {
+			limiterFor(n, 0)
+		}
+	})
+}
+
+func TestThrottledTransport(t *testing.T) {
+	const rps = 20
+	forgetLimiters(t, "throttle-test")
+	tr := &throttledTransport{limiter: limiterFor("throttle-test", rps), base: okTransport}
+
+	// The bucket starts full, so the first rps requests are sent at once and
+	// each of the rest waits for a token.
+	const requests = rps + 10
+	start := time.Now()
+	for i := 0; i < requests; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/", nil)
+		if _, err := tr.RoundTrip(req); err != nil {
+			t.Fatalf("RoundTrip(...): %v", err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	min := time.Duration(requests-rps) * time.Second / rps
+	if elapsed < min*9/10 {
+		t.Errorf("RoundTrip(...): want %d requests to take at least %s at %d requests per second, took %s", requests, min, rps, elapsed)
+	}
+	if elapsed > 5*min {
+		t.Errorf("RoundTrip(...): want %d requests to take about %s at %d requests per second, took %s", requests, min, rps, elapsed)
+	}
+}
+
+func TestThrottledTransportCancelled(t *testing.T) {
+	forgetLimiters(t, "throttle-test-cancelled")
+	l := limiterFor("throttle-test-cancelled", 1)
+	l.Allow()
+	tr := &throttledTransport{limiter: l, base: okTransport}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/", nil)
+	if _, err := tr.RoundTrip(req); err == nil {
+		t.Errorf("RoundTrip(...): want an error for a request cancelled while waiting to be sent")
+	}
+}
+
+func TestLimiterFor(t *testing.T) {
+	forgetLimiters(t, "limiter-test-a", "limiter-test-b")
+
+	a := limiterFor("limiter-test-a", 5)
+	if a == nil || a.Limit() != 5 || a.Burst() != 5 {
+		t.Fatalf("limiterFor(...): want a limiter of 5 requests per second, got %v", a)
+	}
+	if limiterFor("limiter-test-a", 5) != a {
+		t.Errorf("limiterFor(...): want clients of the same ProviderConfig to share a limiter")
+	}
+	if b := limiterFor("limiter-test-b", 5); b == a {
+		t.Errorf("limiterFor(...): want each ProviderConfig to have its own limiter")
+	}
+	if limiterFor("limiter-test-a", 10) != a || a.Limit() != 10 || a.Burst() != 10 {
+		t.Errorf("limiterFor(...): want a changed rate to update the shared limiter, got %v requests per second", a.Limit())
+	}
+	if l := limiterFor("limiter-test-a", 0); l != nil {
+		t.Errorf("limiterFor(...): want no limiter when requests are not throttled, got %v", l)
+	}
+}
+
+// recordingLogger records the messages logged to it.
+type recordingLogger struct {
+	msgs *[]string
+}
+
+func (l recordingLogger) Info(msg string, _ ...interface{})  { *l.msgs = append(*l.msgs, msg) }
+func (l recordingLogger) Debug(msg string, _ ...interface{}) { *l.msgs = append(*l.msgs, msg) }
+func (l recordingLogger) WithValues(_ ...interface{}) logging.Logger {
+	return l
+}
+
+func TestDeprecationTransport(t *testing.T) {
+	const warning = "Warning: GitHub API endpoint is deprecated"
+
+	cases := map[string]struct {
+		reason   string
+		header   http.Header
+		requests []string
+		want     []string
+	}{
+		"NotDeprecated": {
+			reason:   "Responses without a Deprecation or Sunset header should not be logged.",
+			requests: []string{"/deprecation-test/current"},
+		},
+		"Deprecated": {
+			reason:   "A response with a Deprecation header should be logged.",
+			header:   http.Header{"Deprecation": []string{"true"}},
+			requests: []string{"/deprecation-test/deprecated"},
+			want:     []string{warning},
+		},
+		"Sunset": {
+			reason:   "A response with a Sunset header should be logged.",
+			header:   http.Header{"Sunset": []string{"Wed, 11 Nov 2020 23:59:59 GMT"}},
+			requests: []string{"/deprecation-test/sunset"},
+			want:     []string{warning},
+		},
+		"SameEndpoint": {
+			reason:   "Each deprecated endpoint should only be logged once.",
+			header:   http.Header{"Deprecation": []string{"true"}},
+			requests: []string{"/deprecation-test/same", "/deprecation-test/same"},
+			want:     []string{warning},
+		},
+		"OtherEndpoint": {
+			reason:   "Each deprecated endpoint should be logged.",
+			header:   http.Header{"Deprecation": []string{"true"}},
+			requests: []string{"/deprecation-test/one", "/deprecation-test/other"},
+			want:     []string{warning, warning},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Cleanup(func() {
+				for _, p := range tc.requests {
+					warnedEndpoints.Delete(http.MethodGet + " " + p)
+				}
+			})
+			tr := &deprecationTransport{base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusOK, Header: tc.header, Body: http.NoBody, Request: req}, nil
+			})}
+
+			var got []string
+			ctx := WithLogger(context.Background(), recordingLogger{msgs: &got})
+			for _, p := range tc.requests {
+				req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com"+p, nil)
+				if _, err := tr.RoundTrip(req); err != nil {
+					t.Fatalf("\n%s\nRoundTrip(...): %v", tc.reason, err)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nRoundTrip(...): -want log, +got log:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/actions/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errUnexpectedObject = "The managed resource is not a SelfHostedRunner resource"
+	errListRunners      = "cannot list self-hosted runners"
+	errCreateToken      = "cannot create runner registration token"
+	errRemoveRunner     = "cannot remove self-hosted runner"
+
+	// ConnectionSecretKeyToken is the connection secret key under which the
+	// runner registration token is published.
+	ConnectionSecretKeyToken = "token"
+
+	runnerStatusOnline = "online"
+)
+
+// SetupSelfHostedRunner adds a controller that reconciles SelfHostedRunners.
+func SetupSelfHostedRunner(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.SelfHostedRunnerGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.SelfHostedRunnerGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.SelfHostedRunner{}).
+		Complete(reconciler.New(mgr, of, &connector{client: mgr.GetClient(), newClientFn: ghclient.NewClient, logger: logger}, logger, recorder,
+			managed.WithConnectionPublishers(managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme()))))
+}
+
+type connector struct {
+	client      client.Client
+	newClientFn func(*ghclient.Config) (*github.Client, error)
+	logger      logging.Logger
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.SelfHostedRunner)
+	if !ok {
+		return nil, errors.New(errUnexpectedObject)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	gh, err := c.newClientFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{gh, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type external struct {
+	client *github.Client
+	logger logging.Logger
+}
+
+func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.SelfHostedRunner)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errUnexpectedObject)
+	}
+
+	r, err := e.findRunner(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, ghclient.Wrap(err, errListRunners)
+	}
+
+	if r == nil {
+		// The runner registers itself using the token we issued, so until that
+		// token expires there is nothing to create.
+		exp := cr.Status.AtProvider.RegistrationTokenExpiresAt
+		if exp == nil || !time.Now().Before(exp.Time) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		e.logger.Debug("Waiting for runner to register", "name", cr.Spec.ForProvider.Name)
+		cr.SetConditions(xpv1.Creating())
+		return managed.ExternalObservation{
+			ResourceUpToDate: true,
+			ResourceExists:   true,
+		}, nil
+	}
+
+	cr.Status.AtProvider = generateObservation(r)
+	if r.GetStatus() == runnerStatusOnline {
+		cr.SetConditions(xpv1.Available())
+	} else {
+		cr.SetConditions(xpv1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceUpToDate: true,
+		ResourceExists:   true,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.SelfHostedRunner)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errUnexpectedObject)
+	}
+
+	p := cr.Spec.ForProvider
+	var (
+		t   *github.RegistrationToken
+		err error
+	)
+	if p.Repository != nil {
+		t, _, err = e.client.Actions.CreateRegistrationToken(ctx, p.Organization, *p.Repository)
+	} else {
+		t, _, err = e.client.Actions.CreateOrganizationRegistrationToken(ctx, p.Organization)
+	}
+	if err != nil {
+		return managed.ExternalCreation{}, ghclient.Wrap(err, errCreateToken)
+	}
+
+	if t.ExpiresAt != nil {
+		exp := metav1.NewTime(t.ExpiresAt.Time)
+		cr.Status.AtProvider.RegistrationTokenExpiresAt = &exp
+	}
+
+	return managed.ExternalCreation{
+		ConnectionDetails: managed.ConnectionDetails{
+			ConnectionSecretKeyToken: []byte(t.GetToken()),
+		},
+	}, nil
+}
+
+func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	// Runners are configured on the host they run on; there is nothing to
+	// update through the API.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.SelfHostedRunner)
+	if !ok {
+		return errors.New(errUnexpectedObject)
+	}
+
+	id := cr.Status.AtProvider.ID
+	if id == nil {
+		// The runner never registered, so there is nothing to deregister.
+		return nil
+	}
+
+	p := cr.Spec.ForProvider
+	var err error
+	if p.Repository != nil {
+		_, err = e.client.Actions.RemoveRunner(ctx, p.Organization, *p.Repository, *id)
+	} else {
+		_, err = e.client.Actions.RemoveOrganizationRunner(ctx, p.Organization, *id)
+	}
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	return ghclient.Wrap(err, errRemoveRunner)
+}
+
+// findRunner returns the runner registered under the configured name, or nil
+// if no such runner is registered.
+func (e *external) findRunner(ctx context.Context, p v1alpha1.SelfHostedRunnerParameters) (*github.Runner, error) {
+	var found *github.Runner
+	err := ghclient.ListAll(&github.ListOptions{}, func(opts *github.ListOptions) (*github.Response, error) {
+		var (
+			runners *github.Runners
+			res     *github.Response
+			err     error
+		)
+		if p.Repository != nil {
+			runners, res, err = e.client.Actions.ListRunners(ctx, p.Organization, *p.Repository, opts)
+		} else {
+			runners, res, err = e.client.Actions.ListOrganizationRunners(ctx, p.Organization, opts)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range runners.Runners {
+			if r.GetName() == p.Name {
+				found = r
+				return nil, nil
+			}
+		}
+		return res, nil
+	})
+	return found, err
+}
+
+func generateObservation(r *github.Runner) v1alpha1.SelfHostedRunnerObservation {
+	o := v1alpha1.SelfHostedRunnerObservation{
+		ID:     r.ID,
+		OS:     r.OS,
+		Status: r.Status,
+		Busy:   r.Busy,
+	}
+	for _, l := range r.Labels {
+		o.Labels = append(o.Labels, l.GetName())
+	}
+	return o
+}
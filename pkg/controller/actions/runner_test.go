@@ -0,0 +1,326 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v33/github"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/actions/v1alpha1"
+)
+
+const (
+	runnerOrg  = "crossplane"
+	runnerRepo = "provider-github"
+	runnerName = "builder"
+	runnerID   = int64(23)
+)
+
+// response is a canned response of the GitHub API.
+type response struct {
+	status int
+	body   string
+}
+
+// githubAPI returns a GitHub client that talks to a server serving the
+// supplied responses, keyed by request method and path, and the requests it
+// received. Requests for which there is no response fail with a 404.
+func githubAPI(t *testing.T, responses map[string]response) (*github.Client, *[]string) {
+	t.Helper()
+	requests := &[]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+		*requests = append(*requests, key)
+		res, ok := responses[key]
+		if !ok {
+			res = response{status: http.StatusNotFound, body: `{"message": "Not Found"}`}
+		}
+		if res.status == 0 {
+			res.status = http.StatusOK
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(res.status)
+		_, _ = w.Write([]byte(res.body))
+	}))
+	t.Cleanup(srv.Close)
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	return gh, requests
+}
+
+func runner(o ...func(*v1alpha1.SelfHostedRunner)) *v1alpha1.SelfHostedRunner {
+	cr := &v1alpha1.SelfHostedRunner{}
+	cr.SetName(runnerName)
+	cr.Spec.ForProvider = v1alpha1.SelfHostedRunnerParameters{Organization: runnerOrg, Name: runnerName}
+	for _, fn := range o {
+		fn(cr)
+	}
+	return cr
+}
+
+func inRepository(cr *v1alpha1.SelfHostedRunner) {
+	cr.Spec.ForProvider.Repository = github.String(runnerRepo)
+}
+
+func tokenExpiresIn(d time.Duration) func(*v1alpha1.SelfHostedRunner) {
+	return func(cr *v1alpha1.SelfHostedRunner) {
+		exp := metav1.NewTime(time.Now().Add(d))
+		cr.Status.AtProvider.RegistrationTokenExpiresAt = &exp
+	}
+}
+
+func registered(cr *v1alpha1.SelfHostedRunner) {
+	cr.Status.AtProvider.ID = github.Int64(runnerID)
+}
+
+func runners(status string) response {
+	return response{body: fmt.Sprintf(`{"total_count": 2, "runners": [
+		{"id": 1, "name": "other", "os": "linux", "status": "online"},
+		{"id": %d, "name": %q, "os": "linux", "status": %q, "busy": true, "labels": [{"name": "self-hosted"}, {"name": "linux"}]}
+	]}`, runnerID, runnerName, status)}
+}
+
+var (
+	orgRunners  = "GET /orgs/" + runnerOrg + "/actions/runners"
+	repoRunners = "GET /repos/" + runnerOrg + "/" + runnerRepo + "/actions/runners"
+)
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o      managed.ExternalObservation
+		obs    v1alpha1.SelfHostedRunnerObservation
+		status corev1.ConditionStatus
+		err    bool
+	}
+
+	cases := map[string]struct {
+		reason    string
+		responses map[string]response
+		cr        *v1alpha1.SelfHostedRunner
+		want      want
+	}{
+		"NotRegistered": {
+			reason:    "A runner that has not registered and has no registration token should not exist.",
+			responses: map[string]response{orgRunners: {body: `{"total_count": 0, "runners": []}`}},
+			cr:        runner(),
+			want:      want{o: managed.ExternalObservation{}},
+		},
+		"TokenExpired": {
+			reason:    "A runner that did not register before its registration token expired should not exist.",
+			responses: map[string]response{orgRunners: {body: `{"total_count": 0, "runners": []}`}},
+			cr:        runner(tokenExpiresIn(-time.Minute)),
+			want:      want{o: managed.ExternalObservation{}},
+		},
+		"WaitingToRegister": {
+			reason:    "A runner whose registration token is still valid should exist while it registers.",
+			responses: map[string]response{orgRunners: {body: `{"total_count": 0, "runners": []}`}},
+			cr:        runner(tokenExpiresIn(time.Hour)),
+			want:      want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, status: corev1.ConditionFalse},
+		},
+		"Online": {
+			reason:    "An online organization runner should be available.",
+			responses: map[string]response{orgRunners: runners("online")},
+			cr:        runner(),
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs:    v1alpha1.SelfHostedRunnerObservation{ID: github.Int64(runnerID), OS: github.String("linux"), Status: github.String("online"), Busy: github.Bool(true), Labels: []string{"self-hosted", "linux"}},
+				status: corev1.ConditionTrue,
+			},
+		},
+		"OfflineRepositoryRunner": {
+			reason:    "An offline repository runner should exist but be unavailable.",
+			responses: map[string]response{repoRunners: runners("offline")},
+			cr:        runner(inRepository),
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs:    v1alpha1.SelfHostedRunnerObservation{ID: github.Int64(runnerID), OS: github.String("linux"), Status: github.String("offline"), Busy: github.Bool(true), Labels: []string{"self-hosted", "linux"}},
+				status: corev1.ConditionFalse,
+			},
+		},
+		"ListError": {
+			reason:    "Errors listing runners should be returned.",
+			responses: map[string]response{orgRunners: {status: http.StatusInternalServerError}},
+			cr:        runner(),
+			want:      want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gh, _ := githubAPI(t, tc.responses)
+			e := &external{client: gh, logger: logging.NewNopLogger()}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.status == "" {
+				return
+			}
+			if diff := cmp.Diff(tc.want.status, tc.cr.GetCondition(xpv1.TypeReady).Status); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want ready, +got ready:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.obs.ID == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.obs, tc.cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	expires := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	token := response{status: http.StatusCreated, body: `{"token": "AABBCC", "expires_at": "2021-03-01T12:00:00Z"}`}
+
+	type want struct {
+		c        managed.ExternalCreation
+		expires  *metav1.Time
+		requests []string
+		err      bool
+	}
+
+	cases := map[string]struct {
+		reason    string
+		responses map[string]response
+		cr        *v1alpha1.SelfHostedRunner
+		want      want
+	}{
+		"Organization": {
+			reason:    "An organization runner should be issued an organization registration token.",
+			responses: map[string]response{"POST /orgs/" + runnerOrg + "/actions/runners/registration-token": token},
+			cr:        runner(),
+			want: want{
+				c:        managed.ExternalCreation{ConnectionDetails: managed.ConnectionDetails{ConnectionSecretKeyToken: []byte("AABBCC")}},
+				expires:  &metav1.Time{Time: expires},
+				requests: []string{"POST /orgs/" + runnerOrg + "/actions/runners/registration-token"},
+			},
+		},
+		"Repository": {
+			reason:    "A repository runner should be issued a repository registration token.",
+			responses: map[string]response{"POST /repos/" + runnerOrg + "/" + runnerRepo + "/actions/runners/registration-token": token},
+			cr:        runner(inRepository),
+			want: want{
+				c:        managed.ExternalCreation{ConnectionDetails: managed.ConnectionDetails{ConnectionSecretKeyToken: []byte("AABBCC")}},
+				expires:  &metav1.Time{Time: expires},
+				requests: []string{"POST /repos/" + runnerOrg + "/" + runnerRepo + "/actions/runners/registration-token"},
+			},
+		},
+		"Error": {
+			reason: "Errors creating a registration token should be returned.",
+			cr:     runner(),
+			want: want{
+				requests: []string{"POST /orgs/" + runnerOrg + "/actions/runners/registration-token"},
+				err:      true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gh, requests := githubAPI(t, tc.responses)
+			e := &external{client: gh, logger: logging.NewNopLogger()}
+			c, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.c, c); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.expires, tc.cr.Status.AtProvider.RegistrationTokenExpiresAt, cmp.Comparer(func(a, b metav1.Time) bool { return a.Equal(&b) })); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want token expiry, +got token expiry:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, *requests); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want requests, +got requests:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	orgRunner := fmt.Sprintf("DELETE /orgs/%s/actions/runners/%d", runnerOrg, runnerID)
+	repoRunner := fmt.Sprintf("DELETE /repos/%s/%s/actions/runners/%d", runnerOrg, runnerRepo, runnerID)
+
+	type want struct {
+		requests []string
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason    string
+		responses map[string]response
+		cr        *v1alpha1.SelfHostedRunner
+		want      want
+	}{
+		"NeverRegistered": {
+			reason: "A runner that never registered has nothing to remove.",
+			cr:     runner(),
+			want:   want{},
+		},
+		"Organization": {
+			reason:    "A registered organization runner should be removed from the organization.",
+			responses: map[string]response{orgRunner: {status: http.StatusNoContent}},
+			cr:        runner(registered),
+			want:      want{requests: []string{orgRunner}},
+		},
+		"Repository": {
+			reason:    "A registered repository runner should be removed from the repository.",
+			responses: map[string]response{repoRunner: {status: http.StatusNoContent}},
+			cr:        runner(registered, inRepository),
+			want:      want{requests: []string{repoRunner}},
+		},
+		"AlreadyRemoved": {
+			reason: "A runner that was already removed should be considered deleted.",
+			cr:     runner(registered),
+			want:   want{requests: []string{orgRunner}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gh, requests := githubAPI(t, tc.responses)
+			e := &external{client: gh, logger: logging.NewNopLogger()}
+			err := e.Delete(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, *requests, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want requests, +got requests:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package changeonly lets managed resources opt out of drift correction, so
+// that only changes to their spec are applied to GitHub.
+package changeonly
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+const errRecordAppliedGeneration = "cannot record applied generation"
+
+// NewConnecter wraps the supplied ExternalConnecter so that the
+// ExternalClients it connects report the external resources of managed
+// resources annotated with ghclient.AnnotationKeyManageOnChangeOnly as up to
+// date, unless their spec changed since it was last applied. The generation
+// of the spec applied by each create or update is recorded in an annotation
+// using the supplied client.
+func NewConnecter(c managed.ExternalConnecter, kube client.Client) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, kube: kube}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	kube client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: e, kube: c.kube}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	kube client.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	if err != nil || !o.ResourceExists || o.ResourceUpToDate || !ghclient.IsManageOnChangeOnly(mg) {
+		return o, err
+	}
+	if g, ok := ghclient.GetAppliedGeneration(mg); ok && g == mg.GetGeneration() {
+		o.ResourceUpToDate = true
+	}
+	return o, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	c, err := e.ExternalClient.Create(ctx, mg)
+	if err != nil {
+		return c, err
+	}
+	return c, e.recordAppliedGeneration(ctx, mg)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	u, err := e.ExternalClient.Update(ctx, mg)
+	if err != nil {
+		return u, err
+	}
+	return u, e.recordAppliedGeneration(ctx, mg)
+}
+
+// recordAppliedGeneration records the current generation of the supplied
+// managed resource as applied, if it only manages changes. The annotation is
+// patched using a copy so that the status of the supplied managed resource,
+// which the managed reconciler persists afterwards, is left untouched.
+func (e *external) recordAppliedGeneration(ctx context.Context, mg resource.Managed) error {
+	if !ghclient.IsManageOnChangeOnly(mg) {
+		return nil
+	}
+	cp, ok := mg.DeepCopyObject().(resource.Managed)
+	if !ok {
+		return nil
+	}
+	from := client.MergeFrom(cp.DeepCopyObject())
+	ghclient.SetAppliedGeneration(cp)
+	if err := e.kube.Patch(ctx, cp, from); err != nil {
+		return errors.Wrap(err, errRecordAppliedGeneration)
+	}
+	ghclient.SetAppliedGeneration(mg)
+	mg.SetResourceVersion(cp.GetResourceVersion())
+	return nil
+}
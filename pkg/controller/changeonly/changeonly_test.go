@@ -0,0 +1,263 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package changeonly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+var errBoom = errors.New("boom")
+
+type managedModifier func(*fake.Managed)
+
+func withAnnotations(a map[string]string) managedModifier {
+	return func(mg *fake.Managed) { mg.SetAnnotations(a) }
+}
+
+func withGeneration(g int64) managedModifier {
+	return func(mg *fake.Managed) { mg.SetGeneration(g) }
+}
+
+func withResourceVersion(v string) managedModifier {
+	return func(mg *fake.Managed) { mg.SetResourceVersion(v) }
+}
+
+func newManaged(m ...managedModifier) *fake.Managed {
+	mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	for _, f := range m {
+		f(mg)
+	}
+	return mg
+}
+
+func connect(t *testing.T, e managed.ExternalClient, kube client.Client, mg resource.Managed) managed.ExternalClient {
+	t.Helper()
+	c := NewConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+		return e, nil
+	}), kube)
+	ec, err := c.Connect(context.Background(), mg)
+	if err != nil {
+		t.Fatalf("Connect(...): %v", err)
+	}
+	return ec
+}
+
+func TestObserve(t *testing.T) {
+	drifted := managed.ExternalClientFns{
+		ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
+		},
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		mg     *fake.Managed
+		want   want
+	}{
+		"NotAnnotated": {
+			reason: "Drift should be reported for resources that are not annotated.",
+			e:      drifted,
+			mg:     newManaged(withGeneration(2)),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"DriftIgnored": {
+			reason: "Drift should be ignored while the applied generation is the current generation.",
+			e:      drifted,
+			mg: newManaged(withGeneration(2), withAnnotations(map[string]string{
+				ghclient.AnnotationKeyManageOnChangeOnly: "true",
+				ghclient.AnnotationKeyAppliedGeneration:  "2",
+			})),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"SpecChanged": {
+			reason: "Drift should be reported once the spec changed since it was last applied.",
+			e:      drifted,
+			mg: newManaged(withGeneration(3), withAnnotations(map[string]string{
+				ghclient.AnnotationKeyManageOnChangeOnly: "true",
+				ghclient.AnnotationKeyAppliedGeneration:  "2",
+			})),
+			want: want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"NeverApplied": {
+			reason: "Drift should be reported if no generation was recorded as applied.",
+			e:      drifted,
+			mg: newManaged(withGeneration(1), withAnnotations(map[string]string{
+				ghclient.AnnotationKeyManageOnChangeOnly: "true",
+			})),
+			want: want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"DoesNotExist": {
+			reason: "Resources that do not exist should still be created.",
+			e: managed.ExternalClientFns{
+				ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+					return managed.ExternalObservation{}, nil
+				},
+			},
+			mg: newManaged(withGeneration(2), withAnnotations(map[string]string{
+				ghclient.AnnotationKeyManageOnChangeOnly: "true",
+				ghclient.AnnotationKeyAppliedGeneration:  "2",
+			})),
+			want: want{o: managed.ExternalObservation{}},
+		},
+		"ObserveError": {
+			reason: "Errors observing the external resource should be returned.",
+			e: managed.ExternalClientFns{
+				ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+					return managed.ExternalObservation{}, errBoom
+				},
+			},
+			mg:   newManaged(withAnnotations(map[string]string{ghclient.AnnotationKeyManageOnChangeOnly: "true"})),
+			want: want{err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := connect(t, tc.e, &test.MockClient{}, tc.mg)
+			o, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateUpdate(t *testing.T) {
+	ok := managed.ExternalClientFns{
+		CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+			return managed.ExternalCreation{}, nil
+		},
+		UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+			return managed.ExternalUpdate{}, nil
+		},
+	}
+	changeOnly := map[string]string{ghclient.AnnotationKeyManageOnChangeOnly: "true"}
+
+	type want struct {
+		mg  *fake.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      managed.ExternalClient
+		kube   client.Client
+		mg     *fake.Managed
+		want   want
+	}{
+		"RecordsGeneration": {
+			reason: "The applied generation should be patched onto the resource, and its new resource version kept.",
+			e:      ok,
+			kube: &test.MockClient{MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+				if got := obj.GetAnnotations()[ghclient.AnnotationKeyAppliedGeneration]; got != "3" {
+					t.Errorf("Patch(...): want applied generation 3, got %q", got)
+				}
+				obj.SetResourceVersion("2")
+				return nil
+			}},
+			mg: newManaged(withGeneration(3), withResourceVersion("1"), withAnnotations(changeOnly)),
+			want: want{
+				mg: newManaged(withGeneration(3), withResourceVersion("2"), withAnnotations(map[string]string{
+					ghclient.AnnotationKeyManageOnChangeOnly: "true",
+					ghclient.AnnotationKeyAppliedGeneration:  "3",
+				})),
+			},
+		},
+		"NotAnnotated": {
+			reason: "Nothing should be recorded for resources that are not annotated.",
+			e:      ok,
+			kube: &test.MockClient{MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+				t.Errorf("Patch(...): unexpected call")
+				return nil
+			}},
+			mg:   newManaged(withGeneration(3)),
+			want: want{mg: newManaged(withGeneration(3))},
+		},
+		"PatchError": {
+			reason: "Errors recording the applied generation should be returned.",
+			e:      ok,
+			kube:   &test.MockClient{MockPatch: test.NewMockPatchFn(errBoom)},
+			mg:     newManaged(withGeneration(3), withAnnotations(changeOnly)),
+			want: want{
+				mg:  newManaged(withGeneration(3), withAnnotations(changeOnly)),
+				err: errors.Wrap(errBoom, errRecordAppliedGeneration),
+			},
+		},
+		"OperationError": {
+			reason: "Nothing should be recorded if the operation failed.",
+			e: managed.ExternalClientFns{
+				CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+					return managed.ExternalCreation{}, errBoom
+				},
+				UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+					return managed.ExternalUpdate{}, errBoom
+				},
+			},
+			kube: &test.MockClient{MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+				t.Errorf("Patch(...): unexpected call")
+				return nil
+			}},
+			mg:   newManaged(withGeneration(3), withAnnotations(changeOnly)),
+			want: want{mg: newManaged(withGeneration(3), withAnnotations(changeOnly)), err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		for _, op := range []string{"Create", "Update"} {
+			t.Run(name+"/"+op, func(t *testing.T) {
+				mg := tc.mg.DeepCopyObject().(*fake.Managed)
+				e := connect(t, tc.e, tc.kube, mg)
+				var err error
+				if op == "Create" {
+					_, err = e.Create(context.Background(), mg)
+				} else {
+					_, err = e.Update(context.Background(), mg)
+				}
+				if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+					t.Errorf("\n%s\n%s(...): -want error, +got error:\n%s\n", tc.reason, op, diff)
+				}
+				if tc.want.err == nil {
+					if diff := cmp.Diff(tc.want.mg, mg); diff != "" {
+						t.Errorf("\n%s\n%s(...): -want, +got:\n%s\n", tc.reason, op, diff)
+					}
+				}
+			})
+		}
+	}
+}
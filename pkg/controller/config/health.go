@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-github/apis/v1beta1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+const (
+	errListProviderConfigs = "cannot list ProviderConfigs"
+	errFmtRateLimits       = "cannot get GitHub rate limits using ProviderConfig %q"
+	errFmtRateLimited      = "GitHub core rate limit of ProviderConfig %q is exhausted until %s"
+)
+
+// healthCheckTimeout bounds how long a single health check may wait for
+// GitHub to respond.
+const healthCheckTimeout = 10 * time.Second
+
+// NewHealthCheck returns a checker that reports whether GitHub can be reached
+// using the credentials of any ProviderConfig. It calls the rate limit API,
+// which does not count against the rate limit, and fails when no
+// ProviderConfig can reach GitHub or when each one has exhausted its core
+// rate limit. It passes when there are no ProviderConfigs. A result is reused
+// for the supplied interval so that frequent probes do not each call GitHub.
+func NewHealthCheck(c client.Client, l logging.Logger, interval time.Duration) healthz.Checker {
+	h := &healthCheck{client: c, newClientFn: ghclient.NewClient, logger: l, interval: interval}
+	return h.Check
+}
+
+type healthCheck struct {
+	client      client.Client
+	newClientFn func(*ghclient.Config) (*github.Client, error)
+	logger      logging.Logger
+	interval    time.Duration
+
+	mu      sync.Mutex
+	checked time.Time
+	err     error
+}
+
+func (h *healthCheck) Check(req *http.Request) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.checked.IsZero() && time.Since(h.checked) < h.interval {
+		return h.err
+	}
+
+	ctx, cancel := context.WithTimeout(ghclient.WithLogger(req.Context(), h.logger), healthCheckTimeout)
+	defer cancel()
+
+	h.err = h.check(ctx)
+	h.checked = time.Now()
+	if h.err != nil {
+		h.logger.Info("GitHub health check failed", "error", h.err)
+	}
+	return h.err
+}
+
+func (h *healthCheck) check(ctx context.Context) error {
+	l := &v1beta1.ProviderConfigList{}
+	if err := h.client.List(ctx, l); err != nil {
+		return errors.Wrap(err, errListProviderConfigs)
+	}
+
+	var err error
+	for i := range l.Items {
+		if err = h.checkProviderConfig(ctx, &l.Items[i]); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (h *healthCheck) checkProviderConfig(ctx context.Context, pc *v1beta1.ProviderConfig) error {
+	cfg, err := ghclient.NewConfig(ctx, h.client, pc)
+	if err != nil {
+		return errors.Wrapf(err, errFmtRateLimits, pc.GetName())
+	}
+	gh, err := h.newClientFn(cfg)
+	if err != nil {
+		return errors.Wrapf(err, errFmtRateLimits, pc.GetName())
+	}
+	rl, _, err := gh.RateLimits(ctx)
+	if err != nil {
+		return errors.Wrapf(err, errFmtRateLimits, pc.GetName())
+	}
+	if core := rl.GetCore(); core != nil && core.Remaining == 0 {
+		return errors.Errorf(errFmtRateLimited, pc.GetName(), core.Reset.Format(time.RFC3339))
+	}
+	return nil
+}
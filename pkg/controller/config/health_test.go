@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/v1beta1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+var errBoom = errors.New("boom")
+
+// withProviderConfigs returns a kube client that lists ProviderConfigs with
+// the supplied names.
+func withProviderConfigs(names ...string) client.Client {
+	return &test.MockClient{MockList: test.NewMockListFn(nil, func(o client.ObjectList) error {
+		l := o.(*v1beta1.ProviderConfigList)
+		for _, n := range names {
+			pc := v1beta1.ProviderConfig{}
+			pc.SetName(n)
+			pc.Spec.Credentials.Source = xpv1.CredentialsSourceNone
+			l.Items = append(l.Items, pc)
+		}
+		return nil
+	})}
+}
+
+// rateLimits returns a handler that serves the rate limit API, reporting the
+// supplied remaining core requests for each ProviderConfig. ProviderConfigs
+// that are not in the map cannot reach GitHub.
+func rateLimits(remaining map[string]int, calls *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		n, ok := remaining[r.Header.Get("X-Provider-Config")]
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, `{"resources": {"core": {"limit": 5000, "remaining": %d, "reset": 1614600000}}}`, n)
+	}
+}
+
+// headerTransport tags each request with the name of the ProviderConfig it
+// was made with, so that the test server can tell them apart.
+type headerTransport struct {
+	name string
+}
+
+func (t headerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("X-Provider-Config", t.name)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestHealthCheck(t *testing.T) {
+	type want struct {
+		err   error
+		calls int
+	}
+
+	cases := map[string]struct {
+		reason    string
+		kube      client.Client
+		remaining map[string]int
+		want      want
+	}{
+		"NoProviderConfigs": {
+			reason: "The check should pass when there is no ProviderConfig to check.",
+			kube:   withProviderConfigs(),
+			want:   want{},
+		},
+		"ListError": {
+			reason: "Errors listing ProviderConfigs should fail the check.",
+			kube:   &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			want:   want{err: errors.Wrap(errBoom, errListProviderConfigs)},
+		},
+		"Reachable": {
+			reason: "The check should pass when a ProviderConfig can reach GitHub.",
+			kube:   withProviderConfigs("default"),
+			remaining: map[string]int{
+				"default": 4999,
+			},
+			want: want{calls: 1},
+		},
+		"RateLimited": {
+			reason: "The check should fail when the only ProviderConfig has exhausted its rate limit.",
+			kube:   withProviderConfigs("default"),
+			remaining: map[string]int{
+				"default": 0,
+			},
+			want: want{err: errors.Errorf(errFmtRateLimited, "default", time.Unix(1614600000, 0).Format(time.RFC3339)), calls: 1},
+		},
+		"OneReachable": {
+			reason: "The check should pass when any ProviderConfig can reach GitHub.",
+			kube:   withProviderConfigs("broken", "default"),
+			remaining: map[string]int{
+				"default": 4999,
+			},
+			want: want{calls: 2},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(rateLimits(tc.remaining, &calls))
+			defer srv.Close()
+			u, _ := url.Parse(srv.URL + "/")
+
+			h := &healthCheck{
+				client: tc.kube,
+				newClientFn: func(cfg *ghclient.Config) (*github.Client, error) {
+					gh := github.NewClient(&http.Client{Transport: headerTransport{name: cfg.ProviderConfigName}})
+					gh.BaseURL = u
+					return gh, nil
+				},
+				logger:   logging.NewNopLogger(),
+				interval: time.Minute,
+			}
+			err := h.Check(httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCheck(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nCheck(...): -want GitHub calls, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHealthCheckInterval(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(rateLimits(map[string]int{"default": 4999}, &calls))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL + "/")
+
+	h := &healthCheck{
+		client: withProviderConfigs("default"),
+		newClientFn: func(cfg *ghclient.Config) (*github.Client, error) {
+			gh := github.NewClient(&http.Client{Transport: headerTransport{name: cfg.ProviderConfigName}})
+			gh.BaseURL = u
+			return gh, nil
+		},
+		logger:   logging.NewNopLogger(),
+		interval: time.Minute,
+	}
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	for i := 0; i < 3; i++ {
+		if err := h.Check(req); err != nil {
+			t.Fatalf("Check(...): %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Check(...): want the result reused within the interval, got %d calls to GitHub", calls)
+	}
+
+	// Once the interval has passed GitHub is asked again.
+	h.checked = h.checked.Add(-2 * time.Minute)
+	if err := h.Check(req); err != nil {
+		t.Fatalf("Check(...): %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Check(...): want GitHub asked again after the interval, got %d calls", calls)
+	}
+}
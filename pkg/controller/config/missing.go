@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/v1beta1"
+)
+
+// MissingRequeueAfter is how long a managed resource that references a
+// ProviderConfig that does not exist waits before it is reconciled again.
+var MissingRequeueAfter = 2 * time.Minute
+
+// WithMissingRequeue wraps the supplied managed resource reconciler so that
+// reconciles failing because the referenced ProviderConfig does not exist are
+// retried after MissingRequeueAfter, rather than with the short backoff used
+// for other errors. The wrapped reconciler still reports the failure in the
+// managed resource's Synced condition.
+func WithMissingRequeue(mgr ctrl.Manager, of resource.ManagedKind, r reconcile.Reconciler) reconcile.Reconciler {
+	nm := func() resource.Managed {
+		return resource.MustCreateObject(schema.GroupVersionKind(of), mgr.GetScheme()).(resource.Managed)
+	}
+	return &missingRequeuer{client: mgr.GetClient(), newManaged: nm, wrapped: r}
+}
+
+type missingRequeuer struct {
+	client     client.Client
+	newManaged func() resource.Managed
+	wrapped    reconcile.Reconciler
+}
+
+func (m *missingRequeuer) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	res, err := m.wrapped.Reconcile(ctx, req)
+	if err != nil || !res.Requeue {
+		return res, err
+	}
+
+	mg := m.newManaged()
+	if err := m.client.Get(ctx, req.NamespacedName, mg); err != nil {
+		return res, nil
+	}
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return res, nil
+	}
+	if err := m.client.Get(ctx, types.NamespacedName{Name: ref.Name}, &v1beta1.ProviderConfig{}); !kerrors.IsNotFound(err) {
+		return res, nil
+	}
+	return reconcile.Result{RequeueAfter: MissingRequeueAfter}, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/v1beta1"
+)
+
+// withProviderConfig returns a kube client that gets a managed resource
+// referencing the named ProviderConfig, which gets the supplied error.
+func withProviderConfig(name string, err error) client.Client {
+	return &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, o client.Object) error {
+		switch o := o.(type) {
+		case *fake.Managed:
+			if name != "" {
+				o.SetProviderConfigReference(&xpv1.Reference{Name: name})
+			}
+			return nil
+		case *v1beta1.ProviderConfig:
+			if key.Name != name {
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			return err
+		}
+		return errBoom
+	}}
+}
+
+func TestMissingRequeuer(t *testing.T) {
+	requeue := reconcile.Result{Requeue: true}
+	notFound := kerrors.NewNotFound(schema.GroupResource{Group: v1beta1.Group, Resource: "providerconfigs"}, "default")
+
+	type want struct {
+		res reconcile.Result
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		res    reconcile.Result
+		err    error
+		want   want
+	}{
+		"Missing": {
+			reason: "A resource that references a missing ProviderConfig should be requeued after the longer wait.",
+			kube:   withProviderConfig("default", notFound),
+			res:    requeue,
+			want:   want{res: reconcile.Result{RequeueAfter: MissingRequeueAfter}},
+		},
+		"Exists": {
+			reason: "A resource that references an existing ProviderConfig should be requeued as the wrapped reconciler asked.",
+			kube:   withProviderConfig("default", nil),
+			res:    requeue,
+			want:   want{res: requeue},
+		},
+		"GetProviderConfigError": {
+			reason: "A resource whose ProviderConfig cannot be read should be requeued as the wrapped reconciler asked.",
+			kube:   withProviderConfig("default", errBoom),
+			res:    requeue,
+			want:   want{res: requeue},
+		},
+		"NoReference": {
+			reason: "A resource without a ProviderConfig reference should be requeued as the wrapped reconciler asked.",
+			kube:   withProviderConfig("", nil),
+			res:    requeue,
+			want:   want{res: requeue},
+		},
+		"ManagedGone": {
+			reason: "A resource that cannot be read should be requeued as the wrapped reconciler asked.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			res:    requeue,
+			want:   want{res: requeue},
+		},
+		"NotRequeued": {
+			reason: "Results that don't ask to be requeued should be passed through unchanged.",
+			res:    reconcile.Result{RequeueAfter: time.Minute},
+			want:   want{res: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"Error": {
+			reason: "Errors should be passed through unchanged.",
+			err:    errBoom,
+			want:   want{err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &missingRequeuer{
+				client:     tc.kube,
+				newManaged: func() resource.Managed { return &fake.Managed{} },
+				wrapped: reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+					return tc.res, tc.err
+				}),
+			}
+			res, err := m.Reconcile(context.Background(), reconcile.Request{})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.res, res); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -22,8 +22,12 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
+	"github.com/crossplane-contrib/provider-github/pkg/controller/actions"
 	"github.com/crossplane-contrib/provider-github/pkg/controller/config"
 	"github.com/crossplane-contrib/provider-github/pkg/controller/organizations"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/repositories"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/secrets"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/teams"
 )
 
 // Setup creates all GitHub controllers with the supplied logger and adds them
@@ -32,6 +36,23 @@ func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
 	for _, setup := range []func(ctrl.Manager, logging.Logger, workqueue.RateLimiter) error{
 		config.Setup,
 		organizations.SetupMembership,
+		organizations.SetupEnterpriseMembership,
+		organizations.SetupOrganizationMembers,
+		organizations.SetupOrgCustomRepoRole,
+		actions.SetupSelfHostedRunner,
+		repositories.SetupRequiredStatusChecks,
+		repositories.SetupContent,
+		repositories.SetupBranchProtection,
+		repositories.SetupWebhook,
+		repositories.SetupLabel,
+		repositories.SetupDeployKey,
+		repositories.SetupCollaborator,
+		repositories.SetupEnvironment,
+		teams.SetupTeam,
+		teams.SetupTeamMembership,
+		secrets.SetupOrgSecret,
+		secrets.SetupEnvironmentSecret,
+		secrets.SetupMultiEnvSecret,
 	} {
 		if err := setup(mgr, l, rl); err != nil {
 			return err
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package instrument records how long managed resource controllers spend
+// talking to GitHub, and whether GitHub rate limits them.
+package instrument
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	reasonSlowOperation event.Reason = "SlowExternalOperation"
+
+	errSlowOperation = "%s of external resource took %s, longer than the %s threshold"
+)
+
+// SlowThreshold is how long a single external operation may take before a
+// warning event is recorded for the managed resource. Zero disables the
+// warning. It must be set before the controllers are set up.
+var SlowThreshold = 30 * time.Second
+
+var operationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "github_external_operation_duration_seconds",
+	Help:    "Duration of Observe, Create, Update and Delete calls against GitHub, by managed resource kind and operation.",
+	Buckets: prometheus.ExponentialBuckets(0.05, 2, 12),
+}, []string{"kind", "operation"})
+
+func init() {
+	metrics.Registry.MustRegister(operationDuration)
+}
+
+// NewConnecter wraps the supplied ExternalConnecter so that every operation
+// of the ExternalClients it connects is timed and recorded for the supplied
+// kind of managed resource.
+func NewConnecter(c managed.ExternalConnecter, kind string, rec event.Recorder) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, kind: kind, recorder: rec, threshold: SlowThreshold}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	kind      string
+	recorder  event.Recorder
+	threshold time.Duration
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: e, kind: c.kind, recorder: c.recorder, threshold: c.threshold}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	kind      string
+	recorder  event.Recorder
+	threshold time.Duration
+}
+
+func (e *external) record(operation string, mg resource.Managed, start time.Time) {
+	d := time.Since(start)
+	operationDuration.WithLabelValues(e.kind, operation).Observe(d.Seconds())
+	if e.threshold > 0 && d > e.threshold {
+		e.recorder.Event(mg, event.Warning(reasonSlowOperation, errors.Errorf(errSlowOperation, operation, d.Round(time.Millisecond), e.threshold)))
+	}
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	defer e.record("observe", mg, time.Now())
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	e.recordRateLimit("observe", mg, err)
+	return o, err
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	defer e.record("create", mg, time.Now())
+	o, err := e.ExternalClient.Create(ctx, mg)
+	e.recordRateLimit("create", mg, err)
+	return o, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	defer e.record("update", mg, time.Now())
+	o, err := e.ExternalClient.Update(ctx, mg)
+	e.recordRateLimit("update", mg, err)
+	return o, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	defer e.record("delete", mg, time.Now())
+	err := e.ExternalClient.Delete(ctx, mg)
+	e.recordRateLimit("delete", mg, err)
+	return err
+}
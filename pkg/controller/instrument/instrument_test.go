@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instrument
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+// recordingRecorder is an event.Recorder that records the reasons of the
+// events it is asked to record.
+type recordingRecorder struct {
+	reasons *[]event.Reason
+}
+
+func (r recordingRecorder) Event(_ runtime.Object, e event.Event) {
+	*r.reasons = append(*r.reasons, e.Reason)
+}
+
+func (r recordingRecorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+// sleepingClient returns an ExternalClient whose operations each take the
+// supplied duration.
+func sleepingClient(d time.Duration) managed.ExternalClient {
+	return managed.ExternalClientFns{
+		ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+			time.Sleep(d)
+			return managed.ExternalObservation{}, nil
+		},
+		CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+			time.Sleep(d)
+			return managed.ExternalCreation{}, nil
+		},
+		UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+			time.Sleep(d)
+			return managed.ExternalUpdate{}, nil
+		},
+		DeleteFn: func(_ context.Context, _ resource.Managed) error {
+			time.Sleep(d)
+			return nil
+		},
+	}
+}
+
+// operations calls every operation of the supplied ExternalClient once.
+func operations(t *testing.T, e managed.ExternalClient) {
+	t.Helper()
+	ctx, mg := context.Background(), &fake.Managed{}
+	if _, err := e.Observe(ctx, mg); err != nil {
+		t.Fatalf("Observe(...): %v", err)
+	}
+	if _, err := e.Create(ctx, mg); err != nil {
+		t.Fatalf("Create(...): %v", err)
+	}
+	if _, err := e.Update(ctx, mg); err != nil {
+		t.Fatalf("Update(...): %v", err)
+	}
+	if err := e.Delete(ctx, mg); err != nil {
+		t.Fatalf("Delete(...): %v", err)
+	}
+}
+
+// observed returns the number and sum of the durations observed for the
+// supplied kind and operation.
+func observed(t *testing.T, kind, operation string) (uint64, float64) {
+	t.Helper()
+	m := &dto.Metric{}
+	if err := operationDuration.WithLabelValues(kind, operation).(prometheus.Histogram).Write(m); err != nil {
+		t.Fatalf("Write(...): %v", err)
+	}
+	return m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum()
+}
+
+func TestExternalRecordsDuration(t *testing.T) {
+	const kind = "InstrumentTestRecordsDuration"
+	const took = 10 * time.Millisecond
+
+	series := testutil.CollectAndCount(operationDuration)
+	e := &external{ExternalClient: sleepingClient(took), kind: kind, recorder: event.NewNopRecorder()}
+	operations(t, e)
+
+	// Every operation of a new kind should add its own series.
+	if diff := cmp.Diff(series+4, testutil.CollectAndCount(operationDuration)); diff != "" {
+		t.Errorf("operationDuration: -want series, +got series:\n%s\n", diff)
+	}
+	for _, op := range []string{"observe", "create", "update", "delete"} {
+		count, sum := observed(t, kind, op)
+		if count != 1 {
+			t.Errorf("%s: want one duration observed, got %d", op, count)
+		}
+		if sum < took.Seconds() {
+			t.Errorf("%s: want a duration of at least %s observed, got %gs", op, took, sum)
+		}
+	}
+}
+
+func TestExternalWarnsWhenSlow(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		took      time.Duration
+		threshold time.Duration
+		want      []event.Reason
+	}{
+		"Slow": {
+			reason:    "Every operation that takes longer than the threshold should record a warning.",
+			took:      20 * time.Millisecond,
+			threshold: 10 * time.Millisecond,
+			want:      []event.Reason{reasonSlowOperation, reasonSlowOperation, reasonSlowOperation, reasonSlowOperation},
+		},
+		"Fast": {
+			reason:    "Operations that finish within the threshold should not record a warning.",
+			threshold: time.Minute,
+		},
+		"Disabled": {
+			reason: "No warnings should be recorded without a threshold.",
+			took:   time.Millisecond,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var reasons []event.Reason
+			e := &external{
+				ExternalClient: sleepingClient(tc.took),
+				kind:           "InstrumentTestWarnsWhenSlow",
+				recorder:       recordingRecorder{reasons: &reasons},
+				threshold:      tc.threshold,
+			}
+			operations(t, e)
+			if diff := cmp.Diff(tc.want, reasons); diff != "" {
+				t.Errorf("\n%s\n-want events, +got events:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instrument
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// TypeRateLimited conditions indicate whether GitHub is rate limiting the
+// requests made to reconcile a managed resource.
+const TypeRateLimited xpv1.ConditionType = "RateLimited"
+
+// Reasons a managed resource is or is not rate limited.
+const (
+	ReasonRateLimited    xpv1.ConditionReason = "RateLimited"
+	ReasonNotRateLimited xpv1.ConditionReason = "NotRateLimited"
+)
+
+const (
+	reasonRateLimited event.Reason = "RateLimited"
+
+	errFmtRateLimited = "GitHub rate limited %s of external resource until %s"
+)
+
+// defaultAbuseRetryAfter is how long to wait after a secondary rate limit
+// error that does not say when to retry.
+const defaultAbuseRetryAfter = time.Minute
+
+// rateLimitedUntil holds the time until which GitHub rate limits the
+// reconciles of each managed resource, keyed by kind and name.
+var rateLimitedUntil sync.Map
+
+func rateLimitKey(kind, name string) string {
+	return kind + "/" + name
+}
+
+// classifyGitHubError returns true and the time at which the rate limit
+// resets if the supplied error was caused by GitHub rate limiting a request.
+// It returns false for any other error.
+func classifyGitHubError(err error, now time.Time) (bool, time.Time) {
+	var (
+		rl  *github.RateLimitError
+		arl *github.AbuseRateLimitError
+	)
+	switch {
+	case errors.As(err, &rl):
+		return true, rl.Rate.Reset.Time
+	case errors.As(err, &arl):
+		if arl.RetryAfter != nil {
+			return true, now.Add(*arl.RetryAfter)
+		}
+		return true, now.Add(defaultAbuseRetryAfter)
+	}
+	return false, time.Time{}
+}
+
+// recordRateLimit reports whether the supplied result of an operation shows
+// that GitHub is rate limiting the supplied managed resource, via an event
+// and its RateLimited condition. Rate limited managed resources are
+// reconciled again once the rate limit resets; see WithRateLimitRequeue.
+func (e *external) recordRateLimit(operation string, mg resource.Managed, err error) {
+	key := rateLimitKey(e.kind, mg.GetName())
+	limited, reset := classifyGitHubError(err, time.Now())
+	if !limited {
+		rateLimitedUntil.Delete(key)
+		if err == nil && mg.GetCondition(TypeRateLimited).Status == corev1.ConditionTrue {
+			mg.SetConditions(xpv1.Condition{
+				Type:               TypeRateLimited,
+				Status:             corev1.ConditionFalse,
+				LastTransitionTime: metav1.Now(),
+				Reason:             ReasonNotRateLimited,
+			})
+		}
+		return
+	}
+
+	rateLimitedUntil.Store(key, reset)
+	msg := errors.Errorf(errFmtRateLimited, operation, reset.Format(time.RFC3339))
+	e.recorder.Event(mg, event.Warning(reasonRateLimited, msg))
+	mg.SetConditions(xpv1.Condition{
+		Type:               TypeRateLimited,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRateLimited,
+		Message:            msg.Error(),
+	})
+}
+
+// WithRateLimitRequeue wraps the supplied managed resource reconciler of the
+// supplied kind of managed resource so that reconciles failing because
+// GitHub rate limited them are retried once the rate limit resets, rather
+// than with the short backoff used for other errors.
+func WithRateLimitRequeue(kind string, r reconcile.Reconciler) reconcile.Reconciler {
+	return &rateLimitRequeuer{kind: kind, wrapped: r}
+}
+
+type rateLimitRequeuer struct {
+	kind    string
+	wrapped reconcile.Reconciler
+}
+
+func (r *rateLimitRequeuer) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	res, err := r.wrapped.Reconcile(ctx, req)
+	if err != nil || !res.Requeue {
+		return res, err
+	}
+	v, ok := rateLimitedUntil.Load(rateLimitKey(r.kind, req.Name))
+	if !ok {
+		return res, nil
+	}
+	if wait := time.Until(v.(time.Time)); wait > 0 {
+		return reconcile.Result{RequeueAfter: wait}, nil
+	}
+	return res, nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instrument
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestClassifyGitHubError(t *testing.T) {
+	now := time.Unix(1614600000, 0)
+	reset := now.Add(30 * time.Minute)
+	retryAfter := 2 * time.Minute
+
+	type want struct {
+		limited bool
+		reset   time.Time
+	}
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"NoError": {
+			reason: "No error is not a rate limit.",
+			want:   want{},
+		},
+		"OtherError": {
+			reason: "An error GitHub did not rate limit is not a rate limit.",
+			err:    errors.New("boom"),
+			want:   want{},
+		},
+		"PrimaryRateLimit": {
+			reason: "A primary rate limit should reset when GitHub says its rate limit resets.",
+			err:    &github.RateLimitError{Rate: github.Rate{Reset: github.Timestamp{Time: reset}}},
+			want:   want{limited: true, reset: reset},
+		},
+		"WrappedPrimaryRateLimit": {
+			reason: "A primary rate limit should be recognised when it is wrapped.",
+			err:    errors.Wrap(&github.RateLimitError{Rate: github.Rate{Reset: github.Timestamp{Time: reset}}}, "cannot get repository"),
+			want:   want{limited: true, reset: reset},
+		},
+		"SecondaryRateLimit": {
+			reason: "A secondary rate limit should reset after the time GitHub asks to wait.",
+			err:    &github.AbuseRateLimitError{RetryAfter: &retryAfter},
+			want:   want{limited: true, reset: now.Add(retryAfter)},
+		},
+		"SecondaryRateLimitWithoutRetryAfter": {
+			reason: "A secondary rate limit that doesn't say when to retry should reset after the default wait.",
+			err:    errors.Wrap(&github.AbuseRateLimitError{}, "cannot create repository"),
+			want:   want{limited: true, reset: now.Add(defaultAbuseRetryAfter)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			limited, reset := classifyGitHubError(tc.err, now)
+			if diff := cmp.Diff(tc.want, want{limited: limited, reset: reset}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nclassifyGitHubError(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWithRateLimitRequeue(t *testing.T) {
+	errBoom := errors.New("boom")
+	const kind = "Repository.repositories.github.crossplane.io"
+
+	type want struct {
+		res reconcile.Result
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		res    reconcile.Result
+		err    error
+		until  time.Duration
+		want   want
+	}{
+		"Error": {
+			reason: "Errors should be returned as is, even when the resource is rate limited.",
+			err:    errBoom,
+			until:  time.Hour,
+			want:   want{err: errBoom},
+		},
+		"NoRequeue": {
+			reason: "Reconciles that don't requeue should not be changed.",
+			res:    reconcile.Result{RequeueAfter: time.Minute},
+			until:  time.Hour,
+			want:   want{res: reconcile.Result{RequeueAfter: time.Minute}},
+		},
+		"NotRateLimited": {
+			reason: "Requeues of a resource that is not rate limited should not be changed.",
+			res:    reconcile.Result{Requeue: true},
+			want:   want{res: reconcile.Result{Requeue: true}},
+		},
+		"RateLimited": {
+			reason: "Requeues of a rate limited resource should wait until the rate limit resets.",
+			res:    reconcile.Result{Requeue: true},
+			until:  time.Hour,
+			want:   want{res: reconcile.Result{RequeueAfter: time.Hour}},
+		},
+		"RateLimitReset": {
+			reason: "Requeues of a resource whose rate limit already reset should not be changed.",
+			res:    reconcile.Result{Requeue: true},
+			until:  -time.Minute,
+			want:   want{res: reconcile.Result{Requeue: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := reconcile.Request{}
+			req.Name = "rate-limit-test-" + name
+			if tc.until != 0 {
+				rateLimitedUntil.Store(rateLimitKey(kind, req.Name), time.Now().Add(tc.until))
+				defer rateLimitedUntil.Delete(rateLimitKey(kind, req.Name))
+			}
+
+			r := WithRateLimitRequeue(kind, reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				return tc.res, tc.err
+			}))
+			res, err := r.Reconcile(context.Background(), req)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			// The requeue delay is measured from when the reconcile ran, so
+			// allow it to be slightly shorter than the time until the reset.
+			if diff := cmp.Diff(tc.want.res, res, cmp.Comparer(func(a, b time.Duration) bool {
+				d := a - b
+				return d >= -time.Second && d <= time.Second
+			})); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizations
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/organizations/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/organizations"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errNotOrgCustomRepoRole = "The managed resource is not an OrgCustomRepoRole resource"
+	errParseRoleID          = "cannot parse the external name as a custom repository role ID"
+	errListCustomRepoRoles  = "cannot list custom repository roles"
+	errCreateCustomRepoRole = "cannot create custom repository role"
+	errUpdateCustomRepoRole = "cannot update custom repository role"
+	errDeleteCustomRepoRole = "cannot delete custom repository role"
+)
+
+// SetupOrgCustomRepoRole adds a controller that reconciles
+// OrgCustomRepoRoles.
+func SetupOrgCustomRepoRole(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.OrgCustomRepoRoleGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.OrgCustomRepoRoleGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.OrgCustomRepoRole{}).
+		Complete(reconciler.New(mgr, of, &orgCustomRepoRoleConnector{client: mgr.GetClient(), newServiceFn: organizations.NewCustomRepoRoleService, logger: logger}, logger, recorder))
+}
+
+type orgCustomRepoRoleConnector struct {
+	client       client.Client
+	newServiceFn func(*ghclient.Config) (organizations.CustomRepoRoleService, error)
+	logger       logging.Logger
+}
+
+func (c *orgCustomRepoRoleConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.OrgCustomRepoRole)
+	if !ok {
+		return nil, errors.New(errNotOrgCustomRepoRole)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &orgCustomRepoRoleExternal{svc, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type orgCustomRepoRoleExternal struct {
+	client organizations.CustomRepoRoleService
+	logger logging.Logger
+}
+
+func (e *orgCustomRepoRoleExternal) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.OrgCustomRepoRole)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOrgCustomRepoRole)
+	}
+
+	// The role's ID is only known once it has been created.
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errParseRoleID)
+	}
+
+	// The API has no endpoint to get a single role, so the role is found
+	// among all the organization's roles.
+	roles, _, err := e.client.ListCustomRepoRoles(ctx, cr.Spec.ForProvider.Organization)
+	if err != nil {
+		return managed.ExternalObservation{}, ghclient.Wrap(err, errListCustomRepoRoles)
+	}
+	r := organizations.FindCustomRepoRole(roles, id)
+	if r == nil {
+		e.logger.Debug("Custom repository role does not exist", "id", id)
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	organizations.LateInitializeCustomRepoRole(&cr.Spec.ForProvider, r)
+	cr.Status.AtProvider = organizations.GenerateCustomRepoRoleObservation(r)
+	cr.SetConditions(xpv1.Available())
+
+	upToDate := organizations.IsCustomRepoRoleUpToDate(cr.Spec.ForProvider, r)
+	if !upToDate {
+		e.logger.Debug("Custom repository role is not up to date", "id", id)
+	}
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+	}, nil
+}
+
+func (e *orgCustomRepoRoleExternal) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.OrgCustomRepoRole)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOrgCustomRepoRole)
+	}
+
+	p := cr.Spec.ForProvider
+	r, _, err := e.client.CreateCustomRepoRole(ctx, p.Organization, organizations.GenerateCustomRepoRoleOptions(p))
+	if err != nil {
+		return managed.ExternalCreation{}, ghclient.Wrap(err, errCreateCustomRepoRole)
+	}
+	meta.SetExternalName(cr, strconv.FormatInt(r.GetID(), 10))
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *orgCustomRepoRoleExternal) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.OrgCustomRepoRole)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOrgCustomRepoRole)
+	}
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errParseRoleID)
+	}
+	p := cr.Spec.ForProvider
+	_, _, err = e.client.UpdateCustomRepoRole(ctx, p.Organization, id, organizations.GenerateCustomRepoRoleOptions(p))
+	return managed.ExternalUpdate{}, ghclient.Wrap(err, errUpdateCustomRepoRole)
+}
+
+func (e *orgCustomRepoRoleExternal) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.OrgCustomRepoRole)
+	if !ok {
+		return errors.New(errNotOrgCustomRepoRole)
+	}
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errParseRoleID)
+	}
+	_, err = e.client.DeleteCustomRepoRole(ctx, cr.Spec.ForProvider.Organization, id)
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	return ghclient.Wrap(err, errDeleteCustomRepoRole)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/organizations/v1alpha1"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/organizations"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/organizations/fake"
+)
+
+var errBoom = errors.New("boom")
+
+func customRepoRole(perms ...string) *v1alpha1.OrgCustomRepoRole {
+	cr := &v1alpha1.OrgCustomRepoRole{}
+	cr.SetName("security-reviewer")
+	cr.Spec.ForProvider = v1alpha1.OrgCustomRepoRoleParameters{
+		Organization: "crossplane",
+		Name:         "security-reviewer",
+		Description:  github.String("Reviews security alerts"),
+		BaseRole:     "read",
+		Permissions:  perms,
+	}
+	return cr
+}
+
+func ghCustomRepoRole(id int64, perms ...string) *organizations.CustomRepoRole {
+	return &organizations.CustomRepoRole{
+		ID:          github.Int64(id),
+		Name:        github.String("security-reviewer"),
+		Description: github.String("Reviews security alerts"),
+		BaseRole:    github.String("read"),
+		Permissions: perms,
+	}
+}
+
+func TestOrgCustomRepoRoleObserve(t *testing.T) {
+	withID := func(cr *v1alpha1.OrgCustomRepoRole, id string) *v1alpha1.OrgCustomRepoRole {
+		meta.SetExternalName(cr, id)
+		return cr
+	}
+	list := func(roles ...*organizations.CustomRepoRole) func(context.Context, string) ([]*organizations.CustomRepoRole, *github.Response, error) {
+		return func(_ context.Context, org string) ([]*organizations.CustomRepoRole, *github.Response, error) {
+			if org != "crossplane" {
+				t.Errorf("ListCustomRepoRoles(...): unexpected organization %q", org)
+			}
+			return roles, nil, nil
+		}
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fake.MockCustomRepoRoleService
+		cr     *v1alpha1.OrgCustomRepoRole
+		want   want
+	}{
+		"NotCreated": {
+			reason: "A role without an ID has not been created yet.",
+			svc:    &fake.MockCustomRepoRoleService{},
+			cr:     customRepoRole("add_label"),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"InvalidID": {
+			reason: "An external name that is not a role ID should be reported.",
+			svc:    &fake.MockCustomRepoRoleService{},
+			cr:     withID(customRepoRole("add_label"), "security-reviewer"),
+			want:   want{err: errors.Wrap(errors.New(`strconv.ParseInt: parsing "security-reviewer": invalid syntax`), errParseRoleID)},
+		},
+		"ListError": {
+			reason: "Errors listing the roles should be returned.",
+			svc: &fake.MockCustomRepoRoleService{MockListCustomRepoRoles: func(_ context.Context, _ string) ([]*organizations.CustomRepoRole, *github.Response, error) {
+				return nil, nil, errBoom
+			}},
+			cr:   withID(customRepoRole("add_label"), "8030"),
+			want: want{err: errors.Wrap(errBoom, errListCustomRepoRoles)},
+		},
+		"Deleted": {
+			reason: "A role that is no longer listed should not exist.",
+			svc:    &fake.MockCustomRepoRoleService{MockListCustomRepoRoles: list(ghCustomRepoRole(1, "add_label"))},
+			cr:     withID(customRepoRole("add_label"), "8030"),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"UpToDate": {
+			reason: "A role with the desired permissions in any order should be up to date.",
+			svc:    &fake.MockCustomRepoRoleService{MockListCustomRepoRoles: list(ghCustomRepoRole(1), ghCustomRepoRole(8030, "remove_label", "add_label"))},
+			cr:     withID(customRepoRole("add_label", "remove_label"), "8030"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"PermissionDrift": {
+			reason: "A role whose permissions were changed outside of the resource should not be up to date.",
+			svc:    &fake.MockCustomRepoRoleService{MockListCustomRepoRoles: list(ghCustomRepoRole(8030, "add_label", "delete_alerts_code_scanning"))},
+			cr:     withID(customRepoRole("add_label"), "8030"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &orgCustomRepoRoleExternal{client: tc.svc, logger: logging.NewNopLogger()}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestOrgCustomRepoRoleCreate(t *testing.T) {
+	cr := customRepoRole("add_label", "remove_label")
+	e := &orgCustomRepoRoleExternal{
+		client: &fake.MockCustomRepoRoleService{MockCreateCustomRepoRole: func(_ context.Context, org string, opts *organizations.CustomRepoRoleOptions) (*organizations.CustomRepoRole, *github.Response, error) {
+			want := &organizations.CustomRepoRoleOptions{
+				Name:        github.String("security-reviewer"),
+				Description: github.String("Reviews security alerts"),
+				BaseRole:    github.String("read"),
+				Permissions: []string{"add_label", "remove_label"},
+			}
+			if diff := cmp.Diff(want, opts); org != "crossplane" || diff != "" {
+				t.Errorf("CreateCustomRepoRole(...): unexpected role in %q: -want, +got:\n%s", org, diff)
+			}
+			return ghCustomRepoRole(8030, opts.Permissions...), nil, nil
+		}},
+		logger: logging.NewNopLogger(),
+	}
+	c, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Create(...): %v", err)
+	}
+	if !c.ExternalNameAssigned || meta.GetExternalName(cr) != "8030" {
+		t.Errorf("Create(...): want external name 8030 assigned, got %q", meta.GetExternalName(cr))
+	}
+}
+
+func TestOrgCustomRepoRoleUpdate(t *testing.T) {
+	cr := customRepoRole("add_label")
+	meta.SetExternalName(cr, "8030")
+	e := &orgCustomRepoRoleExternal{
+		client: &fake.MockCustomRepoRoleService{MockUpdateCustomRepoRole: func(_ context.Context, _ string, id int64, opts *organizations.CustomRepoRoleOptions) (*organizations.CustomRepoRole, *github.Response, error) {
+			if id != 8030 || !cmp.Equal([]string{"add_label"}, opts.Permissions) {
+				t.Errorf("UpdateCustomRepoRole(...): unexpected update of %d: %+v", id, opts)
+			}
+			return ghCustomRepoRole(id, opts.Permissions...), nil, nil
+		}},
+		logger: logging.NewNopLogger(),
+	}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update(...): %v", err)
+	}
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizations
+
+import (
+	"context"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/organizations/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errNotEnterpriseMembership = "The managed resource is not an EnterpriseMembership resource"
+	errGetOrgMembership        = "cannot get membership in organization %s"
+	errEditOrgMembership       = "cannot set membership in organization %s"
+	errRemoveOrgMembership     = "cannot remove membership in organization %s"
+	errGetOrganization         = "cannot get organization %s"
+	errNotEnterprise           = "organization %s is not on the GitHub Enterprise plan, or the credentials cannot read its plan"
+
+	planEnterprise = "enterprise"
+	roleMember     = "member"
+)
+
+// SetupEnterpriseMembership adds a controller that reconciles
+// EnterpriseMemberships.
+func SetupEnterpriseMembership(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.EnterpriseMembershipGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.EnterpriseMembershipGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.EnterpriseMembership{}).
+		Complete(reconciler.New(mgr, of, &enterpriseMembershipConnector{client: mgr.GetClient(), newClientFn: ghclient.NewClient, logger: logger}, logger, recorder))
+}
+
+type enterpriseMembershipConnector struct {
+	client      client.Client
+	newClientFn func(*ghclient.Config) (*github.Client, error)
+	logger      logging.Logger
+}
+
+func (c *enterpriseMembershipConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.EnterpriseMembership)
+	if !ok {
+		return nil, errors.New(errNotEnterpriseMembership)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	gh, err := c.newClientFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &enterpriseMembershipExternal{gh, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type enterpriseMembershipExternal struct {
+	client *github.Client
+	logger logging.Logger
+}
+
+func (e *enterpriseMembershipExternal) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.EnterpriseMembership)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotEnterpriseMembership)
+	}
+
+	p := cr.Spec.ForProvider
+	desired := make(map[string]bool, len(p.Organizations))
+	for _, org := range p.Organizations {
+		desired[org] = true
+	}
+
+	// Organizations we previously observed a membership in but that are no
+	// longer desired are observed too, so that Update can remove them.
+	orgs := append([]string{}, p.Organizations...)
+	for _, m := range cr.Status.AtProvider.Memberships {
+		if !desired[m.Organization] {
+			orgs = append(orgs, m.Organization)
+		}
+	}
+
+	obs := v1alpha1.EnterpriseMembershipObservation{}
+	upToDate, active := true, true
+	for _, org := range orgs {
+		m, _, err := e.client.Organizations.GetOrgMembership(ctx, p.User, org)
+		if ghclient.IsNotFound(err) {
+			if desired[org] {
+				upToDate, active = false, false
+			}
+			continue
+		}
+		if err != nil {
+			return managed.ExternalObservation{}, ghclient.Wrapf(err, errGetOrgMembership, org)
+		}
+		obs.Memberships = append(obs.Memberships, v1alpha1.OrganizationMembershipObservation{
+			Organization: org,
+			State:        m.State,
+			Role:         m.Role,
+		})
+		switch {
+		case !desired[org]:
+			upToDate = false
+		case m.GetState() == statePending:
+			// The role of a pending invitation cannot be edited, and editing
+			// it would invite the user again. It is reconciled once the
+			// invitation is accepted.
+			active = false
+			if m.GetRole() != desiredRole(p) {
+				e.logger.Debug("Not updating role of pending membership", "organization", org, "want", desiredRole(p), "got", m.GetRole())
+			}
+		case m.GetRole() != desiredRole(p):
+			upToDate = false
+		case m.GetState() != stateActive:
+			active = false
+		}
+	}
+	cr.Status.AtProvider = obs
+
+	if len(obs.Memberships) == 0 {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if active {
+		cr.SetConditions(xpv1.Available())
+	} else {
+		cr.SetConditions(xpv1.Creating())
+	}
+
+	e.logger.Debug("Observed enterprise membership", "upToDate", upToDate, "memberships", len(obs.Memberships))
+
+	return managed.ExternalObservation{
+		ResourceUpToDate: upToDate,
+		ResourceExists:   true,
+	}, nil
+}
+
+func (e *enterpriseMembershipExternal) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.EnterpriseMembership)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotEnterpriseMembership)
+	}
+
+	return managed.ExternalCreation{}, e.reconcile(ctx, cr)
+}
+
+func (e *enterpriseMembershipExternal) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.EnterpriseMembership)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotEnterpriseMembership)
+	}
+
+	return managed.ExternalUpdate{}, e.reconcile(ctx, cr)
+}
+
+// reconcile adds the user to, or updates their role in, every desired
+// organization where the observed membership differs and is not pending, and
+// removes them from observed organizations that are no longer desired.
+func (e *enterpriseMembershipExternal) reconcile(ctx context.Context, cr *v1alpha1.EnterpriseMembership) error {
+	p := cr.Spec.ForProvider
+	observed := make(map[string]v1alpha1.OrganizationMembershipObservation, len(cr.Status.AtProvider.Memberships))
+	for _, m := range cr.Status.AtProvider.Memberships {
+		observed[m.Organization] = m
+	}
+
+	desired := make(map[string]bool, len(p.Organizations))
+	for _, org := range p.Organizations {
+		desired[org] = true
+		if m, ok := observed[org]; ok && (m.State != nil && *m.State == statePending || m.Role != nil && *m.Role == desiredRole(p)) {
+			continue
+		}
+		if err := e.requireEnterprise(ctx, org); err != nil {
+			return err
+		}
+		_, _, err := e.client.Organizations.EditOrgMembership(ctx, p.User, org, &github.Membership{Role: github.String(desiredRole(p))})
+		if err != nil {
+			return ghclient.Wrapf(err, errEditOrgMembership, org)
+		}
+	}
+
+	for org := range observed {
+		if desired[org] {
+			continue
+		}
+		if err := e.removeMembership(ctx, p.User, org); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *enterpriseMembershipExternal) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.EnterpriseMembership)
+	if !ok {
+		return errors.New(errNotEnterpriseMembership)
+	}
+
+	for _, m := range cr.Status.AtProvider.Memberships {
+		if err := e.removeMembership(ctx, cr.Spec.ForProvider.User, m.Organization); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *enterpriseMembershipExternal) removeMembership(ctx context.Context, user, org string) error {
+	_, err := e.client.Organizations.RemoveOrgMembership(ctx, user, org)
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	return ghclient.Wrapf(err, errRemoveOrgMembership, org)
+}
+
+// requireEnterprise returns an error unless the supplied organization is on
+// the GitHub Enterprise plan. The plan is only visible to organization owners,
+// so credentials that cannot manage memberships will also fail this check.
+func (e *enterpriseMembershipExternal) requireEnterprise(ctx context.Context, org string) error {
+	o, _, err := e.client.Organizations.Get(ctx, org)
+	if err != nil {
+		return ghclient.Wrapf(err, errGetOrganization, org)
+	}
+	if o.GetPlan().GetName() != planEnterprise {
+		return errors.Errorf(errNotEnterprise, org)
+	}
+	return nil
+}
+
+func desiredRole(p v1alpha1.EnterpriseMembershipParameters) string {
+	if p.Role == nil {
+		return roleMember
+	}
+	return *p.Role
+}
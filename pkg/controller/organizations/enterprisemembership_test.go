@@ -0,0 +1,311 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizations
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/organizations/v1alpha1"
+)
+
+const memberUser = "octocat"
+
+// respond returns a handler that responds with the supplied status and JSON
+// body.
+func respond(status int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+// githubAPI returns a GitHub client that talks to a server whose routes,
+// keyed by request method and path, are served by the supplied handlers, and
+// the requests the server received. Requests to any other route fail with a
+// 404.
+func githubAPI(t *testing.T, routes map[string]http.HandlerFunc) (*github.Client, *[]string) {
+	t.Helper()
+	requests := &[]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+		*requests = append(*requests, key)
+		h, ok := routes[key]
+		if !ok {
+			h = respond(http.StatusNotFound, `{"message": "Not Found"}`)
+		}
+		h(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(srv.URL + "/")
+	return gh, requests
+}
+
+func orgMembership(org string) string {
+	return fmt.Sprintf("/orgs/%s/memberships/%s", org, memberUser)
+}
+
+func membershipIs(state, role string) http.HandlerFunc {
+	return respond(http.StatusOK, fmt.Sprintf(`{"state": %q, "role": %q}`, state, role))
+}
+
+func enterpriseMembership(orgs []string, observed ...v1alpha1.OrganizationMembershipObservation) *v1alpha1.EnterpriseMembership {
+	cr := &v1alpha1.EnterpriseMembership{}
+	cr.SetName(memberUser)
+	cr.Spec.ForProvider = v1alpha1.EnterpriseMembershipParameters{User: memberUser, Organizations: orgs}
+	cr.Status.AtProvider.Memberships = observed
+	return cr
+}
+
+func observedMembership(org, state, role string) v1alpha1.OrganizationMembershipObservation {
+	return v1alpha1.OrganizationMembershipObservation{Organization: org, State: github.String(state), Role: github.String(role)}
+}
+
+func TestEnterpriseMembershipObserve(t *testing.T) {
+	type want struct {
+		o           managed.ExternalObservation
+		memberships []v1alpha1.OrganizationMembershipObservation
+		err         bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		routes map[string]http.HandlerFunc
+		cr     *v1alpha1.EnterpriseMembership
+		want   want
+	}{
+		"NoMemberships": {
+			reason: "A user that is not a member of any of the organizations should not exist.",
+			cr:     enterpriseMembership([]string{"a", "b"}),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"Members": {
+			reason: "A user that is an active member of every organization should be up to date.",
+			routes: map[string]http.HandlerFunc{
+				"GET " + orgMembership("a"): membershipIs("active", "member"),
+				"GET " + orgMembership("b"): membershipIs("active", "member"),
+			},
+			cr: enterpriseMembership([]string{"a", "b"}),
+			want: want{
+				o:           managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				memberships: []v1alpha1.OrganizationMembershipObservation{observedMembership("a", "active", "member"), observedMembership("b", "active", "member")},
+			},
+		},
+		"Pending": {
+			reason: "A user whose invitation is pending should be up to date, though not yet available.",
+			routes: map[string]http.HandlerFunc{
+				"GET " + orgMembership("a"): membershipIs("pending", "member"),
+			},
+			cr: enterpriseMembership([]string{"a"}),
+			want: want{
+				o:           managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				memberships: []v1alpha1.OrganizationMembershipObservation{observedMembership("a", "pending", "member")},
+			},
+		},
+		"PendingWithDifferentRole": {
+			reason: "A user whose invitation is pending with a different role should be up to date, so they are not invited again.",
+			routes: map[string]http.HandlerFunc{
+				"GET " + orgMembership("a"): membershipIs("pending", "admin"),
+			},
+			cr: enterpriseMembership([]string{"a"}),
+			want: want{
+				o:           managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				memberships: []v1alpha1.OrganizationMembershipObservation{observedMembership("a", "pending", "admin")},
+			},
+		},
+		"MissingFromOne": {
+			reason: "A user missing from one of the organizations should be updated.",
+			routes: map[string]http.HandlerFunc{
+				"GET " + orgMembership("a"): membershipIs("active", "member"),
+			},
+			cr: enterpriseMembership([]string{"a", "b"}),
+			want: want{
+				o:           managed.ExternalObservation{ResourceExists: true},
+				memberships: []v1alpha1.OrganizationMembershipObservation{observedMembership("a", "active", "member")},
+			},
+		},
+		"WrongRole": {
+			reason: "A user with a different role than desired should be updated.",
+			routes: map[string]http.HandlerFunc{
+				"GET " + orgMembership("a"): membershipIs("active", "admin"),
+			},
+			cr: enterpriseMembership([]string{"a"}),
+			want: want{
+				o:           managed.ExternalObservation{ResourceExists: true},
+				memberships: []v1alpha1.OrganizationMembershipObservation{observedMembership("a", "active", "admin")},
+			},
+		},
+		"NoLongerDesired": {
+			reason: "A membership that was observed before but is no longer desired should be observed so it can be removed.",
+			routes: map[string]http.HandlerFunc{
+				"GET " + orgMembership("a"): membershipIs("active", "member"),
+				"GET " + orgMembership("b"): membershipIs("active", "member"),
+			},
+			cr: enterpriseMembership([]string{"a"}, observedMembership("b", "active", "member")),
+			want: want{
+				o:           managed.ExternalObservation{ResourceExists: true},
+				memberships: []v1alpha1.OrganizationMembershipObservation{observedMembership("a", "active", "member"), observedMembership("b", "active", "member")},
+			},
+		},
+		"GetError": {
+			reason: "Errors getting a membership should be returned.",
+			routes: map[string]http.HandlerFunc{
+				"GET " + orgMembership("a"): respond(http.StatusInternalServerError, `{}`),
+			},
+			cr:   enterpriseMembership([]string{"a"}),
+			want: want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gh, _ := githubAPI(t, tc.routes)
+			e := &enterpriseMembershipExternal{client: gh, logger: logging.NewNopLogger()}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.err {
+				return
+			}
+			if diff := cmp.Diff(tc.want.memberships, tc.cr.Status.AtProvider.Memberships); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want memberships, +got memberships:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEnterpriseMembershipUpdate(t *testing.T) {
+	enterprise := respond(http.StatusOK, `{"login": "a", "plan": {"name": "enterprise"}}`)
+
+	type want struct {
+		requests []string
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		routes map[string]http.HandlerFunc
+		cr     *v1alpha1.EnterpriseMembership
+		want   want
+	}{
+		"AddMissing": {
+			reason: "A user should be added to enterprise organizations they are not a member of.",
+			routes: map[string]http.HandlerFunc{
+				"GET /orgs/a":               enterprise,
+				"PUT " + orgMembership("a"): membershipIs("pending", "member"),
+			},
+			cr:   enterpriseMembership([]string{"a"}),
+			want: want{requests: []string{"GET /orgs/a", "PUT " + orgMembership("a")}},
+		},
+		"NotEnterprise": {
+			reason: "A user should not be added to organizations that are not on the enterprise plan.",
+			routes: map[string]http.HandlerFunc{
+				"GET /orgs/a": respond(http.StatusOK, `{"login": "a", "plan": {"name": "free"}}`),
+			},
+			cr:   enterpriseMembership([]string{"a"}),
+			want: want{requests: []string{"GET /orgs/a"}, err: errors.Errorf(errNotEnterprise, "a")},
+		},
+		"UpToDate": {
+			reason: "Organizations the user is already a member of with the desired role should be left alone.",
+			cr:     enterpriseMembership([]string{"a"}, observedMembership("a", "active", "member")),
+			want:   want{},
+		},
+		"ChangeRole": {
+			reason: "A user with a different role than desired should have their role changed.",
+			routes: map[string]http.HandlerFunc{
+				"GET /orgs/a":               enterprise,
+				"PUT " + orgMembership("a"): membershipIs("active", "member"),
+			},
+			cr:   enterpriseMembership([]string{"a"}, observedMembership("a", "active", "admin")),
+			want: want{requests: []string{"GET /orgs/a", "PUT " + orgMembership("a")}},
+		},
+		"PendingWithDifferentRole": {
+			reason: "A pending invitation with a different role should not be edited, which would invite the user again.",
+			routes: map[string]http.HandlerFunc{
+				"GET /orgs/b":               enterprise,
+				"PUT " + orgMembership("b"): membershipIs("pending", "member"),
+			},
+			cr:   enterpriseMembership([]string{"a", "b"}, observedMembership("a", "pending", "admin")),
+			want: want{requests: []string{"GET /orgs/b", "PUT " + orgMembership("b")}},
+		},
+		"RemoveUndesired": {
+			reason: "A user should be removed from organizations that are no longer desired.",
+			routes: map[string]http.HandlerFunc{
+				"DELETE " + orgMembership("b"): respond(http.StatusNoContent, ""),
+			},
+			cr:   enterpriseMembership([]string{"a"}, observedMembership("a", "active", "member"), observedMembership("b", "active", "member")),
+			want: want{requests: []string{"DELETE " + orgMembership("b")}},
+		},
+		"AlreadyRemoved": {
+			reason: "A user that was already removed from an organization that is no longer desired should be ignored.",
+			cr:     enterpriseMembership(nil, observedMembership("b", "active", "member")),
+			want:   want{requests: []string{"DELETE " + orgMembership("b")}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gh, requests := githubAPI(t, tc.routes)
+			e := &enterpriseMembershipExternal{client: gh, logger: logging.NewNopLogger()}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, *requests, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want requests, +got requests:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEnterpriseMembershipDelete(t *testing.T) {
+	gh, requests := githubAPI(t, map[string]http.HandlerFunc{
+		"DELETE " + orgMembership("a"): respond(http.StatusNoContent, ""),
+	})
+	e := &enterpriseMembershipExternal{client: gh, logger: logging.NewNopLogger()}
+
+	// The user is removed from every observed organization, including those
+	// they were already removed from.
+	cr := enterpriseMembership([]string{"a"}, observedMembership("a", "active", "member"), observedMembership("b", "active", "member"))
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Errorf("Delete(...): %v", err)
+	}
+	want := []string{"DELETE " + orgMembership("a"), "DELETE " + orgMembership("b")}
+	if diff := cmp.Diff(want, *requests); diff != "" {
+		t.Errorf("Delete(...): -want requests, +got requests:\n%s", diff)
+	}
+}
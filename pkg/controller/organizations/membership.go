@@ -19,15 +19,26 @@ import (
 
 	"github.com/crossplane-contrib/provider-github/apis/organizations/v1alpha1"
 	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
 )
 
 const (
 	errUnexpectedObject = "The managed resource is not a Membership resource"
+	errCreateInvitation = "cannot create organization invitation"
+	errRemoveMember     = "cannot remove organization member"
+	errEditMembership   = "cannot edit organization membership"
+
+	stateActive      = "active"
+	statePending     = "pending"
+	roleDirectMember = "direct_member"
 )
 
 // SetupMembership adds a controller that reconciles Memberships.
 func SetupMembership(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
 	name := managed.ControllerName(v1alpha1.MembershipGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.MembershipGroupVersionKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -35,19 +46,14 @@ func SetupMembership(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimite
 			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
 		}).
 		For(&v1alpha1.Membership{}).
-		Complete(managed.NewReconciler(mgr,
-			resource.ManagedKind(v1alpha1.MembershipGroupVersionKind),
-			managed.WithExternalConnecter(&connector{client: mgr.GetClient(), newClientFn: ghclient.NewClient}),
-			managed.WithConnectionPublishers(),
-			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
-			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
-			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+		Complete(reconciler.New(mgr, of, &connector{client: mgr.GetClient(), newClientFn: ghclient.NewClient, logger: logger}, logger, recorder,
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient()))))
 }
 
 type connector struct {
 	client      client.Client
-	newClientFn func(string) *github.Client
+	newClientFn func(*ghclient.Config) (*github.Client, error)
+	logger      logging.Logger
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -59,12 +65,17 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if err != nil {
 		return nil, err
 	}
-	return &external{c.newClientFn(string(cfg)), c.client}, nil
+	gh, err := c.newClientFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{gh, c.client, c.logger.WithValues("request", cr.GetName())}, nil
 }
 
 type external struct {
 	client *github.Client
 	kube   client.Client
+	logger logging.Logger
 }
 
 func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
@@ -76,19 +87,37 @@ func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.E
 	// TODO(hasheddan): handle errors correctly
 	m, _, err := e.client.Organizations.GetOrgMembership(ctx, cr.Spec.ForProvider.User, cr.Spec.ForProvider.Organization)
 	if err != nil { // nolint:nilerr
+		e.logger.Debug("Membership not found", "user", cr.Spec.ForProvider.User, "organization", cr.Spec.ForProvider.Organization, "error", err.Error())
 		return managed.ExternalObservation{
 			ResourceExists: false,
 		}, nil
 	}
 
-	if m.State != nil && *m.State == "active" {
+	cr.Status.AtProvider.URL = m.URL
+	cr.Status.AtProvider.State = m.State
+	cr.Status.AtProvider.Role = m.Role
+
+	if m.GetState() == stateActive {
 		cr.SetConditions(xpv1.Available())
 	} else {
 		cr.SetConditions(xpv1.Creating())
 	}
 
+	upToDate := true
+	switch want := desiredMembershipRole(cr.Spec.ForProvider); {
+	case want == "" || want == m.GetRole():
+	case m.GetState() == statePending:
+		// The role of a pending invitation cannot be edited. It is
+		// reconciled once the invitation is accepted.
+		e.logger.Debug("Not updating role of pending membership", "want", want, "got", m.GetRole())
+	default:
+		upToDate = false
+	}
+
+	e.logger.Debug("Observed membership", "state", m.GetState(), "role", m.GetRole(), "upToDate", upToDate)
+
 	return managed.ExternalObservation{
-		ResourceUpToDate: true,
+		ResourceUpToDate: upToDate,
 		ResourceExists:   true,
 	}, nil
 }
@@ -107,19 +136,23 @@ func (e *external) Create(ctx context.Context, mgd resource.Managed) (managed.Ex
 	}
 	_, _, err := e.client.Organizations.CreateOrgInvitation(ctx, cr.Spec.ForProvider.Organization, inv)
 	if err != nil {
-		return managed.ExternalCreation{}, err
+		return managed.ExternalCreation{}, ghclient.Wrap(err, errCreateInvitation)
 	}
 	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
 
 }
 
-func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) { // nolint:gocyclo
-	_, ok := mgd.(*v1alpha1.Membership)
+func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.Membership)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errUnexpectedObject)
 	}
 
-	return managed.ExternalUpdate{}, nil
+	p := cr.Spec.ForProvider
+	want := desiredMembershipRole(p)
+	e.logger.Debug("Updating membership role", "from", cr.Status.AtProvider.Role, "to", want)
+	_, _, err := e.client.Organizations.EditOrgMembership(ctx, p.User, p.Organization, &github.Membership{Role: &want})
+	return managed.ExternalUpdate{}, ghclient.Wrap(err, errEditMembership)
 }
 
 func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
@@ -130,5 +163,19 @@ func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
 
 	_, err := e.client.Organizations.RemoveMember(ctx, cr.Spec.ForProvider.Organization, cr.Spec.ForProvider.User)
 
-	return err
+	return ghclient.Wrap(err, errRemoveMember)
+}
+
+// desiredMembershipRole returns the membership role corresponding to the
+// invitation role of the supplied parameters, or an empty string if no role
+// is specified.
+func desiredMembershipRole(p v1alpha1.MembershipParameters) string {
+	switch r := p.Role; {
+	case r == nil:
+		return ""
+	case *r == roleDirectMember:
+		return roleMember
+	default:
+		return *r
+	}
 }
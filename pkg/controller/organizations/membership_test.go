@@ -0,0 +1,257 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-github/apis/organizations/v1alpha1"
+)
+
+func orgMember(role *string) *v1alpha1.Membership {
+	cr := &v1alpha1.Membership{}
+	cr.SetName(memberUser)
+	cr.Spec.ForProvider = v1alpha1.MembershipParameters{Organization: "a", User: memberUser, Role: role}
+	return cr
+}
+
+func TestDesiredMembershipRole(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		role   *string
+		want   string
+	}{
+		"Unspecified": {
+			reason: "No role should be desired when none is specified.",
+			want:   "",
+		},
+		"DirectMember": {
+			reason: "The direct_member invitation role should map to the member membership role.",
+			role:   github.String(roleDirectMember),
+			want:   roleMember,
+		},
+		"Admin": {
+			reason: "The admin invitation role should map to the admin membership role.",
+			role:   github.String("admin"),
+			want:   "admin",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := desiredMembershipRole(v1alpha1.MembershipParameters{Role: tc.role})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ndesiredMembershipRole(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMembershipObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		obs v1alpha1.MembershipObservation
+	}
+
+	cases := map[string]struct {
+		reason     string
+		membership http.HandlerFunc
+		cr         *v1alpha1.Membership
+		want       want
+	}{
+		"NotFound": {
+			reason: "A user that is not a member should not exist.",
+			cr:     orgMember(nil),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"NoRole": {
+			reason:     "A member should be up to date when no role is specified.",
+			membership: membershipIs("active", "admin"),
+			cr:         orgMember(nil),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.MembershipObservation{State: github.String("active"), Role: github.String("admin")},
+			},
+		},
+		"DirectMember": {
+			reason:     "A member should be up to date when invited as a direct member.",
+			membership: membershipIs("active", "member"),
+			cr:         orgMember(github.String(roleDirectMember)),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.MembershipObservation{State: github.String("active"), Role: github.String("member")},
+			},
+		},
+		"RoleChanged": {
+			reason:     "An active member with a different role than desired should be updated.",
+			membership: membershipIs("active", "member"),
+			cr:         orgMember(github.String("admin")),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true},
+				obs: v1alpha1.MembershipObservation{State: github.String("active"), Role: github.String("member")},
+			},
+		},
+		"PendingRoleChanged": {
+			reason:     "The role of a pending invitation can't be edited, so it should be up to date until accepted.",
+			membership: membershipIs("pending", "member"),
+			cr:         orgMember(github.String("admin")),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.MembershipObservation{State: github.String("pending"), Role: github.String("member")},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			routes := map[string]http.HandlerFunc{}
+			if tc.membership != nil {
+				routes["GET "+orgMembership("a")] = tc.membership
+			}
+			gh, _ := githubAPI(t, routes)
+			e := &external{client: gh, logger: logging.NewNopLogger()}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("\n%s\nObserve(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, tc.cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// A recordingLogger records the messages and key/value pairs it is asked to
+// log.
+type recordingLogger struct {
+	entries *[]logEntry
+	kv      []interface{}
+}
+
+type logEntry struct {
+	msg string
+	kv  []interface{}
+}
+
+func (l recordingLogger) Info(msg string, kv ...interface{}) {
+	*l.entries = append(*l.entries, logEntry{msg: msg, kv: append(append([]interface{}{}, l.kv...), kv...)})
+}
+
+func (l recordingLogger) Debug(msg string, kv ...interface{}) { l.Info(msg, kv...) }
+
+func (l recordingLogger) WithValues(kv ...interface{}) logging.Logger {
+	return recordingLogger{entries: l.entries, kv: append(append([]interface{}{}, l.kv...), kv...)}
+}
+
+func TestMembershipObserveLogging(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		membership http.HandlerFunc
+		cr         *v1alpha1.Membership
+		want       []logEntry
+	}{
+		"UpToDate": {
+			reason:     "The observed state and role should be logged.",
+			membership: membershipIs("active", "admin"),
+			cr:         orgMember(github.String("admin")),
+			want: []logEntry{
+				{msg: "Observed membership", kv: []interface{}{"state", "active", "role", "admin", "upToDate", true}},
+			},
+		},
+		"RoleChanged": {
+			reason:     "A role that has drifted should be logged as not up to date.",
+			membership: membershipIs("active", "member"),
+			cr:         orgMember(github.String("admin")),
+			want: []logEntry{
+				{msg: "Observed membership", kv: []interface{}{"state", "active", "role", "member", "upToDate", false}},
+			},
+		},
+		"PendingRoleChanged": {
+			reason:     "The decision not to update the role of a pending invitation should be logged with the desired and observed roles.",
+			membership: membershipIs("pending", "member"),
+			cr:         orgMember(github.String("admin")),
+			want: []logEntry{
+				{msg: "Not updating role of pending membership", kv: []interface{}{"want", "admin", "got", "member"}},
+				{msg: "Observed membership", kv: []interface{}{"state", "pending", "role", "member", "upToDate", true}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gh, _ := githubAPI(t, map[string]http.HandlerFunc{"GET " + orgMembership("a"): tc.membership})
+			var got []logEntry
+			e := &external{client: gh, logger: recordingLogger{entries: &got}}
+			if _, err := e.Observe(context.Background(), tc.cr); err != nil {
+				t.Fatalf("\n%s\nObserve(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(logEntry{})); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want log, +got log:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMembershipUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		role   string
+		want   string
+	}{
+		"Admin": {
+			reason: "A member should be made an admin.",
+			role:   "admin",
+			want:   "admin",
+		},
+		"DirectMember": {
+			reason: "A member invited as a direct member should be given the member role.",
+			role:   roleDirectMember,
+			want:   roleMember,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got github.Membership
+			gh, _ := githubAPI(t, map[string]http.HandlerFunc{
+				"PUT " + orgMembership("a"): func(w http.ResponseWriter, r *http.Request) {
+					_ = json.NewDecoder(r.Body).Decode(&got)
+					membershipIs("active", got.GetRole())(w, r)
+				},
+			})
+			e := &external{client: gh, logger: logging.NewNopLogger()}
+			if _, err := e.Update(context.Background(), orgMember(github.String(tc.role))); err != nil {
+				t.Fatalf("\n%s\nUpdate(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got.GetRole()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want role, +got role:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
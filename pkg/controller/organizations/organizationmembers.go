@@ -0,0 +1,271 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizations
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/organizations/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errNotOrganizationMembers = "The managed resource is not an OrganizationMembers resource"
+	errListMembers            = "cannot list organization members"
+	errListInvitations        = "cannot list pending organization invitations"
+	errInviteMember           = "cannot invite %s to the organization"
+	errRemoveUnlisted         = "cannot remove %s from the organization"
+	errTooManyRemovals        = "refusing to remove %d unlisted members, more than the maximum of %d; raise maxRemovals if this is intended"
+
+	roleAll   = "all"
+	roleAdmin = "admin"
+
+	defaultMaxRemovals = 10
+)
+
+// SetupOrganizationMembers adds a controller that reconciles
+// OrganizationMembers.
+func SetupOrganizationMembers(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.OrganizationMembersGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.OrganizationMembersGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.OrganizationMembers{}).
+		Complete(reconciler.New(mgr, of, &organizationMembersConnector{client: mgr.GetClient(), newClientFn: ghclient.NewClient, logger: logger}, logger, recorder))
+}
+
+type organizationMembersConnector struct {
+	client      client.Client
+	newClientFn func(*ghclient.Config) (*github.Client, error)
+	logger      logging.Logger
+}
+
+func (c *organizationMembersConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.OrganizationMembers)
+	if !ok {
+		return nil, errors.New(errNotOrganizationMembers)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	gh, err := c.newClientFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &organizationMembersExternal{gh, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type organizationMembersExternal struct {
+	client *github.Client
+	logger logging.Logger
+}
+
+func (e *organizationMembersExternal) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.OrganizationMembers)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOrganizationMembers)
+	}
+
+	d, err := e.diff(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	cr.Status.AtProvider = d.observation
+	cr.SetConditions(xpv1.Available())
+
+	p := cr.Spec.ForProvider
+	removeUnlisted := p.RemoveUnlisted != nil && *p.RemoveUnlisted
+	if len(d.removable) > 0 && !removeUnlisted {
+		e.logger.Debug("Reporting unlisted members without removing them", "unlisted", d.observation.Unlisted)
+	}
+
+	// The organization always exists, so there is never anything to create;
+	// missing and removable members are reconciled by Update.
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(d.observation.Missing) == 0 && (!removeUnlisted || len(d.removable) == 0),
+	}, nil
+}
+
+func (e *organizationMembersExternal) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, nil
+}
+
+// Update invites missing users and, when RemoveUnlisted is set, removes
+// unlisted members.
+func (e *organizationMembersExternal) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.OrganizationMembers)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOrganizationMembers)
+	}
+
+	p := cr.Spec.ForProvider
+	d, err := e.diff(ctx, p)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	for _, user := range d.observation.Missing {
+		e.logger.Debug("Inviting user to organization", "user", user)
+		if _, _, err := e.client.Organizations.EditOrgMembership(ctx, user, p.Organization, &github.Membership{Role: github.String(roleMember)}); err != nil {
+			return managed.ExternalUpdate{}, ghclient.Wrapf(err, errInviteMember, user)
+		}
+	}
+
+	if p.RemoveUnlisted == nil || !*p.RemoveUnlisted {
+		return managed.ExternalUpdate{}, nil
+	}
+	max := defaultMaxRemovals
+	if p.MaxRemovals != nil {
+		max = *p.MaxRemovals
+	}
+	if len(d.removable) > max {
+		return managed.ExternalUpdate{}, errors.Errorf(errTooManyRemovals, len(d.removable), max)
+	}
+	for _, user := range d.removable {
+		e.logger.Debug("Removing unlisted member from organization", "user", user)
+		if _, err := e.client.Organizations.RemoveOrgMembership(ctx, user, p.Organization); err != nil && !ghclient.IsNotFound(err) {
+			return managed.ExternalUpdate{}, ghclient.Wrapf(err, errRemoveUnlisted, user)
+		}
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete leaves the organization's members untouched; only the managed
+// resource is removed.
+func (e *organizationMembersExternal) Delete(ctx context.Context, mgd resource.Managed) error {
+	return nil
+}
+
+// A membersDiff is the difference between the listed and actual members of
+// an organization.
+type membersDiff struct {
+	observation v1alpha1.OrganizationMembersObservation
+
+	// removable are the unlisted members that may be removed, i.e. excluding
+	// owners unless AllowOwnerRemoval is set.
+	removable []string
+}
+
+func (e *organizationMembersExternal) diff(ctx context.Context, p v1alpha1.OrganizationMembersParameters) (membersDiff, error) {
+	members, err := e.listMembers(ctx, p.Organization, roleAll)
+	if err != nil {
+		return membersDiff{}, ghclient.Wrap(err, errListMembers)
+	}
+	owners, err := e.listMembers(ctx, p.Organization, roleAdmin)
+	if err != nil {
+		return membersDiff{}, ghclient.Wrap(err, errListMembers)
+	}
+	pending, err := e.listPending(ctx, p.Organization)
+	if err != nil {
+		return membersDiff{}, ghclient.Wrap(err, errListInvitations)
+	}
+
+	// GitHub usernames are case insensitive.
+	listed := loginSet(p.Members)
+	isMember := loginSet(members)
+	isPending := loginSet(pending)
+	isOwner := loginSet(owners)
+	allowOwnerRemoval := p.AllowOwnerRemoval != nil && *p.AllowOwnerRemoval
+
+	d := membersDiff{observation: v1alpha1.OrganizationMembersObservation{Members: members, Pending: pending}}
+	for _, user := range p.Members {
+		if !isMember[strings.ToLower(user)] && !isPending[strings.ToLower(user)] {
+			d.observation.Missing = append(d.observation.Missing, user)
+		}
+	}
+	for _, user := range members {
+		if listed[strings.ToLower(user)] {
+			continue
+		}
+		d.observation.Unlisted = append(d.observation.Unlisted, user)
+		if allowOwnerRemoval || !isOwner[strings.ToLower(user)] {
+			d.removable = append(d.removable, user)
+		}
+	}
+	return d, nil
+}
+
+func (e *organizationMembersExternal) listMembers(ctx context.Context, org, role string) ([]string, error) {
+	var logins []string
+	// ListAll pages through the results by updating the embedded
+	// ListOptions.
+	opts := &github.ListMembersOptions{Role: role}
+	err := ghclient.ListAll(&opts.ListOptions, func(*github.ListOptions) (*github.Response, error) {
+		users, res, err := e.client.Organizations.ListMembers(ctx, org, opts)
+		for _, u := range users {
+			logins = append(logins, u.GetLogin())
+		}
+		return res, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(logins)
+	return logins, nil
+}
+
+func (e *organizationMembersExternal) listPending(ctx context.Context, org string) ([]string, error) {
+	var logins []string
+	err := ghclient.ListAll(&github.ListOptions{}, func(opts *github.ListOptions) (*github.Response, error) {
+		invs, res, err := e.client.Organizations.ListPendingOrgInvitations(ctx, org, opts)
+		for _, i := range invs {
+			// Invitations sent by email have no login until accepted.
+			if i.GetLogin() != "" {
+				logins = append(logins, i.GetLogin())
+			}
+		}
+		return res, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(logins)
+	return logins, nil
+}
+
+func loginSet(logins []string) map[string]bool {
+	s := make(map[string]bool, len(logins))
+	for _, l := range logins {
+		s[strings.ToLower(l)] = true
+	}
+	return s
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package organizations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/organizations/v1alpha1"
+)
+
+const membersOrg = "crossplane"
+
+// logins returns a handler that responds with users or invitations with the
+// supplied logins.
+func logins(l ...string) http.HandlerFunc {
+	users := make([]map[string]string, len(l))
+	for i := range l {
+		users[i] = map[string]string{"login": l[i]}
+	}
+	body, _ := json.Marshal(users)
+	return respond(http.StatusOK, string(body))
+}
+
+// organization returns the routes of an organization with the supplied
+// members, of whom the supplied owners are owners, and pending invitations.
+func organization(members, owners, pending []string) map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		"GET /orgs/" + membersOrg + "/members": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("role") == roleAdmin {
+				logins(owners...)(w, r)
+				return
+			}
+			logins(members...)(w, r)
+		},
+		"GET /orgs/" + membersOrg + "/invitations": logins(pending...),
+	}
+}
+
+func with(routes map[string]http.HandlerFunc, key string, h http.HandlerFunc) map[string]http.HandlerFunc {
+	routes[key] = h
+	return routes
+}
+
+func organizationMembers(members []string, o ...func(*v1alpha1.OrganizationMembersParameters)) *v1alpha1.OrganizationMembers {
+	cr := &v1alpha1.OrganizationMembers{}
+	cr.SetName(membersOrg)
+	cr.Spec.ForProvider = v1alpha1.OrganizationMembersParameters{Organization: membersOrg, Members: members}
+	for _, fn := range o {
+		fn(&cr.Spec.ForProvider)
+	}
+	return cr
+}
+
+func removeUnlisted(p *v1alpha1.OrganizationMembersParameters) {
+	p.RemoveUnlisted = github.Bool(true)
+}
+
+func allowOwnerRemoval(p *v1alpha1.OrganizationMembersParameters) {
+	p.AllowOwnerRemoval = github.Bool(true)
+}
+
+func maxRemovals(n int) func(*v1alpha1.OrganizationMembersParameters) {
+	return func(p *v1alpha1.OrganizationMembersParameters) {
+		p.MaxRemovals = &n
+	}
+}
+
+func membership(user string) string {
+	return fmt.Sprintf("/orgs/%s/memberships/%s", membersOrg, user)
+}
+
+func TestOrganizationMembersObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		obs v1alpha1.OrganizationMembersObservation
+		err bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		routes map[string]http.HandlerFunc
+		cr     *v1alpha1.OrganizationMembers
+		want   want
+	}{
+		"InSync": {
+			reason: "An organization whose members are exactly those listed should be up to date.",
+			routes: organization([]string{"alice", "bob"}, nil, nil),
+			cr:     organizationMembers([]string{"bob", "alice"}),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.OrganizationMembersObservation{Members: []string{"alice", "bob"}},
+			},
+		},
+		"CaseInsensitive": {
+			reason: "Listed users should match members regardless of case.",
+			routes: organization([]string{"Alice"}, nil, nil),
+			cr:     organizationMembers([]string{"alice"}, removeUnlisted),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.OrganizationMembersObservation{Members: []string{"Alice"}},
+			},
+		},
+		"Missing": {
+			reason: "An organization missing a listed user should be updated.",
+			routes: organization([]string{"alice"}, nil, nil),
+			cr:     organizationMembers([]string{"alice", "bob"}),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true},
+				obs: v1alpha1.OrganizationMembersObservation{Members: []string{"alice"}, Missing: []string{"bob"}},
+			},
+		},
+		"Pending": {
+			reason: "A listed user with a pending invitation should not be invited again.",
+			routes: organization([]string{"alice"}, nil, []string{"bob"}),
+			cr:     organizationMembers([]string{"alice", "bob"}),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.OrganizationMembersObservation{Members: []string{"alice"}, Pending: []string{"bob"}},
+			},
+		},
+		"UnlistedReported": {
+			reason: "Unlisted members should be reported, but not make the organization out of date unless they are to be removed.",
+			routes: organization([]string{"alice", "mallory"}, nil, nil),
+			cr:     organizationMembers([]string{"alice"}),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.OrganizationMembersObservation{Members: []string{"alice", "mallory"}, Unlisted: []string{"mallory"}},
+			},
+		},
+		"UnlistedRemoved": {
+			reason: "An organization with unlisted members should be updated when they are to be removed.",
+			routes: organization([]string{"alice", "mallory"}, nil, nil),
+			cr:     organizationMembers([]string{"alice"}, removeUnlisted),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true},
+				obs: v1alpha1.OrganizationMembersObservation{Members: []string{"alice", "mallory"}, Unlisted: []string{"mallory"}},
+			},
+		},
+		"UnlistedOwner": {
+			reason: "Unlisted owners should not be removed unless owner removal is allowed.",
+			routes: organization([]string{"alice", "owner"}, []string{"owner"}, nil),
+			cr:     organizationMembers([]string{"alice"}, removeUnlisted),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.OrganizationMembersObservation{Members: []string{"alice", "owner"}, Unlisted: []string{"owner"}},
+			},
+		},
+		"UnlistedOwnerRemoved": {
+			reason: "Unlisted owners should be removed when owner removal is allowed.",
+			routes: organization([]string{"alice", "owner"}, []string{"owner"}, nil),
+			cr:     organizationMembers([]string{"alice"}, removeUnlisted, allowOwnerRemoval),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true},
+				obs: v1alpha1.OrganizationMembersObservation{Members: []string{"alice", "owner"}, Unlisted: []string{"owner"}},
+			},
+		},
+		"ListError": {
+			reason: "Errors listing members should be returned.",
+			routes: with(organization(nil, nil, nil), "GET /orgs/"+membersOrg+"/members", respond(http.StatusInternalServerError, `{}`)),
+			cr:     organizationMembers([]string{"alice"}),
+			want:   want{err: true},
+		},
+		"ListInvitationsError": {
+			reason: "Errors listing pending invitations should be returned.",
+			routes: with(organization(nil, nil, nil), "GET /orgs/"+membersOrg+"/invitations", respond(http.StatusInternalServerError, `{}`)),
+			cr:     organizationMembers([]string{"alice"}),
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gh, _ := githubAPI(t, tc.routes)
+			e := &organizationMembersExternal{client: gh, logger: logging.NewNopLogger()}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, tc.cr.Status.AtProvider, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestOrganizationMembersUpdate(t *testing.T) {
+	type want struct {
+		changes []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		routes map[string]http.HandlerFunc
+		cr     *v1alpha1.OrganizationMembers
+		want   want
+	}{
+		"InviteMissing": {
+			reason: "Missing users should be invited.",
+			routes: organization([]string{"alice"}, nil, nil),
+			cr:     organizationMembers([]string{"alice", "bob"}),
+			want:   want{changes: []string{"PUT " + membership("bob")}},
+		},
+		"UnlistedKept": {
+			reason: "Unlisted members should not be removed unless RemoveUnlisted is set.",
+			routes: organization([]string{"alice", "mallory"}, nil, nil),
+			cr:     organizationMembers([]string{"alice"}),
+			want:   want{},
+		},
+		"RemoveUnlisted": {
+			reason: "Unlisted members should be removed when RemoveUnlisted is set, but owners kept.",
+			routes: organization([]string{"alice", "mallory", "owner"}, []string{"owner"}, nil),
+			cr:     organizationMembers([]string{"alice"}, removeUnlisted),
+			want:   want{changes: []string{"DELETE " + membership("mallory")}},
+		},
+		"RemoveUnlistedOwners": {
+			reason: "Unlisted owners should be removed when owner removal is allowed.",
+			routes: organization([]string{"alice", "owner"}, []string{"owner"}, nil),
+			cr:     organizationMembers([]string{"alice"}, removeUnlisted, allowOwnerRemoval),
+			want:   want{changes: []string{"DELETE " + membership("owner")}},
+		},
+		"AlreadyLeft": {
+			reason: "Unlisted members that already left the organization should be ignored.",
+			routes: organization([]string{"alice", "eve"}, nil, nil),
+			cr:     organizationMembers([]string{"alice"}, removeUnlisted),
+			want:   want{changes: []string{"DELETE " + membership("eve")}},
+		},
+		"TooManyRemovals": {
+			reason: "No member should be removed when more than MaxRemovals would be.",
+			routes: organization([]string{"alice", "eve", "mallory"}, nil, nil),
+			cr:     organizationMembers([]string{"alice"}, removeUnlisted, maxRemovals(1)),
+			want:   want{err: errors.Errorf(errTooManyRemovals, 2, 1)},
+		},
+		"TooManyRemovalsByDefault": {
+			reason: "No member should be removed when more than the default maximum would be.",
+			routes: organization([]string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}, nil, nil),
+			cr:     organizationMembers(nil, removeUnlisted),
+			want:   want{err: errors.Errorf(errTooManyRemovals, 11, defaultMaxRemovals)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			routes := tc.routes
+			for _, user := range []string{"bob", "mallory", "owner"} {
+				routes["PUT "+membership(user)] = respond(http.StatusOK, `{"state": "pending", "role": "member"}`)
+			}
+			for _, user := range []string{"mallory", "owner"} {
+				routes["DELETE "+membership(user)] = respond(http.StatusNoContent, "")
+			}
+
+			gh, requests := githubAPI(t, routes)
+			e := &organizationMembersExternal{client: gh, logger: logging.NewNopLogger()}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+
+			var changes []string
+			for _, r := range *requests {
+				if r[:3] != "GET" {
+					changes = append(changes, r)
+				}
+			}
+			if diff := cmp.Diff(tc.want.changes, changes, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want changes, +got changes:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package paused stops managed resources whose reconciliation is paused from
+// being reconciled with GitHub.
+package paused
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+// NewConnecter wraps the supplied ExternalConnecter so that managed resources
+// annotated with ghclient.AnnotationKeyPaused are neither connected nor
+// reconciled with GitHub. Their external resources are reported as existing
+// and up to date, and deleting them fails with ghclient.ErrPaused until the
+// annotation is removed.
+func NewConnecter(c managed.ExternalConnecter, l logging.Logger) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, logger: l}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	logger logging.Logger
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if ghclient.IsPaused(mg) {
+		return &external{logger: c.logger.WithValues("request", mg.GetName())}, nil
+	}
+	return c.ExternalConnecter.Connect(ctx, mg)
+}
+
+// An external client of a paused managed resource. It never calls GitHub.
+type external struct {
+	logger logging.Logger
+}
+
+func (e *external) Observe(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+	e.logger.Debug("Reconciliation is paused")
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (e *external) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, ghclient.ErrPaused
+}
+
+func (e *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, ghclient.ErrPaused
+}
+
+func (e *external) Delete(_ context.Context, _ resource.Managed) error {
+	return ghclient.ErrPaused
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package paused
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+)
+
+var errBoom = errors.New("boom")
+
+// unreachable fails the test if any of its operations is called.
+func unreachable(t *testing.T) managed.ExternalClient {
+	return managed.ExternalClientFns{
+		ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+			t.Errorf("Observe(...): unexpected call")
+			return managed.ExternalObservation{}, errBoom
+		},
+		CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+			t.Errorf("Create(...): unexpected call")
+			return managed.ExternalCreation{}, errBoom
+		},
+		UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+			t.Errorf("Update(...): unexpected call")
+			return managed.ExternalUpdate{}, errBoom
+		},
+		DeleteFn: func(_ context.Context, _ resource.Managed) error {
+			t.Errorf("Delete(...): unexpected call")
+			return errBoom
+		},
+	}
+}
+
+func TestPaused(t *testing.T) {
+	connected := false
+	c := NewConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+		connected = true
+		return unreachable(t), nil
+	}), logging.NewNopLogger())
+
+	mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{
+		Name:        "test",
+		Annotations: map[string]string{ghclient.AnnotationKeyPaused: "true"},
+	}}
+	ctx := context.Background()
+	e, err := c.Connect(ctx, mg)
+	if err != nil {
+		t.Fatalf("Connect(...): %v", err)
+	}
+	if connected {
+		t.Errorf("Connect(...): paused resources should not be connected")
+	}
+
+	o, err := e.Observe(ctx, mg)
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, o); diff != "" || err != nil {
+		t.Errorf("Observe(...): -want, +got:\n%s\nerror: %v", diff, err)
+	}
+	if _, err := e.Create(ctx, mg); !errors.Is(err, ghclient.ErrPaused) {
+		t.Errorf("Create(...): want ErrPaused, got %v", err)
+	}
+	if _, err := e.Update(ctx, mg); !errors.Is(err, ghclient.ErrPaused) {
+		t.Errorf("Update(...): want ErrPaused, got %v", err)
+	}
+	if err := e.Delete(ctx, mg); !errors.Is(err, ghclient.ErrPaused) {
+		t.Errorf("Delete(...): want ErrPaused, got %v", err)
+	}
+}
+
+func TestNotPaused(t *testing.T) {
+	want := managed.ExternalObservation{ResourceExists: true}
+	c := NewConnecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+		return managed.ExternalClientFns{
+			ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+				return want, nil
+			},
+			DeleteFn: func(_ context.Context, _ resource.Managed) error {
+				return errBoom
+			},
+		}, nil
+	}), logging.NewNopLogger())
+
+	mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{
+		Name:        "test",
+		Annotations: map[string]string{ghclient.AnnotationKeyPaused: "false"},
+	}}
+	ctx := context.Background()
+	e, err := c.Connect(ctx, mg)
+	if err != nil {
+		t.Fatalf("Connect(...): %v", err)
+	}
+	o, err := e.Observe(ctx, mg)
+	if diff := cmp.Diff(want, o); diff != "" || err != nil {
+		t.Errorf("Observe(...): -want, +got:\n%s\nerror: %v", diff, err)
+	}
+	if diff := cmp.Diff(errBoom, e.Delete(ctx, mg), test.EquateErrors()); diff != "" {
+		t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reconciler builds the managed resource reconcilers used by all of
+// the provider's managed resource controllers.
+package reconciler
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/changeonly"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/config"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/instrument"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/paused"
+)
+
+// New returns a reconciler for the supplied kind of managed resource that
+// uses the supplied ExternalConnecter to connect to GitHub. The connecter is
+// wrapped so that every controller honors the paused and
+// manage-on-change-only annotations and records the same metrics, and the reconciler is wrapped so
+// that every controller requeues the same way when its ProviderConfig is
+// missing or GitHub rate limits it, and so that warnings about the GitHub
+// requests made while reconciling are logged to the supplied logger.
+//
+// By default connection details are not published and only the default
+// ProviderConfig initializer is used. The supplied options are applied last,
+// so they may override these defaults.
+func New(mgr ctrl.Manager, of resource.ManagedKind, c managed.ExternalConnecter, l logging.Logger, rec event.Recorder, o ...managed.ReconcilerOption) reconcile.Reconciler {
+	kind := of.Kind
+	ec := paused.NewConnecter(instrument.NewConnecter(changeonly.NewConnecter(c, mgr.GetClient()), kind, rec), l)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(ec),
+		managed.WithConnectionPublishers(),
+		managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+		managed.WithLogger(l),
+		managed.WithRecorder(rec),
+	}
+	r := managed.NewReconciler(mgr, of, append(opts, o...)...)
+	return &loggingReconciler{log: l, r: config.WithMissingRequeue(mgr, of, instrument.WithRateLimitRequeue(kind, r))}
+}
+
+// A loggingReconciler adds its logger to the context of each reconcile. GitHub
+// clients are shared by every controller that uses the same ProviderConfig, so
+// they find the logger of the controller they are used by in the context.
+type loggingReconciler struct {
+	log logging.Logger
+	r   reconcile.Reconciler
+}
+
+func (r *loggingReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	return r.r.Reconcile(ghclient.WithLogger(ctx, r.log), req)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errNotBranchProtection    = "The managed resource is not a BranchProtection resource"
+	errGetBranchProtection    = "cannot get branch protection"
+	errUpdateBranchProtection = "cannot update branch protection"
+	errRemoveBranchProtection = "cannot remove branch protection"
+)
+
+// SetupBranchProtection adds a controller that reconciles BranchProtections.
+func SetupBranchProtection(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.BranchProtectionGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.BranchProtectionGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.BranchProtection{}).
+		Complete(reconciler.New(mgr, of, &branchProtectionConnector{client: mgr.GetClient(), newServiceFn: repositories.NewBranchProtectionService, logger: logger}, logger, recorder))
+}
+
+type branchProtectionConnector struct {
+	client       client.Client
+	newServiceFn func(*ghclient.Config) (repositories.BranchProtectionService, error)
+	logger       logging.Logger
+}
+
+func (c *branchProtectionConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.BranchProtection)
+	if !ok {
+		return nil, errors.New(errNotBranchProtection)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &branchProtectionExternal{svc, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type branchProtectionExternal struct {
+	client repositories.BranchProtectionService
+	logger logging.Logger
+}
+
+func (e *branchProtectionExternal) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.BranchProtection)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBranchProtection)
+	}
+
+	p := cr.Spec.ForProvider
+	pr, _, err := e.client.GetBranchProtection(ctx, p.Owner, p.Repository, p.Branch)
+	if ghclient.IsNotFound(err) {
+		// GitHub returns 404 Branch not protected for unprotected branches.
+		e.logger.Debug("Branch is not protected", "branch", p.Branch)
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, ghclient.Wrap(err, errGetBranchProtection)
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	repositories.LateInitializeBranchProtection(&cr.Spec.ForProvider, pr)
+	cr.Status.AtProvider = repositories.GenerateBranchProtectionObservation(pr)
+	cr.SetConditions(xpv1.Available())
+
+	upToDate := repositories.IsBranchProtectionUpToDate(cr.Spec.ForProvider, pr)
+	if !upToDate {
+		e.logger.Debug("Branch protection is not up to date", "branch", p.Branch)
+	}
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+	}, nil
+}
+
+func (e *branchProtectionExternal) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.BranchProtection)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotBranchProtection)
+	}
+
+	return managed.ExternalCreation{}, e.update(ctx, cr.Spec.ForProvider)
+}
+
+func (e *branchProtectionExternal) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.BranchProtection)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotBranchProtection)
+	}
+
+	return managed.ExternalUpdate{}, e.update(ctx, cr.Spec.ForProvider)
+}
+
+func (e *branchProtectionExternal) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.BranchProtection)
+	if !ok {
+		return errors.New(errNotBranchProtection)
+	}
+
+	p := cr.Spec.ForProvider
+	_, err := e.client.RemoveBranchProtection(ctx, p.Owner, p.Repository, p.Branch)
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	return ghclient.Wrap(err, errRemoveBranchProtection)
+}
+
+// update protects the branch, replacing any existing protection.
+func (e *branchProtectionExternal) update(ctx context.Context, p v1alpha1.BranchProtectionParameters) error {
+	_, _, err := e.client.UpdateBranchProtection(ctx, p.Owner, p.Repository, p.Branch, repositories.GenerateProtectionRequest(p))
+	return ghclient.Wrap(err, errUpdateBranchProtection)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories/fake"
+)
+
+func branchProtection(enforceAdmins bool, contexts ...string) *v1alpha1.BranchProtection {
+	f := false
+	cr := &v1alpha1.BranchProtection{}
+	cr.SetName("main")
+	cr.Spec.ForProvider = v1alpha1.BranchProtectionParameters{
+		Owner:                "crossplane",
+		Repository:           "provider-github",
+		Branch:               "main",
+		RequiredStatusChecks: &v1alpha1.StatusChecks{Contexts: contexts},
+		EnforceAdmins:        enforceAdmins,
+		RequireLinearHistory: &f,
+		AllowForcePushes:     &f,
+		AllowDeletions:       &f,
+	}
+	return cr
+}
+
+func protection(enforceAdmins bool, contexts ...string) *github.Protection {
+	return &github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: contexts},
+		EnforceAdmins:        &github.AdminEnforcement{Enabled: enforceAdmins},
+		RequireLinearHistory: &github.RequireLinearHistory{},
+		AllowForcePushes:     &github.AllowForcePushes{},
+		AllowDeletions:       &github.AllowDeletions{},
+	}
+}
+
+func TestBranchProtectionObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		pr     *github.Protection
+		err    error
+		cr     *v1alpha1.BranchProtection
+		want   want
+	}{
+		"NotProtected": {
+			reason: "An unprotected branch should not exist.",
+			err:    notFound(),
+			cr:     branchProtection(false, "build"),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"GetError": {
+			reason: "Errors getting the protection should be returned.",
+			err:    errBoom,
+			cr:     branchProtection(false, "build"),
+			want:   want{err: errors.Wrap(errBoom, errGetBranchProtection)},
+		},
+		"UpToDate": {
+			reason: "A branch protected as desired should be up to date.",
+			pr:     protection(false, "test", "build"),
+			cr:     branchProtection(false, "build", "test"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"EnforceAdminsToggled": {
+			reason: "A protection that should now be enforced for admins should not be up to date.",
+			pr:     protection(false, "build"),
+			cr:     branchProtection(true, "build"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"ContextsChanged": {
+			reason: "A protection requiring different status checks should not be up to date.",
+			pr:     protection(false, "build"),
+			cr:     branchProtection(false, "build", "test"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &branchProtectionExternal{
+				client: &fake.MockBranchProtectionService{MockGetBranchProtection: func(_ context.Context, _, _, _ string) (*github.Protection, *github.Response, error) {
+					return tc.pr, nil, tc.err
+				}},
+				logger: logging.NewNopLogger(),
+			}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestBranchProtectionUpdate(t *testing.T) {
+	cr := branchProtection(true, "build", "test")
+	e := &branchProtectionExternal{
+		client: &fake.MockBranchProtectionService{MockUpdateBranchProtection: func(_ context.Context, _, _, branch string, r *github.ProtectionRequest) (*github.Protection, *github.Response, error) {
+			if branch != "main" || !r.EnforceAdmins {
+				t.Errorf("UpdateBranchProtection(...): unexpected request for %q: %+v", branch, r)
+			}
+			if diff := cmp.Diff([]string{"build", "test"}, r.RequiredStatusChecks.Contexts); diff != "" {
+				t.Errorf("UpdateBranchProtection(...): -want contexts, +got contexts:\n%s", diff)
+			}
+			return nil, nil, nil
+		}},
+		logger: logging.NewNopLogger(),
+	}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Errorf("Update(...): %v", err)
+	}
+}
+
+func TestBranchProtectionDelete(t *testing.T) {
+	e := &branchProtectionExternal{
+		client: &fake.MockBranchProtectionService{MockRemoveBranchProtection: func(_ context.Context, _, _, _ string) (*github.Response, error) {
+			return nil, notFound()
+		}},
+		logger: logging.NewNopLogger(),
+	}
+	if err := e.Delete(context.Background(), branchProtection(false)); err != nil {
+		t.Errorf("Delete(...): an unprotected branch should not be an error, got %v", err)
+	}
+}
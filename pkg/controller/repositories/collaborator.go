@@ -0,0 +1,259 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errNotCollaborator    = "The managed resource is not a Collaborator resource"
+	errIsCollaborator     = "cannot check whether user is a collaborator"
+	errGetPermissionLevel = "cannot get permission level of collaborator"
+	errListInvitations    = "cannot list repository invitations"
+	errListCollaborators  = "cannot list collaborators"
+	errAddCollaborator    = "cannot add collaborator"
+	errUpdateInvitation   = "cannot update repository invitation"
+	errRemoveCollaborator = "cannot remove collaborator"
+	errDeleteInvitation   = "cannot delete repository invitation"
+
+	collaboratorStateActive  = "active"
+	collaboratorStatePending = "pending"
+
+	affiliationAll    = "all"
+	affiliationDirect = "direct"
+)
+
+// SetupCollaborator adds a controller that reconciles Collaborators.
+func SetupCollaborator(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.CollaboratorGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.CollaboratorGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.Collaborator{}).
+		Complete(reconciler.New(mgr, of, &collaboratorConnector{client: mgr.GetClient(), newServiceFn: repositories.NewCollaboratorService, logger: logger}, logger, recorder))
+}
+
+type collaboratorConnector struct {
+	client       client.Client
+	newServiceFn func(*ghclient.Config) (repositories.CollaboratorService, error)
+	logger       logging.Logger
+}
+
+func (c *collaboratorConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Collaborator)
+	if !ok {
+		return nil, errors.New(errNotCollaborator)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &collaboratorExternal{svc, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type collaboratorExternal struct {
+	client repositories.CollaboratorService
+	logger logging.Logger
+}
+
+func (e *collaboratorExternal) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.Collaborator)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCollaborator)
+	}
+
+	p := cr.Spec.ForProvider
+	isCollaborator, _, err := e.client.IsCollaborator(ctx, p.Owner, p.Repository, p.User)
+	if err != nil {
+		return managed.ExternalObservation{}, ghclient.Wrap(err, errIsCollaborator)
+	}
+
+	var upToDate bool
+	if isCollaborator {
+		l, _, err := e.client.GetPermissionLevel(ctx, p.Owner, p.Repository, p.User)
+		if err != nil {
+			return managed.ExternalObservation{}, ghclient.Wrap(err, errGetPermissionLevel)
+		}
+		// The permission level doesn't tell "maintain" from "push" or
+		// "triage" from "pull", so the role is read from the collaborator's
+		// permissions. The level is only used if GitHub doesn't report them.
+		u, err := e.findCollaborator(ctx, p, affiliationAll)
+		if err != nil {
+			return managed.ExternalObservation{}, ghclient.Wrap(err, errListCollaborators)
+		}
+		permission := repositories.CollaboratorRole(u)
+		if permission != "" {
+			upToDate = repositories.IsCollaboratorUpToDate(p, permission)
+		} else {
+			permission = l.GetPermission()
+			upToDate = repositories.HasPermissionLevel(p, permission)
+		}
+		cr.Status.AtProvider = v1alpha1.CollaboratorObservation{State: collaboratorStateActive, Permission: permission}
+		cr.SetConditions(xpv1.Available())
+
+		// GitHub reports the highest permission the user has, which may be
+		// inherited from the organization or a team. Adding the user with a
+		// lower permission can't lower it, so once the user is a direct
+		// collaborator the inherited permission is reported instead.
+		if want := repositories.CollaboratorPermission(p); repositories.ExceedsPermission(permission, want) {
+			direct, err := e.findCollaborator(ctx, p, affiliationDirect)
+			if err != nil {
+				return managed.ExternalObservation{}, ghclient.Wrap(err, errListCollaborators)
+			}
+			cr.Status.AtProvider.InheritedPermission = permission
+			if direct != nil {
+				e.logger.Debug("Collaborator inherits a higher permission", "user", p.User, "permission", permission)
+				upToDate = true
+			}
+		}
+	} else {
+		inv, err := e.findInvitation(ctx, p)
+		if err != nil {
+			return managed.ExternalObservation{}, ghclient.Wrap(err, errListInvitations)
+		}
+		if inv == nil {
+			e.logger.Debug("User is neither a collaborator nor invited", "user", p.User)
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		// Invitations report the full role, although "pull" and "push" are
+		// reported as "read" and "write".
+		role := repositories.Role(inv.GetPermissions())
+		upToDate = repositories.IsCollaboratorUpToDate(p, role)
+		cr.Status.AtProvider = v1alpha1.CollaboratorObservation{State: collaboratorStatePending, Permission: role, InvitationID: inv.ID}
+		cr.SetConditions(xpv1.Creating())
+	}
+
+	e.logger.Debug("Observed collaborator", "state", cr.Status.AtProvider.State, "permission", cr.Status.AtProvider.Permission, "upToDate", upToDate)
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+func (e *collaboratorExternal) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.Collaborator)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCollaborator)
+	}
+
+	p := cr.Spec.ForProvider
+	opts := &github.RepositoryAddCollaboratorOptions{Permission: repositories.CollaboratorPermission(p)}
+	_, _, err := e.client.AddCollaborator(ctx, p.Owner, p.Repository, p.User, opts)
+	return managed.ExternalCreation{}, ghclient.Wrap(err, errAddCollaborator)
+}
+
+func (e *collaboratorExternal) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.Collaborator)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCollaborator)
+	}
+
+	p := cr.Spec.ForProvider
+	perm := repositories.CollaboratorPermission(p)
+	if id := cr.Status.AtProvider.InvitationID; cr.Status.AtProvider.State == collaboratorStatePending && id != nil {
+		_, _, err := e.client.UpdateInvitation(ctx, p.Owner, p.Repository, *id, repositories.InvitationPermission(perm))
+		return managed.ExternalUpdate{}, ghclient.Wrap(err, errUpdateInvitation)
+	}
+	// Adding an existing collaborator changes their permission.
+	_, _, err := e.client.AddCollaborator(ctx, p.Owner, p.Repository, p.User, &github.RepositoryAddCollaboratorOptions{Permission: perm})
+	return managed.ExternalUpdate{}, ghclient.Wrap(err, errAddCollaborator)
+}
+
+func (e *collaboratorExternal) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.Collaborator)
+	if !ok {
+		return errors.New(errNotCollaborator)
+	}
+
+	p := cr.Spec.ForProvider
+	if id := cr.Status.AtProvider.InvitationID; cr.Status.AtProvider.State == collaboratorStatePending && id != nil {
+		_, err := e.client.DeleteInvitation(ctx, p.Owner, p.Repository, *id)
+		if ghclient.IsNotFound(err) {
+			return nil
+		}
+		return ghclient.Wrap(err, errDeleteInvitation)
+	}
+	_, err := e.client.RemoveCollaborator(ctx, p.Owner, p.Repository, p.User)
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	return ghclient.Wrap(err, errRemoveCollaborator)
+}
+
+// findInvitation returns the pending invitation of the user to the
+// repository, or nil if the user is not invited.
+func (e *collaboratorExternal) findInvitation(ctx context.Context, p v1alpha1.CollaboratorParameters) (*github.RepositoryInvitation, error) {
+	var found *github.RepositoryInvitation
+	err := ghclient.ListAll(&github.ListOptions{}, func(opts *github.ListOptions) (*github.Response, error) {
+		invs, res, err := e.client.ListInvitations(ctx, p.Owner, p.Repository, opts)
+		if err != nil {
+			return nil, err
+		}
+		if found = repositories.FindInvitation(invs, p.User); found != nil {
+			return nil, nil
+		}
+		return res, nil
+	})
+	return found, err
+}
+
+// findCollaborator returns the user among the collaborators of the repository
+// with the supplied affiliation, or nil if the user is not one of them.
+func (e *collaboratorExternal) findCollaborator(ctx context.Context, p v1alpha1.CollaboratorParameters, affiliation string) (*github.User, error) {
+	var found *github.User
+	err := ghclient.ListAll(&github.ListOptions{}, func(opts *github.ListOptions) (*github.Response, error) {
+		users, res, err := e.client.ListCollaborators(ctx, p.Owner, p.Repository, &github.ListCollaboratorsOptions{Affiliation: affiliation, ListOptions: *opts})
+		if err != nil {
+			return nil, err
+		}
+		if found = repositories.FindCollaborator(users, p.User); found != nil {
+			return nil, nil
+		}
+		return res, nil
+	})
+	return found, err
+}
@@ -0,0 +1,377 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories/fake"
+)
+
+const (
+	collaboratorUser = "octocat"
+	invitationID     = int64(11)
+)
+
+func collaborator(permission string, o ...func(*v1alpha1.Collaborator)) *v1alpha1.Collaborator {
+	cr := &v1alpha1.Collaborator{}
+	cr.SetName("octocat")
+	cr.Spec.ForProvider = v1alpha1.CollaboratorParameters{
+		Owner:      hookOwner,
+		Repository: hookRepo,
+		User:       collaboratorUser,
+		Permission: github.String(permission),
+	}
+	for _, fn := range o {
+		fn(cr)
+	}
+	return cr
+}
+
+func pending(permission string) func(*v1alpha1.Collaborator) {
+	return func(cr *v1alpha1.Collaborator) {
+		cr.Status.AtProvider = v1alpha1.CollaboratorObservation{State: collaboratorStatePending, Permission: permission, InvitationID: github.Int64(invitationID)}
+	}
+}
+
+// collaboratorService returns a CollaboratorService for a repository on which
+// the user has the supplied permission level and role, with the supplied
+// direct collaborators and invitations. An empty level means the user has no
+// access, and an empty role that GitHub doesn't report the user's role.
+func collaboratorService(level, role string, direct []string, invs ...*github.RepositoryInvitation) *fake.MockCollaboratorService {
+	return &fake.MockCollaboratorService{
+		MockIsCollaborator: func(_ context.Context, _, _, _ string) (bool, *github.Response, error) {
+			return level != "", nil, nil
+		},
+		MockGetPermissionLevel: func(_ context.Context, _, _, _ string) (*github.RepositoryPermissionLevel, *github.Response, error) {
+			return &github.RepositoryPermissionLevel{Permission: github.String(level)}, nil, nil
+		},
+		MockListCollaborators: func(_ context.Context, _, _ string, opts *github.ListCollaboratorsOptions) ([]*github.User, *github.Response, error) {
+			switch opts.Affiliation {
+			case affiliationAll:
+				u := &github.User{Login: github.String(collaboratorUser)}
+				if role != "" {
+					u.Permissions = permissions(role)
+				}
+				return []*github.User{{Login: github.String("someone")}, u}, &github.Response{}, nil
+			case affiliationDirect:
+				users := make([]*github.User, len(direct))
+				for i, u := range direct {
+					users[i] = &github.User{Login: github.String(u)}
+				}
+				return users, &github.Response{}, nil
+			}
+			return nil, nil, errors.Errorf("unexpected affiliation %q", opts.Affiliation)
+		},
+		MockListInvitations: func(_ context.Context, _, _ string, _ *github.ListOptions) ([]*github.RepositoryInvitation, *github.Response, error) {
+			return invs, &github.Response{}, nil
+		},
+	}
+}
+
+// permissions returns the permissions GitHub lists for a collaborator with
+// the supplied role, which include every lesser role.
+func permissions(role string) *map[string]bool {
+	all := []string{"pull", "triage", "push", "maintain", "admin"}
+	p := map[string]bool{}
+	granted := true
+	for _, r := range all {
+		p[r] = granted
+		if r == role {
+			granted = false
+		}
+	}
+	return &p
+}
+
+func invitation(permissions string) *github.RepositoryInvitation {
+	return &github.RepositoryInvitation{
+		ID:          github.Int64(invitationID),
+		Invitee:     &github.User{Login: github.String(collaboratorUser)},
+		Permissions: github.String(permissions),
+	}
+}
+
+func TestCollaboratorObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		obs v1alpha1.CollaboratorObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fake.MockCollaboratorService
+		cr     *v1alpha1.Collaborator
+		want   want
+	}{
+		"NotInvited": {
+			reason: "A user that is neither a collaborator nor invited should not exist.",
+			svc:    collaboratorService("", "", nil),
+			cr:     collaborator("push"),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"IsCollaboratorError": {
+			reason: "Errors checking whether the user is a collaborator should be returned.",
+			svc: &fake.MockCollaboratorService{MockIsCollaborator: func(_ context.Context, _, _, _ string) (bool, *github.Response, error) {
+				return false, nil, errBoom
+			}},
+			cr:   collaborator("push"),
+			want: want{err: errors.Wrap(errBoom, errIsCollaborator)},
+		},
+		"PendingInvitation": {
+			reason: "A user invited with the desired permission should exist and be up to date while the invitation is pending.",
+			svc:    collaboratorService("", "", nil, invitation("write")),
+			cr:     collaborator("push"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.CollaboratorObservation{State: collaboratorStatePending, Permission: "push", InvitationID: github.Int64(invitationID)},
+			},
+		},
+		"PendingInvitationUpgraded": {
+			reason: "A user invited with a lower permission than desired should be updated.",
+			svc:    collaboratorService("", "", nil, invitation("read")),
+			cr:     collaborator("admin"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true},
+				obs: v1alpha1.CollaboratorObservation{State: collaboratorStatePending, Permission: "pull", InvitationID: github.Int64(invitationID)},
+			},
+		},
+		"PendingInvitationPushToMaintain": {
+			reason: "A user invited to push who should maintain should be updated, although both have the write level.",
+			svc:    collaboratorService("", "", nil, invitation("write")),
+			cr:     collaborator("maintain"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true},
+				obs: v1alpha1.CollaboratorObservation{State: collaboratorStatePending, Permission: "push", InvitationID: github.Int64(invitationID)},
+			},
+		},
+		"PendingInvitationPullToTriage": {
+			reason: "A user invited to pull who should triage should be updated, although both have the read level.",
+			svc:    collaboratorService("", "", nil, invitation("read")),
+			cr:     collaborator("triage"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true},
+				obs: v1alpha1.CollaboratorObservation{State: collaboratorStatePending, Permission: "pull", InvitationID: github.Int64(invitationID)},
+			},
+		},
+		"PendingInvitationMaintain": {
+			reason: "A user invited to maintain should be up to date.",
+			svc:    collaboratorService("", "", nil, invitation("maintain")),
+			cr:     collaborator("maintain"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.CollaboratorObservation{State: collaboratorStatePending, Permission: "maintain", InvitationID: github.Int64(invitationID)},
+			},
+		},
+		"Active": {
+			reason: "A collaborator with the desired permission should be up to date.",
+			svc:    collaboratorService("write", "push", []string{collaboratorUser}),
+			cr:     collaborator("push"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.CollaboratorObservation{State: collaboratorStateActive, Permission: "push"},
+			},
+		},
+		"ActiveUpgraded": {
+			reason: "A collaborator with a lower permission than desired should be updated.",
+			svc:    collaboratorService("read", "pull", []string{collaboratorUser}),
+			cr:     collaborator("push"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true},
+				obs: v1alpha1.CollaboratorObservation{State: collaboratorStateActive, Permission: "pull"},
+			},
+		},
+		"ActivePushToMaintain": {
+			reason: "A collaborator who pushes but should maintain should be updated, although both have the write level.",
+			svc:    collaboratorService("write", "push", []string{collaboratorUser}),
+			cr:     collaborator("maintain"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true},
+				obs: v1alpha1.CollaboratorObservation{State: collaboratorStateActive, Permission: "push"},
+			},
+		},
+		"ActivePullToTriage": {
+			reason: "A collaborator who pulls but should triage should be updated, although both have the read level.",
+			svc:    collaboratorService("read", "pull", []string{collaboratorUser}),
+			cr:     collaborator("triage"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true},
+				obs: v1alpha1.CollaboratorObservation{State: collaboratorStateActive, Permission: "pull"},
+			},
+		},
+		"ActiveMaintain": {
+			reason: "A collaborator who maintains should be up to date.",
+			svc:    collaboratorService("write", "maintain", []string{collaboratorUser}),
+			cr:     collaborator("maintain"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.CollaboratorObservation{State: collaboratorStateActive, Permission: "maintain"},
+			},
+		},
+		"ActiveLevelOnly": {
+			reason: "A collaborator whose role GitHub doesn't report should be compared by permission level.",
+			svc:    collaboratorService("write", "", []string{collaboratorUser}),
+			cr:     collaborator("maintain"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.CollaboratorObservation{State: collaboratorStateActive, Permission: "write"},
+			},
+		},
+		"InheritedHigherPermission": {
+			reason: "A direct collaborator that inherits a higher permission should be up to date, and the inherited permission reported.",
+			svc:    collaboratorService("admin", "admin", []string{"someone", collaboratorUser}),
+			cr:     collaborator("pull"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.CollaboratorObservation{State: collaboratorStateActive, Permission: "admin", InheritedPermission: "admin"},
+			},
+		},
+		"InheritedMaintain": {
+			reason: "A direct collaborator that inherits maintain should be up to date when they should push.",
+			svc:    collaboratorService("write", "maintain", []string{collaboratorUser}),
+			cr:     collaborator("push"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				obs: v1alpha1.CollaboratorObservation{State: collaboratorStateActive, Permission: "maintain", InheritedPermission: "maintain"},
+			},
+		},
+		"InheritedNotDirect": {
+			reason: "A user that only inherits access should be added as a direct collaborator.",
+			svc:    collaboratorService("admin", "admin", []string{"someone"}),
+			cr:     collaborator("pull"),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true},
+				obs: v1alpha1.CollaboratorObservation{State: collaboratorStateActive, Permission: "admin", InheritedPermission: "admin"},
+			},
+		},
+		"ListCollaboratorsError": {
+			reason: "Errors listing collaborators should be returned.",
+			svc: func() *fake.MockCollaboratorService {
+				svc := collaboratorService("admin", "admin", nil)
+				svc.MockListCollaborators = func(_ context.Context, _, _ string, _ *github.ListCollaboratorsOptions) ([]*github.User, *github.Response, error) {
+					return nil, nil, errBoom
+				}
+				return svc
+			}(),
+			cr:   collaborator("pull"),
+			want: want{err: errors.Wrap(errBoom, errListCollaborators)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &collaboratorExternal{client: tc.svc, logger: logging.NewNopLogger()}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, tc.cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCollaboratorUpdate(t *testing.T) {
+	type want struct {
+		calls []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Collaborator
+		err    error
+		want   want
+	}{
+		"PendingInvitation": {
+			reason: "The permission of a pending invitation should be updated.",
+			cr:     collaborator("push", pending("read")),
+			want:   want{calls: []string{"UpdateInvitation write"}},
+		},
+		"PendingInvitationError": {
+			reason: "Errors updating a pending invitation should be returned.",
+			cr:     collaborator("push", pending("read")),
+			err:    errBoom,
+			want:   want{calls: []string{"UpdateInvitation write"}, err: errors.Wrap(errBoom, errUpdateInvitation)},
+		},
+		"Active": {
+			reason: "A collaborator's permission should be upgraded by adding them again.",
+			cr:     collaborator("admin"),
+			want:   want{calls: []string{"AddCollaborator admin"}},
+		},
+		"PushToMaintain": {
+			reason: "A collaborator who pushes should be upgraded to maintain by adding them again.",
+			cr:     collaborator("maintain"),
+			want:   want{calls: []string{"AddCollaborator maintain"}},
+		},
+		"PendingPullToTriage": {
+			reason: "A pending invitation to pull should be upgraded to triage.",
+			cr:     collaborator("triage", pending("pull")),
+			want:   want{calls: []string{"UpdateInvitation triage"}},
+		},
+		"ActiveError": {
+			reason: "Errors upgrading a collaborator's permission should be returned.",
+			cr:     collaborator("admin"),
+			err:    errBoom,
+			want:   want{calls: []string{"AddCollaborator admin"}, err: errors.Wrap(errBoom, errAddCollaborator)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			e := &collaboratorExternal{
+				client: &fake.MockCollaboratorService{
+					MockUpdateInvitation: func(_ context.Context, _, _ string, id int64, permissions string) (*github.RepositoryInvitation, *github.Response, error) {
+						calls = append(calls, "UpdateInvitation "+permissions)
+						if id != invitationID {
+							t.Errorf("UpdateInvitation(...): want invitation %d, got %d", invitationID, id)
+						}
+						return nil, nil, tc.err
+					},
+					MockAddCollaborator: func(_ context.Context, _, _, _ string, opts *github.RepositoryAddCollaboratorOptions) (*github.CollaboratorInvitation, *github.Response, error) {
+						calls = append(calls, "AddCollaborator "+opts.Permission)
+						return nil, nil, tc.err
+					},
+				},
+				logger: logging.NewNopLogger(),
+			}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want calls, +got calls:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errNotContent    = "The managed resource is not a Content resource"
+	errGetContent    = "cannot get file"
+	errContentIsDir  = "path is a directory, not a file"
+	errDecodeContent = "cannot decode file content"
+	errListCommits   = "cannot list commits of file"
+	errCreateFile    = "cannot create file"
+	errUpdateFile    = "cannot update file"
+	errDeleteFile    = "cannot delete file"
+	fmtDeleteMessage = "Delete %s"
+)
+
+// templateTTL is how long the rendered content of a templated file is reused
+// when observing it before its template is fetched from GitHub again.
+const templateTTL = time.Hour
+
+// SetupContent adds a controller that reconciles Contents.
+func SetupContent(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.ContentGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.ContentGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.Content{}).
+		Complete(reconciler.New(mgr, of, &contentConnector{client: mgr.GetClient(), newServiceFn: repositories.NewContentService, templates: repositories.NewTemplateCache(templateTTL), logger: logger}, logger, recorder))
+}
+
+type contentConnector struct {
+	client       client.Client
+	newServiceFn func(*ghclient.Config) (repositories.ContentService, error)
+	templates    *repositories.TemplateCache
+	logger       logging.Logger
+}
+
+func (c *contentConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Content)
+	if !ok {
+		return nil, errors.New(errNotContent)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &contentExternal{client: svc, templates: c.templates, baseURL: cfg.BaseURL, logger: c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type contentExternal struct {
+	client    repositories.ContentService
+	templates *repositories.TemplateCache
+	baseURL   string
+	logger    logging.Logger
+}
+
+func (e *contentExternal) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.Content)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotContent)
+	}
+
+	p := cr.Spec.ForProvider
+	opts := &github.RepositoryContentGetOptions{}
+	if p.Branch != nil {
+		opts.Ref = *p.Branch
+	}
+	f, _, _, err := e.client.GetContents(ctx, p.Owner, p.Repository, p.Path, opts)
+	if ghclient.IsNotFound(err) {
+		e.logger.Debug("File does not exist", "path", p.Path)
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, ghclient.Wrap(err, errGetContent)
+	}
+	if f == nil {
+		return managed.ExternalObservation{}, errors.New(errContentIsDir)
+	}
+	content, err := f.GetContent()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errDecodeContent)
+	}
+
+	cr.Status.AtProvider = repositories.GenerateContentObservation(f)
+	cr.SetConditions(xpv1.Available())
+
+	want, err := e.templates.GetDesiredContent(ctx, e.client, e.baseURL, p)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if content != want {
+		e.logger.Debug("File content differs", "path", p.Path)
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
+	}
+
+	msg, err := e.lastCommitMessage(ctx, p)
+	if err != nil {
+		return managed.ExternalObservation{}, ghclient.Wrap(err, errListCommits)
+	}
+	if msg != p.Message {
+		e.logger.Debug("Commit message of file differs", "path", p.Path, "message", msg)
+	}
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: msg == p.Message,
+	}, nil
+}
+
+func (e *contentExternal) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.Content)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotContent)
+	}
+
+	p, err := e.resolve(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	_, _, err = e.client.CreateFile(ctx, p.Owner, p.Repository, p.Path, repositories.GenerateFileOptions(p, nil))
+	return managed.ExternalCreation{}, ghclient.Wrap(err, errCreateFile)
+}
+
+func (e *contentExternal) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.Content)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotContent)
+	}
+
+	// GitHub rejects updates that don't supply the SHA of the file being
+	// replaced with a 409 Conflict.
+	p, err := e.resolve(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	_, _, err = e.client.UpdateFile(ctx, p.Owner, p.Repository, p.Path, repositories.GenerateFileOptions(p, cr.Status.AtProvider.SHA))
+	return managed.ExternalUpdate{}, ghclient.Wrap(err, errUpdateFile)
+}
+
+func (e *contentExternal) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.Content)
+	if !ok {
+		return errors.New(errNotContent)
+	}
+
+	p := cr.Spec.ForProvider
+	_, _, err := e.client.DeleteFile(ctx, p.Owner, p.Repository, p.Path, &github.RepositoryContentFileOptions{
+		Message: github.String(fmt.Sprintf(fmtDeleteMessage, p.Path)),
+		SHA:     cr.Status.AtProvider.SHA,
+		Branch:  p.Branch,
+	})
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	return ghclient.Wrap(err, errDeleteFile)
+}
+
+// resolve returns the supplied ContentParameters with the desired content of
+// the file, which is validated before it is committed.
+func (e *contentExternal) resolve(ctx context.Context, p v1alpha1.ContentParameters) (v1alpha1.ContentParameters, error) {
+	content, err := repositories.GetDesiredContent(ctx, e.client, p)
+	if err != nil {
+		return p, err
+	}
+	p.Content = content
+	return p, repositories.ValidateContent(p)
+}
+
+// lastCommitMessage returns the message of the most recent commit that
+// touched the file.
+func (e *contentExternal) lastCommitMessage(ctx context.Context, p v1alpha1.ContentParameters) (string, error) {
+	opts := &github.CommitsListOptions{Path: p.Path, ListOptions: github.ListOptions{PerPage: 1}}
+	if p.Branch != nil {
+		opts.SHA = *p.Branch
+	}
+	commits, _, err := e.client.ListCommits(ctx, p.Owner, p.Repository, opts)
+	if err != nil || len(commits) == 0 {
+		return "", err
+	}
+	return commits[0].GetCommit().GetMessage(), nil
+}
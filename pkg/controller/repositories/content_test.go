@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories/fake"
+)
+
+const commitMessage = "Manage LICENSE"
+
+func content(p v1alpha1.ContentParameters) *v1alpha1.Content {
+	cr := &v1alpha1.Content{}
+	cr.SetName("license")
+	p.Owner = hookOwner
+	p.Repository = hookRepo
+	p.Path = "LICENSE"
+	p.Message = commitMessage
+	cr.Spec.ForProvider = p
+	return cr
+}
+
+func mitLicense() v1alpha1.ContentParameters {
+	return v1alpha1.ContentParameters{Template: &v1alpha1.ContentTemplate{
+		License:      github.String("mit"),
+		Replacements: map[string]string{"year": "2021"},
+	}}
+}
+
+// contentService returns a ContentService that serves a file with the
+// supplied content, last committed with the supplied message, and the MIT
+// license template.
+func contentService(file, message string) *fake.MockContentService {
+	return &fake.MockContentService{
+		MockGetContents: func(_ context.Context, _, _, _ string, _ *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+			return &github.RepositoryContent{Content: github.String(file), SHA: github.String("abc")}, nil, nil, nil
+		},
+		MockListCommits: func(_ context.Context, _, _ string, _ *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+			return []*github.RepositoryCommit{{Commit: &github.Commit{Message: github.String(message)}}}, nil, nil
+		},
+		MockGetLicense: func(_ context.Context, _ string) (*github.License, *github.Response, error) {
+			return &github.License{Body: github.String("Copyright [year]")}, nil, nil
+		},
+	}
+}
+
+func TestContentObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fake.MockContentService
+		cr     *v1alpha1.Content
+		want   want
+	}{
+		"NotFound": {
+			reason: "A file GitHub does not know about should not exist.",
+			svc: &fake.MockContentService{MockGetContents: func(_ context.Context, _, _, _ string, _ *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+				return nil, nil, nil, notFound()
+			}},
+			cr:   content(v1alpha1.ContentParameters{Content: "hello"}),
+			want: want{o: managed.ExternalObservation{}},
+		},
+		"Directory": {
+			reason: "A path that is a directory should be reported as an error.",
+			svc: &fake.MockContentService{MockGetContents: func(_ context.Context, _, _, _ string, _ *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+				return nil, []*github.RepositoryContent{{}}, nil, nil
+			}},
+			cr:   content(v1alpha1.ContentParameters{Content: "hello"}),
+			want: want{err: errors.New(errContentIsDir)},
+		},
+		"ContentDiffers": {
+			reason: "A file with different content should be updated.",
+			svc:    contentService("goodbye", commitMessage),
+			cr:     content(v1alpha1.ContentParameters{Content: "hello"}),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"MessageDiffers": {
+			reason: "A file last committed with a different message should be updated.",
+			svc:    contentService("hello", "Initial commit"),
+			cr:     content(v1alpha1.ContentParameters{Content: "hello"}),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"UpToDate": {
+			reason: "A file with the desired content and commit message should be up to date.",
+			svc:    contentService("hello", commitMessage),
+			cr:     content(v1alpha1.ContentParameters{Content: "hello"}),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"TemplateUpToDate": {
+			reason: "A file with the rendered content of its template should be up to date.",
+			svc:    contentService("Copyright 2021", commitMessage),
+			cr:     content(mitLicense()),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &contentExternal{client: tc.svc, templates: repositories.NewTemplateCache(templateTTL), logger: logging.NewNopLogger()}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestContentObserveReusesTemplate(t *testing.T) {
+	svc := contentService("Copyright 2021", commitMessage)
+	fetched := 0
+	svc.MockGetLicense = func(_ context.Context, _ string) (*github.License, *github.Response, error) {
+		fetched++
+		return &github.License{Body: github.String("Copyright [year]")}, nil, nil
+	}
+
+	// Each poll connects anew, but the connector's template cache is shared.
+	templates := repositories.NewTemplateCache(time.Hour)
+	for i := 0; i < 3; i++ {
+		e := &contentExternal{client: svc, templates: templates, logger: logging.NewNopLogger()}
+		if _, err := e.Observe(context.Background(), content(mitLicense())); err != nil {
+			t.Fatalf("Observe(...): %v", err)
+		}
+	}
+	if fetched != 1 {
+		t.Errorf("Observe(...): want the template fetched once, got %d fetches", fetched)
+	}
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errNotDeployKey          = "The managed resource is not a DeployKey resource"
+	errParseKeyID            = "cannot parse external name as a deploy key ID"
+	errGetKey                = "cannot get deploy key"
+	errCreateKey             = "cannot create deploy key"
+	errDeleteKey             = "cannot delete deploy key"
+	errNoPublicKey           = "either key or keySecretRef must be set"
+	errGetPublicKeySecret    = "cannot get secret containing the public key"
+	errFmtNoPublicKeyKey     = "secret containing the public key has no key %q"
+	errUpdateKeyExternalName = "cannot update external name of replaced deploy key"
+)
+
+// SetupDeployKey adds a controller that reconciles DeployKeys.
+func SetupDeployKey(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.DeployKeyGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.DeployKeyGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.DeployKey{}).
+		Complete(reconciler.New(mgr, of, &deployKeyConnector{client: mgr.GetClient(), newServiceFn: repositories.NewDeployKeyService, logger: logger}, logger, recorder))
+}
+
+type deployKeyConnector struct {
+	client       client.Client
+	newServiceFn func(*ghclient.Config) (repositories.DeployKeyService, error)
+	logger       logging.Logger
+}
+
+func (c *deployKeyConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.DeployKey)
+	if !ok {
+		return nil, errors.New(errNotDeployKey)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &deployKeyExternal{svc, c.client, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type deployKeyExternal struct {
+	client repositories.DeployKeyService
+	kube   client.Client
+	logger logging.Logger
+}
+
+func (e *deployKeyExternal) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.DeployKey)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDeployKey)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errParseKeyID)
+	}
+
+	p := cr.Spec.ForProvider
+	k, _, err := e.client.GetKey(ctx, p.Owner, p.Repository, id)
+	if ghclient.IsNotFound(err) {
+		e.logger.Debug("Deploy key does not exist", "id", id)
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, ghclient.Wrap(err, errGetKey)
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	repositories.LateInitializeDeployKey(&cr.Spec.ForProvider, k)
+	cr.Status.AtProvider = repositories.GenerateDeployKeyObservation(k)
+	cr.SetConditions(xpv1.Available())
+
+	key, err := e.publicKey(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	upToDate := repositories.IsDeployKeyUpToDate(cr.Spec.ForProvider, key, k)
+	if !upToDate {
+		e.logger.Debug("Deploy key is not up to date and will be replaced", "id", id)
+	}
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+	}, nil
+}
+
+func (e *deployKeyExternal) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.DeployKey)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotDeployKey)
+	}
+
+	key, err := e.publicKey(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := e.create(ctx, cr, key); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+// Update replaces the deploy key, since GitHub does not allow deploy keys to
+// be edited.
+func (e *deployKeyExternal) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.DeployKey)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDeployKey)
+	}
+
+	// Read the new key before deleting the old one, so that a missing or
+	// unreadable key secret leaves the existing deploy key in place.
+	key, err := e.publicKey(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := e.Delete(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	from := client.MergeFrom(cr.DeepCopy())
+	if err := e.create(ctx, cr, key); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	// Only the status is persisted after an update, so the ID of the new
+	// deploy key must be recorded here for it to be found again. Otherwise
+	// the new key would be leaked and another one created. The annotation is
+	// merge patched, so it can't conflict with other changes to the resource,
+	// and retried, since the key can't be recreated without leaking it.
+	err = retry.OnError(retry.DefaultBackoff, func(err error) bool { return !kerrors.IsNotFound(err) }, func() error {
+		cp := cr.DeepCopy()
+		if err := e.kube.Patch(ctx, cp, from); err != nil {
+			return err
+		}
+		cr.SetResourceVersion(cp.GetResourceVersion())
+		return nil
+	})
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateKeyExternalName)
+}
+
+func (e *deployKeyExternal) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.DeployKey)
+	if !ok {
+		return errors.New(errNotDeployKey)
+	}
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errParseKeyID)
+	}
+	p := cr.Spec.ForProvider
+	_, err = e.client.DeleteKey(ctx, p.Owner, p.Repository, id)
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	return ghclient.Wrap(err, errDeleteKey)
+}
+
+// create creates the deploy key described by the supplied DeployKey with the
+// supplied public key and sets its external name to the ID of the new key.
+func (e *deployKeyExternal) create(ctx context.Context, cr *v1alpha1.DeployKey, key string) error {
+	p := cr.Spec.ForProvider
+	k, _, err := e.client.CreateKey(ctx, p.Owner, p.Repository, repositories.GenerateKey(p, key))
+	if err != nil {
+		return ghclient.Wrap(err, errCreateKey)
+	}
+	meta.SetExternalName(cr, strconv.FormatInt(k.GetID(), 10))
+	return nil
+}
+
+// publicKey returns the public key set, or referenced, by the supplied
+// parameters.
+func (e *deployKeyExternal) publicKey(ctx context.Context, p v1alpha1.DeployKeyParameters) (string, error) {
+	if p.Key != nil {
+		return *p.Key, nil
+	}
+	ref := p.KeySecretRef
+	if ref == nil {
+		return "", errors.New(errNoPublicKey)
+	}
+	s := &corev1.Secret{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return "", errors.Wrap(err, errGetPublicKeySecret)
+	}
+	v, ok := s.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf(errFmtNoPublicKeyKey, ref.Key)
+	}
+	return string(v), nil
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories/fake"
+)
+
+const (
+	deployKeyID = int64(7)
+	oldKey      = "ssh-ed25519 AAAAold deploy@example.org"
+	newKey      = "ssh-ed25519 AAAAnew deploy@example.org"
+)
+
+func deployKey(key string) *v1alpha1.DeployKey {
+	cr := &v1alpha1.DeployKey{}
+	cr.SetName("deploy")
+	meta.SetExternalName(cr, "7")
+	cr.Spec.ForProvider = v1alpha1.DeployKeyParameters{
+		Owner:      hookOwner,
+		Repository: hookRepo,
+		Title:      "deploy",
+		Key:        &key,
+		ReadOnly:   github.Bool(true),
+	}
+	return cr
+}
+
+func ghKey(id int64, key string) *github.Key {
+	return &github.Key{ID: github.Int64(id), Title: github.String("deploy"), Key: github.String(key), ReadOnly: github.Bool(true)}
+}
+
+func TestDeployKeyObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fake.MockDeployKeyService
+		cr     *v1alpha1.DeployKey
+		want   want
+	}{
+		"NotCreated": {
+			reason: "A deploy key without an ID has not been created yet.",
+			svc:    &fake.MockDeployKeyService{},
+			cr: func() *v1alpha1.DeployKey {
+				cr := deployKey(oldKey)
+				meta.SetExternalName(cr, "")
+				return cr
+			}(),
+			want: want{o: managed.ExternalObservation{}},
+		},
+		"NotFound": {
+			reason: "A deploy key GitHub does not know about should not exist.",
+			svc: &fake.MockDeployKeyService{MockGetKey: func(_ context.Context, _, _ string, _ int64) (*github.Key, *github.Response, error) {
+				return nil, nil, notFound()
+			}},
+			cr:   deployKey(oldKey),
+			want: want{o: managed.ExternalObservation{}},
+		},
+		"GetError": {
+			reason: "Errors getting the deploy key should be returned.",
+			svc: &fake.MockDeployKeyService{MockGetKey: func(_ context.Context, _, _ string, _ int64) (*github.Key, *github.Response, error) {
+				return nil, nil, errBoom
+			}},
+			cr:   deployKey(oldKey),
+			want: want{err: errors.Wrap(errBoom, errGetKey)},
+		},
+		"UpToDate": {
+			reason: "A deploy key with the desired key material should be up to date, whatever its comment.",
+			svc: &fake.MockDeployKeyService{MockGetKey: func(_ context.Context, _, _ string, _ int64) (*github.Key, *github.Response, error) {
+				return ghKey(deployKeyID, "ssh-ed25519 AAAAold"), nil, nil
+			}},
+			cr:   deployKey(oldKey),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"KeyChanged": {
+			reason: "A deploy key with different key material should be replaced.",
+			svc: &fake.MockDeployKeyService{MockGetKey: func(_ context.Context, _, _ string, _ int64) (*github.Key, *github.Response, error) {
+				return ghKey(deployKeyID, oldKey), nil, nil
+			}},
+			cr:   deployKey(newKey),
+			want: want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &deployKeyExternal{client: tc.svc, logger: logging.NewNopLogger()}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// patchExternalName returns a patch function that checks only the supplied
+// external name is patched. It fails the first patch with the supplied
+// error, if any.
+func patchExternalName(t *testing.T, name string, first error) test.MockPatchFn {
+	return func(_ context.Context, obj client.Object, p client.Patch, _ ...client.PatchOption) error {
+		if first != nil {
+			err := first
+			first = nil
+			return err
+		}
+		data, err := p.Data(obj)
+		if err != nil {
+			return err
+		}
+		want := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, meta.AnnotationKeyExternalName, name)
+		if diff := cmp.Diff(want, string(data)); diff != "" {
+			t.Errorf("Patch(...): -want patch, +got patch:\n%s\n", diff)
+		}
+		return nil
+	}
+}
+
+func TestDeployKeyUpdate(t *testing.T) {
+	type want struct {
+		calls []string
+		name  string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		cr     *v1alpha1.DeployKey
+		want   want
+	}{
+		"Replaced": {
+			reason: "The old deploy key should be deleted and the new key's ID recorded.",
+			kube:   &test.MockClient{MockPatch: patchExternalName(t, "8", nil)},
+			cr:     deployKey(newKey),
+			want:   want{calls: []string{"DeleteKey", "CreateKey"}, name: "8"},
+		},
+		"RecordRetried": {
+			reason: "Recording the new key's ID should be retried, so that the new key is not leaked.",
+			kube:   &test.MockClient{MockPatch: patchExternalName(t, "8", errBoom)},
+			cr:     deployKey(newKey),
+			want:   want{calls: []string{"DeleteKey", "CreateKey"}, name: "8"},
+		},
+		"RecordError": {
+			reason: "Errors recording the new key's ID should be returned once retries are exhausted.",
+			kube:   &test.MockClient{MockPatch: test.NewMockPatchFn(errBoom)},
+			cr:     deployKey(newKey),
+			want:   want{calls: []string{"DeleteKey", "CreateKey"}, name: "8", err: errors.Wrap(errBoom, errUpdateKeyExternalName)},
+		},
+		"OldKeyAlreadyDeleted": {
+			reason: "A deploy key that was already deleted should still be replaced.",
+			kube:   &test.MockClient{MockPatch: patchExternalName(t, "8", nil)},
+			cr: func() *v1alpha1.DeployKey {
+				cr := deployKey(newKey)
+				meta.SetExternalName(cr, "404")
+				return cr
+			}(),
+			want: want{calls: []string{"DeleteKey", "CreateKey"}, name: "8"},
+		},
+		"KeySecretMissing": {
+			reason: "The old deploy key should be left in place when the new key cannot be read.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cr: func() *v1alpha1.DeployKey {
+				cr := deployKey(newKey)
+				cr.Spec.ForProvider.Key = nil
+				cr.Spec.ForProvider.KeySecretRef = &xpv1.SecretKeySelector{
+					SecretReference: xpv1.SecretReference{Name: "deploy", Namespace: "default"},
+					Key:             "key",
+				}
+				return cr
+			}(),
+			want: want{name: "7", err: errors.Wrap(errBoom, errGetPublicKeySecret)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			e := &deployKeyExternal{
+				client: &fake.MockDeployKeyService{
+					MockDeleteKey: func(_ context.Context, _, _ string, id int64) (*github.Response, error) {
+						calls = append(calls, "DeleteKey")
+						if id == 404 {
+							return nil, notFound()
+						}
+						return nil, nil
+					},
+					MockCreateKey: func(_ context.Context, _, _ string, k *github.Key) (*github.Key, *github.Response, error) {
+						calls = append(calls, "CreateKey")
+						if k.GetKey() != newKey {
+							t.Errorf("CreateKey(...): want key %q, got %q", newKey, k.GetKey())
+						}
+						return ghKey(8, k.GetKey()), nil, nil
+					},
+				},
+				kube:   tc.kube,
+				logger: logging.NewNopLogger(),
+			}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want calls, +got calls:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.name, meta.GetExternalName(tc.cr)); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDeployKeyDelete(t *testing.T) {
+	e := &deployKeyExternal{
+		client: &fake.MockDeployKeyService{MockDeleteKey: func(_ context.Context, _, _ string, _ int64) (*github.Response, error) {
+			return nil, notFound()
+		}},
+		logger: logging.NewNopLogger(),
+	}
+	if err := e.Delete(context.Background(), deployKey(oldKey)); err != nil {
+		t.Errorf("Delete(...): a deploy key that no longer exists should be deleted, got %v", err)
+	}
+}
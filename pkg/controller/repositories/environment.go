@@ -0,0 +1,163 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errNotEnvironment    = "The managed resource is not an Environment resource"
+	errGetEnvironment    = "cannot get environment"
+	errCreateEnvironment = "cannot create environment"
+	errUpdateEnvironment = "cannot update environment"
+	errDeleteEnvironment = "cannot delete environment"
+)
+
+// SetupEnvironment adds a controller that reconciles Environments.
+func SetupEnvironment(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.EnvironmentGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.EnvironmentGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.Environment{}).
+		Complete(reconciler.New(mgr, of, &environmentConnector{client: mgr.GetClient(), newServiceFn: repositories.NewEnvironmentService, logger: logger}, logger, recorder,
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient()))))
+}
+
+type environmentConnector struct {
+	client       client.Client
+	newServiceFn func(*ghclient.Config) (repositories.EnvironmentService, error)
+	logger       logging.Logger
+}
+
+func (c *environmentConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Environment)
+	if !ok {
+		return nil, errors.New(errNotEnvironment)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &environmentExternal{svc, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type environmentExternal struct {
+	client repositories.EnvironmentService
+	logger logging.Logger
+}
+
+func (e *environmentExternal) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.Environment)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotEnvironment)
+	}
+
+	p := cr.Spec.ForProvider
+	name := meta.GetExternalName(cr)
+	env, _, err := e.client.GetEnvironment(ctx, p.Owner, p.Repository, name)
+	if ghclient.IsNotFound(err) {
+		e.logger.Debug("Environment does not exist", "name", name)
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, ghclient.Wrap(err, errGetEnvironment)
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	repositories.LateInitializeEnvironment(&cr.Spec.ForProvider, env)
+	cr.Status.AtProvider = repositories.GenerateEnvironmentObservation(env)
+	cr.SetConditions(xpv1.Available())
+
+	upToDate := repositories.IsEnvironmentUpToDate(cr.Spec.ForProvider, env)
+	if !upToDate {
+		e.logger.Debug("Environment is not up to date", "name", name)
+	}
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+	}, nil
+}
+
+func (e *environmentExternal) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.Environment)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotEnvironment)
+	}
+
+	p := cr.Spec.ForProvider
+	_, _, err := e.client.CreateUpdateEnvironment(ctx, p.Owner, p.Repository, meta.GetExternalName(cr), repositories.GenerateCreateUpdateEnvironment(p))
+	return managed.ExternalCreation{}, ghclient.Wrap(err, errCreateEnvironment)
+}
+
+func (e *environmentExternal) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.Environment)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotEnvironment)
+	}
+
+	// GitHub creates and updates environments with the same request.
+	p := cr.Spec.ForProvider
+	_, _, err := e.client.CreateUpdateEnvironment(ctx, p.Owner, p.Repository, meta.GetExternalName(cr), repositories.GenerateCreateUpdateEnvironment(p))
+	return managed.ExternalUpdate{}, ghclient.Wrap(err, errUpdateEnvironment)
+}
+
+func (e *environmentExternal) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.Environment)
+	if !ok {
+		return errors.New(errNotEnvironment)
+	}
+
+	p := cr.Spec.ForProvider
+	_, err := e.client.DeleteEnvironment(ctx, p.Owner, p.Repository, meta.GetExternalName(cr))
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	return ghclient.Wrap(err, errDeleteEnvironment)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories/fake"
+)
+
+func envResource(wait int, rv ...v1alpha1.EnvironmentReviewer) *v1alpha1.Environment {
+	cr := &v1alpha1.Environment{}
+	cr.SetName("production")
+	meta.SetExternalName(cr, "production")
+	cr.Spec.ForProvider = v1alpha1.EnvironmentParameters{
+		Owner:                  hookOwner,
+		Repository:             hookRepo,
+		WaitTimer:              &wait,
+		Reviewers:              append([]v1alpha1.EnvironmentReviewer{}, rv...),
+		DeploymentBranchPolicy: &v1alpha1.DeploymentBranchPolicy{},
+	}
+	return cr
+}
+
+func ghEnvironment(wait int, rv ...v1alpha1.EnvironmentReviewer) *repositories.Environment {
+	e := &repositories.Environment{ID: github.Int64(1), Name: github.String("production")}
+	if wait > 0 {
+		e.ProtectionRules = append(e.ProtectionRules, &repositories.ProtectionRule{Type: "wait_timer", WaitTimer: &wait})
+	}
+	if len(rv) > 0 {
+		r := &repositories.ProtectionRule{Type: "required_reviewers"}
+		for _, v := range rv {
+			gr := &repositories.Reviewer{Type: v.Type}
+			gr.Reviewer.ID = v.ID
+			r.Reviewers = append(r.Reviewers, gr)
+		}
+		e.ProtectionRules = append(e.ProtectionRules, r)
+	}
+	return e
+}
+
+func TestEnvironmentObserve(t *testing.T) {
+	user := v1alpha1.EnvironmentReviewer{Type: "User", ID: 1}
+	team := v1alpha1.EnvironmentReviewer{Type: "Team", ID: 2}
+	get := func(env *repositories.Environment) func(context.Context, string, string, string) (*repositories.Environment, *github.Response, error) {
+		return func(_ context.Context, owner, repo, name string) (*repositories.Environment, *github.Response, error) {
+			if owner != hookOwner || repo != hookRepo || name != "production" {
+				t.Errorf("GetEnvironment(...): unexpected environment %s/%s/%s", owner, repo, name)
+			}
+			return env, nil, nil
+		}
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fake.MockEnvironmentService
+		cr     *v1alpha1.Environment
+		want   want
+	}{
+		"NotFound": {
+			reason: "An environment GitHub does not know about should not exist.",
+			svc: &fake.MockEnvironmentService{MockGetEnvironment: func(_ context.Context, _, _, _ string) (*repositories.Environment, *github.Response, error) {
+				return nil, nil, notFound()
+			}},
+			cr:   envResource(0),
+			want: want{o: managed.ExternalObservation{}},
+		},
+		"GetError": {
+			reason: "Errors getting the environment should be returned.",
+			svc: &fake.MockEnvironmentService{MockGetEnvironment: func(_ context.Context, _, _, _ string) (*repositories.Environment, *github.Response, error) {
+				return nil, nil, errBoom
+			}},
+			cr:   envResource(0),
+			want: want{err: errors.Wrap(errBoom, errGetEnvironment)},
+		},
+		"UpToDate": {
+			reason: "An environment with the desired wait timer and reviewers should be up to date.",
+			svc:    &fake.MockEnvironmentService{MockGetEnvironment: get(ghEnvironment(30, user, team))},
+			cr:     envResource(30, team, user),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"WaitTimerChanged": {
+			reason: "An environment whose wait timer was changed should not be up to date.",
+			svc:    &fake.MockEnvironmentService{MockGetEnvironment: get(ghEnvironment(10, user))},
+			cr:     envResource(30, user),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"ReviewerChanged": {
+			reason: "An environment whose reviewers were changed should not be up to date.",
+			svc:    &fake.MockEnvironmentService{MockGetEnvironment: get(ghEnvironment(30, team))},
+			cr:     envResource(30, user),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &environmentExternal{client: tc.svc, logger: logging.NewNopLogger()}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEnvironmentUpdate(t *testing.T) {
+	cr := envResource(30, v1alpha1.EnvironmentReviewer{Type: "Team", ID: 2})
+	e := &environmentExternal{
+		client: &fake.MockEnvironmentService{MockCreateUpdateEnvironment: func(_ context.Context, _, _, name string, env *repositories.CreateUpdateEnvironment) (*repositories.Environment, *github.Response, error) {
+			want := &repositories.CreateUpdateEnvironment{
+				WaitTimer: github.Int(30),
+				Reviewers: []repositories.EnvReviewer{{Type: "Team", ID: 2}},
+			}
+			if diff := cmp.Diff(want, env); name != "production" || diff != "" {
+				t.Errorf("CreateUpdateEnvironment(...): unexpected update of %q: -want, +got:\n%s", name, diff)
+			}
+			return ghEnvironment(30), nil, nil
+		}},
+		logger: logging.NewNopLogger(),
+	}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update(...): %v", err)
+	}
+}
+
+func TestEnvironmentDelete(t *testing.T) {
+	e := &environmentExternal{
+		client: &fake.MockEnvironmentService{MockDeleteEnvironment: func(_ context.Context, _, _, _ string) (*github.Response, error) {
+			return nil, notFound()
+		}},
+		logger: logging.NewNopLogger(),
+	}
+	if err := e.Delete(context.Background(), envResource(0)); err != nil {
+		t.Errorf("Delete(...): an environment that no longer exists should be deleted, got %v", err)
+	}
+}
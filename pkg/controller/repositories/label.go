@@ -0,0 +1,182 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errNotLabel    = "The managed resource is not a Label resource"
+	errGetLabel    = "cannot get label"
+	errCreateLabel = "cannot create label"
+	errEditLabel   = "cannot edit label"
+	errDeleteLabel = "cannot delete label"
+)
+
+// SetupLabel adds a controller that reconciles Labels.
+func SetupLabel(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.LabelGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.LabelGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.Label{}).
+		Complete(reconciler.New(mgr, of, &labelConnector{client: mgr.GetClient(), newServiceFn: repositories.NewLabelService, logger: logger}, logger, recorder))
+}
+
+type labelConnector struct {
+	client       client.Client
+	newServiceFn func(*ghclient.Config) (repositories.LabelService, error)
+	logger       logging.Logger
+}
+
+func (c *labelConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Label)
+	if !ok {
+		return nil, errors.New(errNotLabel)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &labelExternal{svc, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type labelExternal struct {
+	client repositories.LabelService
+	logger logging.Logger
+}
+
+func (e *labelExternal) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.Label)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotLabel)
+	}
+
+	p := cr.Spec.ForProvider
+	l, _, err := e.client.GetLabel(ctx, p.Owner, p.Repository, url.PathEscape(p.Name))
+	if observed := cr.Status.AtProvider.Name; ghclient.IsNotFound(err) && observed != "" && observed != p.Name {
+		// The name was changed, so the label still has its previous name
+		// until it is renamed.
+		l, _, err = e.client.GetLabel(ctx, p.Owner, p.Repository, url.PathEscape(observed))
+	}
+	if ghclient.IsNotFound(err) {
+		e.logger.Debug("Label does not exist", "name", p.Name)
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, ghclient.Wrap(err, errGetLabel)
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	repositories.LateInitializeLabel(&cr.Spec.ForProvider, l)
+	cr.Status.AtProvider = repositories.GenerateLabelObservation(l)
+	cr.SetConditions(xpv1.Available())
+
+	upToDate := repositories.IsLabelUpToDate(cr.Spec.ForProvider, l)
+	if !upToDate {
+		e.logger.Debug("Label is not up to date", "name", l.GetName())
+	}
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+	}, nil
+}
+
+func (e *labelExternal) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.Label)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotLabel)
+	}
+
+	p := cr.Spec.ForProvider
+	l, err := repositories.GenerateLabel(p)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	_, _, err = e.client.CreateLabel(ctx, p.Owner, p.Repository, l)
+	return managed.ExternalCreation{}, ghclient.Wrap(err, errCreateLabel)
+}
+
+func (e *labelExternal) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.Label)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotLabel)
+	}
+
+	p := cr.Spec.ForProvider
+	l, err := repositories.GenerateLabel(p)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	_, _, err = e.client.EditLabel(ctx, p.Owner, p.Repository, url.PathEscape(labelName(cr)), l)
+	return managed.ExternalUpdate{}, ghclient.Wrap(err, errEditLabel)
+}
+
+func (e *labelExternal) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.Label)
+	if !ok {
+		return errors.New(errNotLabel)
+	}
+
+	p := cr.Spec.ForProvider
+	_, err := e.client.DeleteLabel(ctx, p.Owner, p.Repository, url.PathEscape(labelName(cr)))
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	return ghclient.Wrap(err, errDeleteLabel)
+}
+
+// labelName returns the name the supplied label was last observed with at
+// GitHub, or its desired name if it has not been observed.
+func labelName(cr *v1alpha1.Label) string {
+	if n := cr.Status.AtProvider.Name; n != "" {
+		return n
+	}
+	return cr.Spec.ForProvider.Name
+}
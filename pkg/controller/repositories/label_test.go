@@ -0,0 +1,249 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories/fake"
+)
+
+const labelColor = "d73a4a"
+
+func label(name string, o ...func(*v1alpha1.Label)) *v1alpha1.Label {
+	cr := &v1alpha1.Label{}
+	cr.SetName("label")
+	cr.Spec.ForProvider = v1alpha1.LabelParameters{
+		Owner:      hookOwner,
+		Repository: hookRepo,
+		Name:       name,
+		Color:      github.String(labelColor),
+	}
+	for _, fn := range o {
+		fn(cr)
+	}
+	return cr
+}
+
+// observedAs sets the name the label was last observed with at GitHub.
+func observedAs(name string) func(*v1alpha1.Label) {
+	return func(cr *v1alpha1.Label) { cr.Status.AtProvider.Name = name }
+}
+
+// labels returns a LabelService that serves labels with the supplied names,
+// looked up by their path escaped names.
+func labels(names ...string) *fake.MockLabelService {
+	return &fake.MockLabelService{MockGetLabel: func(_ context.Context, _, _, name string) (*github.Label, *github.Response, error) {
+		for _, n := range names {
+			if name == url.PathEscape(n) {
+				return &github.Label{ID: github.Int64(1), Name: github.String(n), Color: github.String(labelColor)}, nil, nil
+			}
+		}
+		return nil, nil, notFound()
+	}}
+}
+
+func TestLabelObserve(t *testing.T) {
+	type want struct {
+		o        managed.ExternalObservation
+		observed string
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fake.MockLabelService
+		cr     *v1alpha1.Label
+		want   want
+	}{
+		"NotFound": {
+			reason: "A label GitHub does not know about should not exist.",
+			svc:    labels(),
+			cr:     label("bug"),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"GetError": {
+			reason: "Errors getting the label should be returned.",
+			svc: &fake.MockLabelService{MockGetLabel: func(_ context.Context, _, _, _ string) (*github.Label, *github.Response, error) {
+				return nil, nil, errBoom
+			}},
+			cr:   label("bug"),
+			want: want{err: errors.Wrap(errBoom, errGetLabel)},
+		},
+		"UpToDate": {
+			reason: "A label matching its parameters should be up to date.",
+			svc:    labels("bug"),
+			cr:     label("bug"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, observed: "bug"},
+		},
+		"EscapedName": {
+			reason: "A label name should be path escaped when it is looked up.",
+			svc:    labels("good first issue"),
+			cr:     label("good first issue"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, observed: "good first issue"},
+		},
+		"LateInitialized": {
+			reason: "Unset parameters should be late initialized from the label.",
+			svc:    labels("bug"),
+			cr:     label("bug", func(cr *v1alpha1.Label) { cr.Spec.ForProvider.Color = nil }),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true}, observed: "bug"},
+		},
+		"Renamed": {
+			reason: "A label whose name changed should be found by its previous name, and need renaming.",
+			svc:    labels("bug"),
+			cr:     label("defect", observedAs("bug")),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}, observed: "bug"},
+		},
+		"RenamedNotFound": {
+			reason: "A renamed label that no longer exists under either name should not exist.",
+			svc:    labels(),
+			cr:     label("defect", observedAs("bug")),
+			want:   want{o: managed.ExternalObservation{}, observed: "bug"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &labelExternal{client: tc.svc, logger: logging.NewNopLogger()}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.observed, tc.cr.Status.AtProvider.Name); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want observed name, +got observed name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLabelUpdate(t *testing.T) {
+	type want struct {
+		name  string
+		label *github.Label
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Label
+		err    error
+		want   want
+	}{
+		"Edited": {
+			reason: "A label should be edited under its desired name if it has not been observed.",
+			cr:     label("bug"),
+			want:   want{name: "bug", label: &github.Label{Name: github.String("bug"), Color: github.String(labelColor)}},
+		},
+		"Renamed": {
+			reason: "A renamed label should be edited under the name it was observed with.",
+			cr:     label("defect", observedAs("good first issue")),
+			want:   want{name: "good%20first%20issue", label: &github.Label{Name: github.String("defect"), Color: github.String(labelColor)}},
+		},
+		"InvalidColor": {
+			reason: "A label with an invalid color should not be edited.",
+			cr:     label("bug", func(cr *v1alpha1.Label) { cr.Spec.ForProvider.Color = github.String("red") }),
+			want:   want{err: errors.New(`invalid color "red": must be a 3 or 6 digit hexadecimal value, optionally prefixed with #`)},
+		},
+		"EditError": {
+			reason: "Errors editing the label should be returned.",
+			cr:     label("bug"),
+			err:    errBoom,
+			want:   want{name: "bug", label: &github.Label{Name: github.String("bug"), Color: github.String(labelColor)}, err: errors.Wrap(errBoom, errEditLabel)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var gotName string
+			var gotLabel *github.Label
+			e := &labelExternal{
+				client: &fake.MockLabelService{MockEditLabel: func(_ context.Context, _, _, name string, l *github.Label) (*github.Label, *github.Response, error) {
+					gotName, gotLabel = name, l
+					return l, nil, tc.err
+				}},
+				logger: logging.NewNopLogger(),
+			}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.name, gotName); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want name, +got name:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.label, gotLabel); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want label, +got label:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLabelDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.Label
+		err    error
+		want   error
+	}{
+		"Deleted": {
+			reason: "A label should be deleted under the name it was observed with.",
+			cr:     label("defect", observedAs("bug")),
+		},
+		"AlreadyDeleted": {
+			reason: "A label that was already deleted should be considered deleted.",
+			cr:     label("bug", observedAs("bug")),
+			err:    notFound(),
+		},
+		"Error": {
+			reason: "Errors deleting the label should be returned.",
+			cr:     label("bug", observedAs("bug")),
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errDeleteLabel),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &labelExternal{
+				client: &fake.MockLabelService{MockDeleteLabel: func(_ context.Context, _, _, name string) (*github.Response, error) {
+					if name != "bug" {
+						t.Errorf("DeleteLabel(...): want label bug, got %s", name)
+					}
+					return nil, tc.err
+				}},
+				logger: logging.NewNopLogger(),
+			}
+			err := e.Delete(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errNotRequiredStatusChecks    = "The managed resource is not a RequiredStatusChecks resource"
+	errGetRequiredStatusChecks    = "cannot get required status checks"
+	errUpdateRequiredStatusChecks = "cannot update required status checks"
+	errRemoveRequiredStatusChecks = "cannot remove required status checks"
+	errGetProtection              = "cannot get branch protection"
+	errEnableRequiredStatusChecks = "cannot protect branch with required status checks"
+)
+
+// SetupRequiredStatusChecks adds a controller that reconciles
+// RequiredStatusChecks.
+func SetupRequiredStatusChecks(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.RequiredStatusChecksGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.RequiredStatusChecksGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.RequiredStatusChecks{}).
+		Complete(reconciler.New(mgr, of, &statusChecksConnector{client: mgr.GetClient(), newServiceFn: repositories.NewRequiredStatusChecksService, logger: logger}, logger, recorder))
+}
+
+type statusChecksConnector struct {
+	client       client.Client
+	newServiceFn func(*ghclient.Config) (repositories.RequiredStatusChecksService, error)
+	logger       logging.Logger
+}
+
+func (c *statusChecksConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RequiredStatusChecks)
+	if !ok {
+		return nil, errors.New(errNotRequiredStatusChecks)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &statusChecksExternal{svc, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type statusChecksExternal struct {
+	client repositories.RequiredStatusChecksService
+	logger logging.Logger
+}
+
+func (e *statusChecksExternal) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.RequiredStatusChecks)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRequiredStatusChecks)
+	}
+
+	p := cr.Spec.ForProvider
+	checks, _, err := e.client.GetRequiredStatusChecks(ctx, p.Owner, p.Repository, p.Branch)
+	if ghclient.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, ghclient.Wrap(err, errGetRequiredStatusChecks)
+	}
+
+	cr.Status.AtProvider = repositories.GenerateRequiredStatusChecksObservation(checks)
+	cr.SetConditions(xpv1.Available())
+
+	upToDate := repositories.IsRequiredStatusChecksUpToDate(p, checks)
+	e.logger.Debug("Observed required status checks", "upToDate", upToDate, "contexts", checks.Contexts, "strict", checks.Strict)
+
+	return managed.ExternalObservation{
+		ResourceUpToDate: upToDate,
+		ResourceExists:   true,
+	}, nil
+}
+
+func (e *statusChecksExternal) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.RequiredStatusChecks)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRequiredStatusChecks)
+	}
+
+	p := cr.Spec.ForProvider
+	err := e.update(ctx, p)
+	if !ghclient.IsNotFound(err) {
+		return managed.ExternalCreation{}, err
+	}
+
+	// GitHub can only update the required status checks of a branch that is
+	// already protected with them, so enable them through the branch's
+	// protection instead, keeping the rest of it as it is.
+	pr, _, err := e.client.GetBranchProtection(ctx, p.Owner, p.Repository, p.Branch)
+	if err != nil && !ghclient.IsNotFound(err) {
+		return managed.ExternalCreation{}, ghclient.Wrap(err, errGetProtection)
+	}
+	_, _, err = e.client.UpdateBranchProtection(ctx, p.Owner, p.Repository, p.Branch, repositories.GenerateProtectionRequestWithStatusChecks(pr, p))
+	return managed.ExternalCreation{}, ghclient.Wrap(err, errEnableRequiredStatusChecks)
+}
+
+func (e *statusChecksExternal) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.RequiredStatusChecks)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRequiredStatusChecks)
+	}
+
+	return managed.ExternalUpdate{}, e.update(ctx, cr.Spec.ForProvider)
+}
+
+func (e *statusChecksExternal) update(ctx context.Context, p v1alpha1.RequiredStatusChecksParameters) error {
+	_, _, err := e.client.UpdateRequiredStatusChecks(ctx, p.Owner, p.Repository, p.Branch, repositories.GenerateRequiredStatusChecksRequest(p))
+	return ghclient.Wrap(err, errUpdateRequiredStatusChecks)
+}
+
+func (e *statusChecksExternal) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.RequiredStatusChecks)
+	if !ok {
+		return errors.New(errNotRequiredStatusChecks)
+	}
+
+	p := cr.Spec.ForProvider
+	_, err := e.client.RemoveRequiredStatusChecks(ctx, p.Owner, p.Repository, p.Branch)
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	return ghclient.Wrap(err, errRemoveRequiredStatusChecks)
+}
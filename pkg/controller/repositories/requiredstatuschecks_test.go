@@ -0,0 +1,237 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories/fake"
+)
+
+func requiredStatusChecks(contexts ...string) *v1alpha1.RequiredStatusChecks {
+	cr := &v1alpha1.RequiredStatusChecks{}
+	cr.SetName("main")
+	cr.Spec.ForProvider = v1alpha1.RequiredStatusChecksParameters{
+		Owner:      "crossplane",
+		Repository: "provider-github",
+		Branch:     "main",
+		Contexts:   contexts,
+	}
+	return cr
+}
+
+func TestRequiredStatusChecksObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		checks *github.RequiredStatusChecks
+		err    error
+		cr     *v1alpha1.RequiredStatusChecks
+		want   want
+	}{
+		"NotRequired": {
+			reason: "Status checks that are not required should not exist.",
+			err:    notFound(),
+			cr:     requiredStatusChecks("build"),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"GetError": {
+			reason: "Errors getting the status checks should be returned.",
+			err:    errBoom,
+			cr:     requiredStatusChecks("build"),
+			want:   want{err: errors.Wrap(errBoom, errGetRequiredStatusChecks)},
+		},
+		"UpToDate": {
+			reason: "The same contexts in a different order should be up to date.",
+			checks: &github.RequiredStatusChecks{Contexts: []string{"test", "build"}},
+			cr:     requiredStatusChecks("build", "test"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"ContextAdded": {
+			reason: "A context that should be required should cause drift.",
+			checks: &github.RequiredStatusChecks{Contexts: []string{"build"}},
+			cr:     requiredStatusChecks("build", "test"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"ContextRemoved": {
+			reason: "A context that should no longer be required should cause drift.",
+			checks: &github.RequiredStatusChecks{Contexts: []string{"build", "test"}},
+			cr:     requiredStatusChecks("build"),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &statusChecksExternal{
+				client: &fake.MockRequiredStatusChecksService{MockGetRequiredStatusChecks: func(_ context.Context, _, _, _ string) (*github.RequiredStatusChecks, *github.Response, error) {
+					return tc.checks, nil, tc.err
+				}},
+				logger: logging.NewNopLogger(),
+			}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRequiredStatusChecksCreate(t *testing.T) {
+	patchNotFound := func(_ context.Context, _, _, _ string, _ *github.RequiredStatusChecksRequest) (*github.RequiredStatusChecks, *github.Response, error) {
+		return nil, nil, notFound()
+	}
+
+	type want struct {
+		req *github.ProtectionRequest
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fake.MockRequiredStatusChecksService
+		want   want
+	}{
+		"Updated": {
+			reason: "The status checks of a branch that already requires some should be updated in place.",
+			svc: &fake.MockRequiredStatusChecksService{
+				MockUpdateRequiredStatusChecks: func(_ context.Context, _, _, _ string, r *github.RequiredStatusChecksRequest) (*github.RequiredStatusChecks, *github.Response, error) {
+					return nil, nil, nil
+				},
+			},
+		},
+		"UpdateError": {
+			reason: "Errors other than not found updating the status checks should be returned.",
+			svc: &fake.MockRequiredStatusChecksService{
+				MockUpdateRequiredStatusChecks: func(_ context.Context, _, _, _ string, _ *github.RequiredStatusChecksRequest) (*github.RequiredStatusChecks, *github.Response, error) {
+					return nil, nil, errBoom
+				},
+			},
+			want: want{err: errors.Wrap(errBoom, errUpdateRequiredStatusChecks)},
+		},
+		"Unprotected": {
+			reason: "An unprotected branch should be protected with only the status checks.",
+			svc: &fake.MockRequiredStatusChecksService{
+				MockUpdateRequiredStatusChecks: patchNotFound,
+				MockGetBranchProtection: func(_ context.Context, _, _, _ string) (*github.Protection, *github.Response, error) {
+					return nil, nil, notFound()
+				},
+			},
+			want: want{req: &github.ProtectionRequest{
+				RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: []string{"build"}},
+			}},
+		},
+		"ProtectedWithoutChecks": {
+			reason: "The rest of the protection of a branch protected without status checks should be kept.",
+			svc: &fake.MockRequiredStatusChecksService{
+				MockUpdateRequiredStatusChecks: patchNotFound,
+				MockGetBranchProtection: func(_ context.Context, _, _, _ string) (*github.Protection, *github.Response, error) {
+					return &github.Protection{
+						RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{RequiredApprovingReviewCount: 2},
+						EnforceAdmins:              &github.AdminEnforcement{Enabled: true},
+					}, nil, nil
+				},
+			},
+			want: want{req: &github.ProtectionRequest{
+				RequiredStatusChecks:       &github.RequiredStatusChecks{Contexts: []string{"build"}},
+				RequiredPullRequestReviews: &github.PullRequestReviewsEnforcementRequest{RequiredApprovingReviewCount: 2},
+				EnforceAdmins:              true,
+			}},
+		},
+		"GetProtectionError": {
+			reason: "Errors getting the branch's protection should be returned.",
+			svc: &fake.MockRequiredStatusChecksService{
+				MockUpdateRequiredStatusChecks: patchNotFound,
+				MockGetBranchProtection: func(_ context.Context, _, _, _ string) (*github.Protection, *github.Response, error) {
+					return nil, nil, errBoom
+				},
+			},
+			want: want{err: errors.Wrap(errBoom, errGetProtection)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *github.ProtectionRequest
+			tc.svc.MockUpdateBranchProtection = func(_ context.Context, _, _, _ string, r *github.ProtectionRequest) (*github.Protection, *github.Response, error) {
+				got = r
+				return nil, nil, nil
+			}
+			e := &statusChecksExternal{client: tc.svc, logger: logging.NewNopLogger()}
+			_, err := e.Create(context.Background(), requiredStatusChecks("build"))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.req, got); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want protection request, +got protection request:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRequiredStatusChecksDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   error
+	}{
+		"Removed": {
+			reason: "Removing the status checks should succeed.",
+		},
+		"NotFound": {
+			reason: "Status checks that are already gone should not be an error.",
+			err:    notFound(),
+		},
+		"RemoveError": {
+			reason: "Errors removing the status checks should be returned.",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errRemoveRequiredStatusChecks),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &statusChecksExternal{
+				client: &fake.MockRequiredStatusChecksService{MockRemoveRequiredStatusChecks: func(_ context.Context, _, _, _ string) (*github.Response, error) {
+					return nil, tc.err
+				}},
+				logger: logging.NewNopLogger(),
+			}
+			err := e.Delete(context.Background(), requiredStatusChecks("build"))
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
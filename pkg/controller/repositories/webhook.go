@@ -0,0 +1,249 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errNotWebhook       = "The managed resource is not a Webhook resource"
+	errParseHookID      = "cannot parse external name as a webhook ID"
+	errGetHook          = "cannot get webhook"
+	errCreateHook       = "cannot create webhook"
+	errEditHook         = "cannot edit webhook"
+	errDeleteHook       = "cannot delete webhook"
+	errGetWebhookSecret = "cannot get webhook secret"
+	errFmtNoSecretKey   = "webhook secret has no key %q"
+)
+
+// SetupWebhook adds a controller that reconciles Webhooks.
+func SetupWebhook(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.WebhookGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.WebhookGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.Webhook{}).
+		Complete(reconciler.New(mgr, of, &webhookConnector{client: mgr.GetClient(), newServiceFn: repositories.NewWebhookService, logger: logger}, logger, recorder))
+}
+
+type webhookConnector struct {
+	client       client.Client
+	newServiceFn func(*ghclient.Config) (repositories.WebhookService, error)
+	logger       logging.Logger
+}
+
+func (c *webhookConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Webhook)
+	if !ok {
+		return nil, errors.New(errNotWebhook)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &webhookExternal{svc, c.client, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type webhookExternal struct {
+	client repositories.WebhookService
+	kube   client.Client
+	logger logging.Logger
+}
+
+func (e *webhookExternal) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.Webhook)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotWebhook)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errParseHookID)
+	}
+
+	p := cr.Spec.ForProvider
+	h, _, err := e.client.GetHook(ctx, p.Owner, p.Repository, id)
+	if ghclient.IsNotFound(err) {
+		e.logger.Debug("Webhook does not exist", "id", id)
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, ghclient.Wrap(err, errGetHook)
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	repositories.LateInitializeWebhook(&cr.Spec.ForProvider, h)
+	secret, err := e.secret(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	obs := repositories.GenerateWebhookObservation(h)
+	obs.SecretHash = cr.Status.AtProvider.SecretHash
+	if obs.SecretHash == "" && secret != nil && repositories.WebhookHasSecret(h) {
+		// The hash recorded by Create does not survive the managed reconciler
+		// persisting the webhook's new external name, so the secret GitHub
+		// reports is assumed to be the one that was written.
+		obs.SecretHash = secretHash(secret)
+	}
+	cr.Status.AtProvider = obs
+	cr.SetConditions(xpv1.Available())
+
+	upToDate := repositories.IsWebhookUpToDate(cr.Spec.ForProvider, h) && secretMatches(obs.SecretHash, secret)
+	if !upToDate {
+		e.logger.Debug("Webhook is not up to date", "id", id)
+	}
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+	}, nil
+}
+
+func (e *webhookExternal) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.Webhook)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotWebhook)
+	}
+
+	p := cr.Spec.ForProvider
+	secret, err := e.secret(ctx, p)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	h, _, err := e.client.CreateHook(ctx, p.Owner, p.Repository, repositories.GenerateHook(p, secret))
+	if err != nil {
+		return managed.ExternalCreation{}, ghclient.Wrap(err, errCreateHook)
+	}
+
+	meta.SetExternalName(cr, strconv.FormatInt(h.GetID(), 10))
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *webhookExternal) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.Webhook)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotWebhook)
+	}
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errParseHookID)
+	}
+	p := cr.Spec.ForProvider
+	secret, err := e.secret(ctx, p)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if _, _, err := e.client.EditHook(ctx, p.Owner, p.Repository, id, repositories.GenerateHook(p, secret)); err != nil {
+		return managed.ExternalUpdate{}, ghclient.Wrap(err, errEditHook)
+	}
+
+	cr.Status.AtProvider.SecretHash = secretHash(secret)
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *webhookExternal) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.Webhook)
+	if !ok {
+		return errors.New(errNotWebhook)
+	}
+
+	id, err := strconv.ParseInt(meta.GetExternalName(cr), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errParseHookID)
+	}
+	p := cr.Spec.ForProvider
+	_, err = e.client.DeleteHook(ctx, p.Owner, p.Repository, id)
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	return ghclient.Wrap(err, errDeleteHook)
+}
+
+// secret returns the webhook secret referenced by the supplied parameters,
+// or nil if none is referenced.
+func (e *webhookExternal) secret(ctx context.Context, p v1alpha1.WebhookParameters) (*string, error) {
+	ref := p.SecretRef
+	if ref == nil {
+		return nil, nil
+	}
+	s := &corev1.Secret{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetWebhookSecret)
+	}
+	v, ok := s.Data[ref.Key]
+	if !ok {
+		return nil, errors.Errorf(errFmtNoSecretKey, ref.Key)
+	}
+	secret := string(v)
+	return &secret, nil
+}
+
+// secretHash returns the versioned hash of the supplied secret, or an empty
+// string if there is no secret.
+func secretHash(secret *string) string {
+	if secret == nil {
+		return ""
+	}
+	return ghclient.VersionedHash([]byte(*secret))
+}
+
+// secretMatches returns true if the supplied recorded hash, which may be a
+// legacy unversioned hash, is the hash of the supplied secret.
+func secretMatches(recorded string, secret *string) bool {
+	if secret == nil {
+		return recorded == ""
+	}
+	return ghclient.HashMatches(recorded, []byte(*secret))
+}
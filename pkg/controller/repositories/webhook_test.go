@@ -0,0 +1,260 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repositories
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/repositories/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/repositories/fake"
+)
+
+var errBoom = errors.New("boom")
+
+// notFound returns the error go-github returns for a 404 response.
+func notFound() error {
+	return &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+}
+
+// withSecretData returns a kube client that returns a Secret with the
+// supplied data.
+func withSecretData(data map[string][]byte) client.Client {
+	return &test.MockClient{MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+		o.(*corev1.Secret).Data = data
+		return nil
+	})}
+}
+
+const (
+	hookOwner = "crossplane"
+	hookRepo  = "provider-github"
+	hookURL   = "https://example.org/hook"
+	hookID    = int64(42)
+)
+
+type webhookModifier func(*v1alpha1.Webhook)
+
+func withHookEvents(e ...string) webhookModifier {
+	return func(cr *v1alpha1.Webhook) { cr.Spec.ForProvider.Events = e }
+}
+
+func withHookActive(a bool) webhookModifier {
+	return func(cr *v1alpha1.Webhook) { cr.Spec.ForProvider.Active = &a }
+}
+
+func withHookSecretRef() webhookModifier {
+	return func(cr *v1alpha1.Webhook) {
+		cr.Spec.ForProvider.SecretRef = &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "hook", Namespace: "default"},
+			Key:             "secret",
+		}
+	}
+}
+
+func withHookSecretHash(h string) webhookModifier {
+	return func(cr *v1alpha1.Webhook) { cr.Status.AtProvider.SecretHash = h }
+}
+
+func webhook(m ...webhookModifier) *v1alpha1.Webhook {
+	cr := &v1alpha1.Webhook{}
+	cr.SetName("hook")
+	meta.SetExternalName(cr, "42")
+	cr.Spec.ForProvider = v1alpha1.WebhookParameters{
+		Owner:      hookOwner,
+		Repository: hookRepo,
+		URL:        hookURL,
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func hook(active bool, secret bool, events ...string) *github.Hook {
+	cfg := map[string]interface{}{
+		"url":          hookURL,
+		"content_type": "json",
+		"insecure_ssl": "0",
+	}
+	if secret {
+		cfg["secret"] = "********"
+	}
+	return &github.Hook{ID: github.Int64(hookID), Active: &active, Events: events, Config: cfg}
+}
+
+func TestWebhookObserve(t *testing.T) {
+	getHook := func(h *github.Hook) func(context.Context, string, string, int64) (*github.Hook, *github.Response, error) {
+		return func(_ context.Context, _, _ string, id int64) (*github.Hook, *github.Response, error) {
+			if id != hookID {
+				t.Errorf("GetHook(...): want ID %d, got %d", hookID, id)
+			}
+			return h, nil, nil
+		}
+	}
+	secret := map[string][]byte{"secret": []byte("s3cret")}
+
+	type want struct {
+		o    managed.ExternalObservation
+		hash string
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fake.MockWebhookService
+		kube   client.Client
+		cr     *v1alpha1.Webhook
+		want   want
+	}{
+		"NotFound": {
+			reason: "A webhook GitHub does not know about should not exist.",
+			svc: &fake.MockWebhookService{MockGetHook: func(_ context.Context, _, _ string, _ int64) (*github.Hook, *github.Response, error) {
+				return nil, nil, notFound()
+			}},
+			cr:   webhook(),
+			want: want{o: managed.ExternalObservation{}},
+		},
+		"GetError": {
+			reason: "Errors getting the webhook should be returned.",
+			svc: &fake.MockWebhookService{MockGetHook: func(_ context.Context, _, _ string, _ int64) (*github.Hook, *github.Response, error) {
+				return nil, nil, errBoom
+			}},
+			cr:   webhook(),
+			want: want{err: errors.Wrap(errBoom, errGetHook)},
+		},
+		"EventsReordered": {
+			reason: "Events should be compared regardless of their order.",
+			svc:    &fake.MockWebhookService{MockGetHook: getHook(hook(true, false, "push", "pull_request"))},
+			cr:     webhook(withHookActive(true), withHookEvents("pull_request", "push")),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true}},
+		},
+		"EventAdded": {
+			reason: "A webhook missing an event should not be up to date.",
+			svc:    &fake.MockWebhookService{MockGetHook: getHook(hook(true, false, "push"))},
+			cr:     webhook(withHookActive(true), withHookEvents("push", "pull_request")),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceLateInitialized: true}},
+		},
+		"Deactivated": {
+			reason: "An active webhook that should be inactive should not be up to date.",
+			svc:    &fake.MockWebhookService{MockGetHook: getHook(hook(true, false, "push"))},
+			cr:     webhook(withHookActive(false), withHookEvents("push")),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceLateInitialized: true}},
+		},
+		"CreatedWithSecret": {
+			reason: "The hash of the secret of a webhook first observed after it was created should be recorded.",
+			svc:    &fake.MockWebhookService{MockGetHook: getHook(hook(true, true, "push"))},
+			kube:   withSecretData(secret),
+			cr:     webhook(withHookActive(true), withHookEvents("push"), withHookSecretRef()),
+			want: want{
+				o:    managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				hash: ghclient.VersionedHash([]byte("s3cret")),
+			},
+		},
+		"SecretChanged": {
+			reason: "A webhook whose secret changed since it was applied should not be up to date.",
+			svc:    &fake.MockWebhookService{MockGetHook: getHook(hook(true, true, "push"))},
+			kube:   withSecretData(secret),
+			cr:     webhook(withHookActive(true), withHookEvents("push"), withHookSecretRef(), withHookSecretHash(ghclient.VersionedHash([]byte("old")))),
+			want: want{
+				o:    managed.ExternalObservation{ResourceExists: true, ResourceLateInitialized: true},
+				hash: ghclient.VersionedHash([]byte("old")),
+			},
+		},
+		"LegacySecretHash": {
+			reason: "A legacy unversioned hash of the current secret should match.",
+			svc:    &fake.MockWebhookService{MockGetHook: getHook(hook(true, true, "push"))},
+			kube:   withSecretData(secret),
+			cr:     webhook(withHookActive(true), withHookEvents("push"), withHookSecretRef(), withHookSecretHash(ghclient.Hash([]byte("s3cret")))),
+			want: want{
+				o:    managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				hash: ghclient.Hash([]byte("s3cret")),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &webhookExternal{client: tc.svc, kube: tc.kube, logger: logging.NewNopLogger()}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.hash, tc.cr.Status.AtProvider.SecretHash); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want secret hash, +got secret hash:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWebhookCreate(t *testing.T) {
+	cr := webhook(withHookEvents("push"))
+	meta.SetExternalName(cr, "")
+	e := &webhookExternal{
+		client: &fake.MockWebhookService{MockCreateHook: func(_ context.Context, _, _ string, h *github.Hook) (*github.Hook, *github.Response, error) {
+			if diff := cmp.Diff([]string{"push"}, h.Events); diff != "" {
+				t.Errorf("CreateHook(...): -want events, +got events:\n%s", diff)
+			}
+			return &github.Hook{ID: github.Int64(hookID)}, nil, nil
+		}},
+		logger: logging.NewNopLogger(),
+	}
+	c, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Create(...): %v", err)
+	}
+	if !c.ExternalNameAssigned || meta.GetExternalName(cr) != "42" {
+		t.Errorf("Create(...): want external name 42 assigned, got %q", meta.GetExternalName(cr))
+	}
+}
+
+func TestWebhookUpdate(t *testing.T) {
+	cr := webhook(withHookActive(false), withHookEvents("push", "issues"), withHookSecretRef())
+	e := &webhookExternal{
+		client: &fake.MockWebhookService{MockEditHook: func(_ context.Context, _, _ string, id int64, h *github.Hook) (*github.Hook, *github.Response, error) {
+			if id != hookID || h.GetActive() || h.Config["secret"] != "s3cret" {
+				t.Errorf("EditHook(...): unexpected webhook %d: %+v", id, h)
+			}
+			return h, nil, nil
+		}},
+		kube:   withSecretData(map[string][]byte{"secret": []byte("s3cret")}),
+		logger: logging.NewNopLogger(),
+	}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update(...): %v", err)
+	}
+	if want := ghclient.VersionedHash([]byte("s3cret")); cr.Status.AtProvider.SecretHash != want {
+		t.Errorf("Update(...): want secret hash %q, got %q", want, cr.Status.AtProvider.SecretHash)
+	}
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/secrets/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/secrets"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errNotEnvSecret    = "The managed resource is not an EnvironmentSecret resource"
+	errGetRepositoryID = "cannot get repository ID"
+	errGetEnvSecret    = "cannot get environment secret"
+	errGetEnvPublicKey = "cannot get environment public key"
+	errCreateEnvSecret = "cannot create or update environment secret"
+	errDeleteEnvSecret = "cannot delete environment secret"
+)
+
+// SetupEnvironmentSecret adds a controller that reconciles
+// EnvironmentSecrets.
+func SetupEnvironmentSecret(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.EnvironmentSecretGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.EnvironmentSecretGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.EnvironmentSecret{}).
+		Complete(reconciler.New(mgr, of, &envSecretConnector{client: mgr.GetClient(), newServiceFn: secrets.NewService, logger: logger}, logger, recorder,
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient()))))
+}
+
+type envSecretConnector struct {
+	client       client.Client
+	newServiceFn func(*ghclient.Config) (secrets.Service, error)
+	logger       logging.Logger
+}
+
+func (c *envSecretConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.EnvironmentSecret)
+	if !ok {
+		return nil, errors.New(errNotEnvSecret)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &envSecretExternal{svc, c.client, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type envSecretExternal struct {
+	client secrets.Service
+	kube   client.Client
+	logger logging.Logger
+}
+
+func (e *envSecretExternal) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.EnvironmentSecret)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotEnvSecret)
+	}
+
+	p := cr.Spec.ForProvider
+	name, err := secrets.SecretName(cr.Spec.ForProvider.Name, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	id, err := e.repositoryID(ctx, cr)
+	if ghclient.IsNotFound(err) {
+		e.logger.Debug("Repository does not exist", "repository", p.Repository)
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	s, _, err := e.client.GetEnvSecret(ctx, id, p.Environment, name)
+	if ghclient.IsNotFound(err) {
+		e.logger.Debug("Environment secret does not exist", "name", name)
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, ghclient.Wrap(err, errGetEnvSecret)
+	}
+
+	value, err := e.value(ctx, p)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.SetConditions(xpv1.Available())
+	upToDate := secrets.IsEnvSecretUpToDate(cr.Status.AtProvider, s, value)
+	if !upToDate {
+		e.logger.Debug("Environment secret is not up to date", "name", name)
+	}
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+func (e *envSecretExternal) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.EnvironmentSecret)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotEnvSecret)
+	}
+
+	return managed.ExternalCreation{}, e.write(ctx, cr)
+}
+
+func (e *envSecretExternal) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.EnvironmentSecret)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotEnvSecret)
+	}
+
+	return managed.ExternalUpdate{}, e.write(ctx, cr)
+}
+
+func (e *envSecretExternal) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.EnvironmentSecret)
+	if !ok {
+		return errors.New(errNotEnvSecret)
+	}
+
+	name, err := secrets.SecretName(cr.Spec.ForProvider.Name, meta.GetExternalName(cr))
+	if err != nil {
+		return err
+	}
+	id, err := e.repositoryID(ctx, cr)
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	_, err = e.client.DeleteEnvSecret(ctx, id, cr.Spec.ForProvider.Environment, name)
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	return ghclient.Wrap(err, errDeleteEnvSecret)
+}
+
+// write encrypts the referenced value and writes it to GitHub. It records the
+// hash of the value and the time the secret was written in the observation.
+func (e *envSecretExternal) write(ctx context.Context, cr *v1alpha1.EnvironmentSecret) error {
+	p := cr.Spec.ForProvider
+	name, err := secrets.SecretName(cr.Spec.ForProvider.Name, meta.GetExternalName(cr))
+	if err != nil {
+		return err
+	}
+	id, err := e.repositoryID(ctx, cr)
+	if err != nil {
+		return err
+	}
+	value, err := e.value(ctx, p)
+	if err != nil {
+		return err
+	}
+	key, _, err := e.client.GetEnvPublicKey(ctx, id, p.Environment)
+	if err != nil {
+		return ghclient.Wrap(err, errGetEnvPublicKey)
+	}
+	enc, err := secrets.EncryptSecret(key.GetKey(), value)
+	if err != nil {
+		return err
+	}
+	if _, err := e.client.CreateOrUpdateEnvSecret(ctx, id, p.Environment, secrets.GenerateEnvSecret(name, key.GetKeyID(), enc)); err != nil {
+		return ghclient.Wrap(err, errCreateEnvSecret)
+	}
+
+	s, _, err := e.client.GetEnvSecret(ctx, id, p.Environment, name)
+	if err != nil {
+		return ghclient.Wrap(err, errGetEnvSecret)
+	}
+	t := metav1.NewTime(s.UpdatedAt.Time)
+	cr.Status.AtProvider.EncryptValue = secrets.GenerateHash(value)
+	cr.Status.AtProvider.LastUpdate = &t
+	return nil
+}
+
+// repositoryID returns the ID of the repository the secret's environment
+// belongs to.
+func (e *envSecretExternal) repositoryID(ctx context.Context, cr *v1alpha1.EnvironmentSecret) (int64, error) {
+	p := cr.Spec.ForProvider
+	o := &cr.Status.AtProvider
+	return repositoryID(ctx, e.client, p.Owner, p.Repository, &o.RepositoryID, &o.Repository)
+}
+
+// repositoryID returns the ID of the supplied repository. The environment
+// secrets API addresses repositories by ID, so it is resolved once and
+// recorded in the supplied observed ID and repository, and resolved again
+// only if the owner or repository changes.
+func repositoryID(ctx context.Context, svc secrets.Service, owner, repo string, id *int64, resolved *string) (int64, error) {
+	full := owner + "/" + repo
+	if *id != 0 && *resolved == full {
+		return *id, nil
+	}
+	rid, _, err := svc.GetRepositoryID(ctx, owner, repo)
+	if err != nil {
+		return 0, ghclient.Wrap(err, errGetRepositoryID)
+	}
+	*id = rid
+	*resolved = full
+	return rid, nil
+}
+
+// value returns the value referenced by the supplied parameters.
+func (e *envSecretExternal) value(ctx context.Context, p v1alpha1.EnvironmentSecretParameters) (string, error) {
+	ref := p.ValueSecretRef
+	s := &corev1.Secret{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return "", errors.Wrap(err, errGetValueSecret)
+	}
+	v, ok := s.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf(errFmtNoValueKey, ref.Key)
+	}
+	return string(v), nil
+}
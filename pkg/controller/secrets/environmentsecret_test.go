@@ -0,0 +1,269 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/secrets/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/secrets"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/secrets/fake"
+)
+
+var (
+	errBoom = errors.New("boom")
+
+	secretUpdated = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// A valid NaCl public key is 32 bytes.
+	envPublicKey = &github.PublicKey{KeyID: github.String("key"), Key: github.String("MTIzNDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTI=")}
+)
+
+const (
+	secretValue = "s3cret"
+	envRepoID   = int64(42)
+)
+
+// notFound returns the error go-github returns for a 404 response.
+func notFound() error {
+	return &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+}
+
+// withValue returns a kube client that returns a Secret containing the
+// supplied value.
+func withValue(v string) client.Client {
+	return &test.MockClient{MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+		o.(*corev1.Secret).Data = map[string][]byte{"value": []byte(v)}
+		return nil
+	})}
+}
+
+// ghSecret returns a secret as GitHub returns it, last updated at the
+// supplied time.
+func ghSecret(name string, updated time.Time) *github.Secret {
+	return &github.Secret{Name: name, UpdatedAt: github.Timestamp{Time: updated}}
+}
+
+type envSecretModifier func(*v1alpha1.EnvironmentSecret)
+
+func withEnvSecretName(n string) envSecretModifier {
+	return func(cr *v1alpha1.EnvironmentSecret) { cr.Spec.ForProvider.Name = &n }
+}
+
+func withEnvRepositoryID(repo string, id int64) envSecretModifier {
+	return func(cr *v1alpha1.EnvironmentSecret) {
+		cr.Status.AtProvider.Repository = repo
+		cr.Status.AtProvider.RepositoryID = id
+	}
+}
+
+func withEnvWritten(value string, at time.Time) envSecretModifier {
+	return func(cr *v1alpha1.EnvironmentSecret) {
+		t := metav1.NewTime(at)
+		cr.Status.AtProvider.EncryptValue = secrets.GenerateHash(value)
+		cr.Status.AtProvider.LastUpdate = &t
+	}
+}
+
+func envSecret(m ...envSecretModifier) *v1alpha1.EnvironmentSecret {
+	cr := &v1alpha1.EnvironmentSecret{}
+	cr.SetName("token")
+	meta.SetExternalName(cr, "token")
+	cr.Spec.ForProvider = v1alpha1.EnvironmentSecretParameters{
+		Owner:       "crossplane",
+		Repository:  "provider-github",
+		Environment: "production",
+		ValueSecretRef: xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "token", Namespace: "default"},
+			Key:             "value",
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestEnvSecretObserve(t *testing.T) {
+	resolve := func(_ context.Context, owner, repo string) (int64, *github.Response, error) {
+		if owner != "crossplane" || repo != "provider-github" {
+			t.Errorf("GetRepositoryID(...): unexpected repository %s/%s", owner, repo)
+		}
+		return envRepoID, nil, nil
+	}
+	unresolvable := func(_ context.Context, owner, repo string) (int64, *github.Response, error) {
+		t.Errorf("GetRepositoryID(...): unexpected call for cached repository %s/%s", owner, repo)
+		return 0, nil, errBoom
+	}
+	getSecret := func(want string) func(context.Context, int64, string, string) (*github.Secret, *github.Response, error) {
+		return func(_ context.Context, id int64, env, name string) (*github.Secret, *github.Response, error) {
+			if id != envRepoID || env != "production" || name != want {
+				t.Errorf("GetEnvSecret(...): unexpected secret %d/%s/%s", id, env, name)
+			}
+			return ghSecret(name, secretUpdated), nil, nil
+		}
+	}
+
+	type want struct {
+		o      managed.ExternalObservation
+		repoID int64
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fake.MockService
+		kube   client.Client
+		cr     *v1alpha1.EnvironmentSecret
+		want   want
+	}{
+		"HyphenatedName": {
+			reason: "A resource name with hyphens should not be used as the secret name.",
+			svc:    &fake.MockService{},
+			cr:     envSecret(func(cr *v1alpha1.EnvironmentSecret) { meta.SetExternalName(cr, "my-token") }),
+			want:   want{err: errors.Errorf("secret name %q must not contain hyphens: set spec.forProvider.name or the crossplane.io/external-name annotation to a name with underscores instead", "my-token")},
+		},
+		"RepositoryNotFound": {
+			reason: "The secret of a repository that does not exist should not exist.",
+			svc: &fake.MockService{MockGetRepositoryID: func(_ context.Context, _, _ string) (int64, *github.Response, error) {
+				return 0, nil, notFound()
+			}},
+			cr:   envSecret(),
+			want: want{o: managed.ExternalObservation{}},
+		},
+		"RepositoryIDError": {
+			reason: "Errors resolving the repository ID should be returned.",
+			svc: &fake.MockService{MockGetRepositoryID: func(_ context.Context, _, _ string) (int64, *github.Response, error) {
+				return 0, nil, errBoom
+			}},
+			cr:   envSecret(),
+			want: want{err: errors.Wrap(errBoom, errGetRepositoryID)},
+		},
+		"RepositoryIDResolved": {
+			reason: "The repository ID should be resolved and recorded when it is not yet known.",
+			svc:    &fake.MockService{MockGetRepositoryID: resolve, MockGetEnvSecret: getSecret("TOKEN")},
+			kube:   withValue(secretValue),
+			cr:     envSecret(withEnvWritten(secretValue, secretUpdated)),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, repoID: envRepoID},
+		},
+		"RepositoryIDCached": {
+			reason: "A recorded repository ID should be used without resolving it again.",
+			svc:    &fake.MockService{MockGetRepositoryID: unresolvable, MockGetEnvSecret: getSecret("TOKEN")},
+			kube:   withValue(secretValue),
+			cr:     envSecret(withEnvRepositoryID("crossplane/provider-github", envRepoID), withEnvWritten(secretValue, secretUpdated)),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, repoID: envRepoID},
+		},
+		"RepositoryChanged": {
+			reason: "The repository ID should be resolved again when the repository changes.",
+			svc:    &fake.MockService{MockGetRepositoryID: resolve, MockGetEnvSecret: getSecret("TOKEN")},
+			kube:   withValue(secretValue),
+			cr:     envSecret(withEnvRepositoryID("crossplane/old", 7), withEnvWritten(secretValue, secretUpdated)),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, repoID: envRepoID},
+		},
+		"SecretNotFound": {
+			reason: "A secret GitHub does not know about should not exist.",
+			svc: &fake.MockService{MockGetRepositoryID: resolve, MockGetEnvSecret: func(_ context.Context, _ int64, _, _ string) (*github.Secret, *github.Response, error) {
+				return nil, nil, notFound()
+			}},
+			cr:   envSecret(),
+			want: want{o: managed.ExternalObservation{}, repoID: envRepoID},
+		},
+		"ExplicitName": {
+			reason: "A set name should be used instead of the external name.",
+			svc:    &fake.MockService{MockGetRepositoryID: resolve, MockGetEnvSecret: getSecret("MY_TOKEN")},
+			kube:   withValue(secretValue),
+			cr:     envSecret(withEnvSecretName("my_token"), withEnvWritten(secretValue, secretUpdated)),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, repoID: envRepoID},
+		},
+		"ValueChanged": {
+			reason: "A secret whose referenced value changed should not be up to date.",
+			svc:    &fake.MockService{MockGetRepositoryID: resolve, MockGetEnvSecret: getSecret("TOKEN")},
+			kube:   withValue("changed"),
+			cr:     envSecret(withEnvWritten(secretValue, secretUpdated)),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}, repoID: envRepoID},
+		},
+		"UpdatedElsewhere": {
+			reason: "A secret written since this resource last wrote it should not be up to date.",
+			svc:    &fake.MockService{MockGetRepositoryID: resolve, MockGetEnvSecret: getSecret("TOKEN")},
+			kube:   withValue(secretValue),
+			cr:     envSecret(withEnvWritten(secretValue, secretUpdated.Add(-time.Hour))),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}, repoID: envRepoID},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &envSecretExternal{client: tc.svc, kube: tc.kube, logger: logging.NewNopLogger()}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.repoID, tc.cr.Status.AtProvider.RepositoryID); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want repository ID, +got repository ID:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEnvSecretCreate(t *testing.T) {
+	cr := envSecret(withEnvRepositoryID("crossplane/provider-github", envRepoID))
+	e := &envSecretExternal{
+		client: &fake.MockService{
+			MockGetEnvPublicKey: func(_ context.Context, _ int64, _ string) (*github.PublicKey, *github.Response, error) {
+				return envPublicKey, nil, nil
+			},
+			MockCreateOrUpdateEnvSecret: func(_ context.Context, id int64, env string, s *github.EncryptedSecret) (*github.Response, error) {
+				if id != envRepoID || env != "production" || s.Name != "TOKEN" || s.KeyID != "key" || s.EncryptedValue == "" {
+					t.Errorf("CreateOrUpdateEnvSecret(...): unexpected secret %d/%s: %+v", id, env, s)
+				}
+				return nil, nil
+			},
+			MockGetEnvSecret: func(_ context.Context, _ int64, _, name string) (*github.Secret, *github.Response, error) {
+				return ghSecret(name, secretUpdated), nil, nil
+			},
+		},
+		kube:   withValue(secretValue),
+		logger: logging.NewNopLogger(),
+	}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("Create(...): %v", err)
+	}
+	o := cr.Status.AtProvider
+	if !ghclient.HashMatches(o.EncryptValue, []byte(secretValue)) || o.LastUpdate == nil || !o.LastUpdate.Time.Equal(secretUpdated) {
+		t.Errorf("Create(...): want value hash and update time recorded, got %+v", o)
+	}
+}
@@ -0,0 +1,318 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/secrets/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/secrets"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errNotMultiEnvSecret  = "The managed resource is not a MultiEnvSecret resource"
+	errFmtGetEnvSecret    = "cannot get secret of environment %q"
+	errFmtGetEnvPublicKey = "cannot get public key of environment %q"
+	errFmtCreateEnvSecret = "cannot create or update secret of environment %q"
+	errFmtDeleteEnvSecret = "cannot delete secret of environment %q"
+)
+
+// SetupMultiEnvSecret adds a controller that reconciles MultiEnvSecrets.
+func SetupMultiEnvSecret(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.MultiEnvSecretGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.MultiEnvSecretGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.MultiEnvSecret{}).
+		Complete(reconciler.New(mgr, of, &multiEnvSecretConnector{client: mgr.GetClient(), newServiceFn: secrets.NewService, logger: logger}, logger, recorder))
+}
+
+type multiEnvSecretConnector struct {
+	client       client.Client
+	newServiceFn func(*ghclient.Config) (secrets.Service, error)
+	logger       logging.Logger
+}
+
+func (c *multiEnvSecretConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.MultiEnvSecret)
+	if !ok {
+		return nil, errors.New(errNotMultiEnvSecret)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &multiEnvSecretExternal{svc, c.client, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type multiEnvSecretExternal struct {
+	client secrets.Service
+	kube   client.Client
+	logger logging.Logger
+}
+
+func (e *multiEnvSecretExternal) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.MultiEnvSecret)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMultiEnvSecret)
+	}
+
+	p := cr.Spec.ForProvider
+	name, err := secrets.NormalizeName(p.Name)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	id, err := e.repositoryID(ctx, cr)
+	if ghclient.IsNotFound(err) {
+		e.logger.Debug("Repository does not exist", "repository", p.Repository)
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	value, err := e.value(ctx, p)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	drifted, exists, err := e.drifted(ctx, cr, id, name, value)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if !exists {
+		e.logger.Debug("Secret does not exist in any environment", "name", name)
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.SetConditions(xpv1.Available())
+	upToDate := len(drifted) == 0 && len(secrets.StaleEnvironments(p, cr.Status.AtProvider)) == 0
+	if !upToDate {
+		e.logger.Debug("Secret is not up to date in every environment", "name", name, "drifted", drifted)
+	}
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+func (e *multiEnvSecretExternal) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.MultiEnvSecret)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMultiEnvSecret)
+	}
+
+	return managed.ExternalCreation{}, e.sync(ctx, cr)
+}
+
+func (e *multiEnvSecretExternal) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.MultiEnvSecret)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMultiEnvSecret)
+	}
+
+	return managed.ExternalUpdate{}, e.sync(ctx, cr)
+}
+
+func (e *multiEnvSecretExternal) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.MultiEnvSecret)
+	if !ok {
+		return errors.New(errNotMultiEnvSecret)
+	}
+
+	p := cr.Spec.ForProvider
+	name, err := secrets.NormalizeName(p.Name)
+	if err != nil {
+		return err
+	}
+	id, err := e.repositoryID(ctx, cr)
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	envs := append([]string{}, p.Environments...)
+	for _, env := range append(envs, secrets.StaleEnvironments(p, cr.Status.AtProvider)...) {
+		if err := e.delete(ctx, cr, id, env, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drifted returns the environments in which the secret does not exist or is
+// not up to date, and whether the secret exists in any environment.
+func (e *multiEnvSecretExternal) drifted(ctx context.Context, cr *v1alpha1.MultiEnvSecret, id int64, name, value string) ([]string, bool, error) {
+	var drifted []string
+	exists := false
+	for _, env := range cr.Spec.ForProvider.Environments {
+		s, _, err := e.client.GetEnvSecret(ctx, id, env, name)
+		if ghclient.IsNotFound(err) {
+			drifted = append(drifted, env)
+			continue
+		}
+		if err != nil {
+			return nil, false, ghclient.Wrapf(err, errFmtGetEnvSecret, env)
+		}
+		exists = true
+		if w, ok := secrets.EnvironmentWrite(cr.Status.AtProvider, env); !ok || !secrets.IsEnvSecretWriteUpToDate(w, s, value) {
+			drifted = append(drifted, env)
+		}
+	}
+	return drifted, exists, nil
+}
+
+// sync writes the referenced value to every environment in which the secret
+// does not exist or is not up to date, and deletes the secret from the
+// environments that were removed from the spec.
+func (e *multiEnvSecretExternal) sync(ctx context.Context, cr *v1alpha1.MultiEnvSecret) error {
+	p := cr.Spec.ForProvider
+	name, err := secrets.NormalizeName(p.Name)
+	if err != nil {
+		return err
+	}
+	id, err := e.repositoryID(ctx, cr)
+	if err != nil {
+		return err
+	}
+	value, err := e.value(ctx, p)
+	if err != nil {
+		return err
+	}
+	drifted, _, err := e.drifted(ctx, cr, id, name, value)
+	if err != nil {
+		return err
+	}
+	for _, env := range drifted {
+		if err := e.write(ctx, cr, id, env, name, value); err != nil {
+			return err
+		}
+	}
+	for _, env := range secrets.StaleEnvironments(p, cr.Status.AtProvider) {
+		if err := e.delete(ctx, cr, id, env, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write encrypts the supplied value and writes it to the secret of the
+// supplied environment. It records the hash of the value and the time the
+// secret was written in the observation.
+func (e *multiEnvSecretExternal) write(ctx context.Context, cr *v1alpha1.MultiEnvSecret, id int64, env, name, value string) error {
+	key, _, err := e.client.GetEnvPublicKey(ctx, id, env)
+	if err != nil {
+		return ghclient.Wrapf(err, errFmtGetEnvPublicKey, env)
+	}
+	enc, err := secrets.EncryptSecret(key.GetKey(), value)
+	if err != nil {
+		return err
+	}
+	if _, err := e.client.CreateOrUpdateEnvSecret(ctx, id, env, secrets.GenerateEnvSecret(name, key.GetKeyID(), enc)); err != nil {
+		return ghclient.Wrapf(err, errFmtCreateEnvSecret, env)
+	}
+
+	s, _, err := e.client.GetEnvSecret(ctx, id, env, name)
+	if err != nil {
+		return ghclient.Wrapf(err, errFmtGetEnvSecret, env)
+	}
+	t := metav1.NewTime(s.UpdatedAt.Time)
+	setEnvironmentWrite(&cr.Status.AtProvider, v1alpha1.EnvironmentSecretWrite{
+		Environment:  env,
+		EncryptValue: secrets.GenerateHash(value),
+		LastUpdate:   &t,
+	})
+	return nil
+}
+
+// delete deletes the secret of the supplied environment, and the write
+// recorded for it.
+func (e *multiEnvSecretExternal) delete(ctx context.Context, cr *v1alpha1.MultiEnvSecret, id int64, env, name string) error {
+	_, err := e.client.DeleteEnvSecret(ctx, id, env, name)
+	if err != nil && !ghclient.IsNotFound(err) {
+		return ghclient.Wrapf(err, errFmtDeleteEnvSecret, env)
+	}
+	o := &cr.Status.AtProvider
+	for i, w := range o.Environments {
+		if w.Environment == env {
+			o.Environments = append(o.Environments[:i], o.Environments[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// setEnvironmentWrite records the supplied write in the supplied observation,
+// replacing any write recorded for the same environment.
+func setEnvironmentWrite(o *v1alpha1.MultiEnvSecretObservation, w v1alpha1.EnvironmentSecretWrite) {
+	for i := range o.Environments {
+		if o.Environments[i].Environment == w.Environment {
+			o.Environments[i] = w
+			return
+		}
+	}
+	o.Environments = append(o.Environments, w)
+}
+
+// repositoryID returns the ID of the repository the secret's environments
+// belong to.
+func (e *multiEnvSecretExternal) repositoryID(ctx context.Context, cr *v1alpha1.MultiEnvSecret) (int64, error) {
+	p := cr.Spec.ForProvider
+	o := &cr.Status.AtProvider
+	return repositoryID(ctx, e.client, p.Owner, p.Repository, &o.RepositoryID, &o.Repository)
+}
+
+// value returns the value referenced by the supplied parameters.
+func (e *multiEnvSecretExternal) value(ctx context.Context, p v1alpha1.MultiEnvSecretParameters) (string, error) {
+	ref := p.ValueSecretRef
+	s := &corev1.Secret{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return "", errors.Wrap(err, errGetValueSecret)
+	}
+	v, ok := s.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf(errFmtNoValueKey, ref.Key)
+	}
+	return string(v), nil
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane-contrib/provider-github/apis/secrets/v1alpha1"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/secrets"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/secrets/fake"
+)
+
+type multiEnvSecretModifier func(*v1alpha1.MultiEnvSecret)
+
+func withEnvironments(envs ...string) multiEnvSecretModifier {
+	return func(cr *v1alpha1.MultiEnvSecret) { cr.Spec.ForProvider.Environments = envs }
+}
+
+func withWritten(env, value string, at time.Time) multiEnvSecretModifier {
+	return func(cr *v1alpha1.MultiEnvSecret) {
+		t := metav1.NewTime(at)
+		cr.Status.AtProvider.Environments = append(cr.Status.AtProvider.Environments, v1alpha1.EnvironmentSecretWrite{
+			Environment:  env,
+			EncryptValue: secrets.GenerateHash(value),
+			LastUpdate:   &t,
+		})
+	}
+}
+
+func multiEnvSecret(m ...multiEnvSecretModifier) *v1alpha1.MultiEnvSecret {
+	cr := &v1alpha1.MultiEnvSecret{}
+	cr.SetName("token")
+	cr.Spec.ForProvider = v1alpha1.MultiEnvSecretParameters{
+		Owner:        "crossplane",
+		Repository:   "provider-github",
+		Environments: []string{"staging", "production"},
+		Name:         "deploy_token",
+		ValueSecretRef: xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "token", Namespace: "default"},
+			Key:             "value",
+		},
+	}
+	cr.Status.AtProvider.Repository = "crossplane/provider-github"
+	cr.Status.AtProvider.RepositoryID = envRepoID
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// envSecrets returns a fake GetEnvSecret that returns a secret last updated
+// at the supplied time for each environment in the supplied map, and a 404
+// for any other environment.
+func envSecrets(t *testing.T, updated map[string]time.Time) func(context.Context, int64, string, string) (*github.Secret, *github.Response, error) {
+	return func(_ context.Context, id int64, env, name string) (*github.Secret, *github.Response, error) {
+		if id != envRepoID || name != "DEPLOY_TOKEN" {
+			t.Errorf("GetEnvSecret(...): unexpected secret %d/%s/%s", id, env, name)
+		}
+		at, ok := updated[env]
+		if !ok {
+			return nil, nil, notFound()
+		}
+		return ghSecret(name, at), nil, nil
+	}
+}
+
+func TestMultiEnvSecretObserve(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		updated map[string]time.Time
+		kube    client.Client
+		cr      *v1alpha1.MultiEnvSecret
+		want    managed.ExternalObservation
+	}{
+		"NoEnvironment": {
+			reason:  "A secret that exists in no environment should not exist.",
+			updated: map[string]time.Time{},
+			kube:    withValue(secretValue),
+			cr:      multiEnvSecret(),
+			want:    managed.ExternalObservation{},
+		},
+		"UpToDate": {
+			reason:  "A secret written with the current value to every environment should be up to date.",
+			updated: map[string]time.Time{"staging": secretUpdated, "production": secretUpdated},
+			kube:    withValue(secretValue),
+			cr:      multiEnvSecret(withWritten("staging", secretValue, secretUpdated), withWritten("production", secretValue, secretUpdated)),
+			want:    managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"MissingEnvironment": {
+			reason:  "A secret missing from one environment should not be up to date.",
+			updated: map[string]time.Time{"staging": secretUpdated},
+			kube:    withValue(secretValue),
+			cr:      multiEnvSecret(withWritten("staging", secretValue, secretUpdated)),
+			want:    managed.ExternalObservation{ResourceExists: true},
+		},
+		"UpdatedElsewhere": {
+			reason:  "A secret updated outside this resource in one environment should not be up to date.",
+			updated: map[string]time.Time{"staging": secretUpdated, "production": secretUpdated.Add(time.Hour)},
+			kube:    withValue(secretValue),
+			cr:      multiEnvSecret(withWritten("staging", secretValue, secretUpdated), withWritten("production", secretValue, secretUpdated)),
+			want:    managed.ExternalObservation{ResourceExists: true},
+		},
+		"ValueChanged": {
+			reason:  "A secret whose referenced value changed should not be up to date.",
+			updated: map[string]time.Time{"staging": secretUpdated, "production": secretUpdated},
+			kube:    withValue("changed"),
+			cr:      multiEnvSecret(withWritten("staging", secretValue, secretUpdated), withWritten("production", secretValue, secretUpdated)),
+			want:    managed.ExternalObservation{ResourceExists: true},
+		},
+		"EnvironmentRemoved": {
+			reason:  "A secret still written to an environment removed from the spec should not be up to date.",
+			updated: map[string]time.Time{"staging": secretUpdated},
+			kube:    withValue(secretValue),
+			cr:      multiEnvSecret(withEnvironments("staging"), withWritten("staging", secretValue, secretUpdated), withWritten("production", secretValue, secretUpdated)),
+			want:    managed.ExternalObservation{ResourceExists: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &multiEnvSecretExternal{
+				client: &fake.MockService{MockGetEnvSecret: envSecrets(t, tc.updated)},
+				kube:   tc.kube,
+				logger: logging.NewNopLogger(),
+			}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("\n%s\nObserve(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMultiEnvSecretUpdate(t *testing.T) {
+	written := secretUpdated.Add(time.Hour)
+
+	cases := map[string]struct {
+		reason  string
+		updated map[string]time.Time
+		cr      *v1alpha1.MultiEnvSecret
+		written []string
+		deleted []string
+		want    []string
+	}{
+		"AllEnvironments": {
+			reason:  "A new secret should be written to every environment.",
+			updated: map[string]time.Time{},
+			cr:      multiEnvSecret(),
+			written: []string{"production", "staging"},
+			want:    []string{"production", "staging"},
+		},
+		"DriftedEnvironment": {
+			reason:  "Only the environment that drifted should be written.",
+			updated: map[string]time.Time{"staging": secretUpdated, "production": secretUpdated.Add(-time.Hour)},
+			cr:      multiEnvSecret(withWritten("staging", secretValue, secretUpdated), withWritten("production", secretValue, secretUpdated)),
+			written: []string{"production"},
+			want:    []string{"production", "staging"},
+		},
+		"EnvironmentRemoved": {
+			reason:  "The secret should be deleted from an environment removed from the spec.",
+			updated: map[string]time.Time{"staging": secretUpdated},
+			cr:      multiEnvSecret(withEnvironments("staging"), withWritten("staging", secretValue, secretUpdated), withWritten("production", secretValue, secretUpdated)),
+			deleted: []string{"production"},
+			want:    []string{"staging"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var gotWritten, gotDeleted []string
+			get := envSecrets(t, tc.updated)
+			e := &multiEnvSecretExternal{
+				client: &fake.MockService{
+					MockGetEnvSecret: func(ctx context.Context, id int64, env, name string) (*github.Secret, *github.Response, error) {
+						// Environments written during this update are
+						// last updated at the time they were written.
+						for _, w := range gotWritten {
+							if w == env {
+								return ghSecret(name, written), nil, nil
+							}
+						}
+						return get(ctx, id, env, name)
+					},
+					MockGetEnvPublicKey: func(_ context.Context, _ int64, _ string) (*github.PublicKey, *github.Response, error) {
+						return envPublicKey, nil, nil
+					},
+					MockCreateOrUpdateEnvSecret: func(_ context.Context, _ int64, env string, s *github.EncryptedSecret) (*github.Response, error) {
+						if s.Name != "DEPLOY_TOKEN" || s.EncryptedValue == "" {
+							t.Errorf("CreateOrUpdateEnvSecret(...): unexpected secret %+v", s)
+						}
+						gotWritten = append(gotWritten, env)
+						return nil, nil
+					},
+					MockDeleteEnvSecret: func(_ context.Context, _ int64, env, _ string) (*github.Response, error) {
+						gotDeleted = append(gotDeleted, env)
+						return nil, nil
+					},
+				},
+				kube:   withValue(secretValue),
+				logger: logging.NewNopLogger(),
+			}
+			if _, err := e.Update(context.Background(), tc.cr); err != nil {
+				t.Fatalf("\n%s\nUpdate(...): %v", tc.reason, err)
+			}
+			sort.Strings(gotWritten)
+			if diff := cmp.Diff(tc.written, gotWritten); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want written environments, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.deleted, gotDeleted); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want deleted environments, +got:\n%s\n", tc.reason, diff)
+			}
+			var recorded []string
+			for _, w := range tc.cr.Status.AtProvider.Environments {
+				if !secrets.IsEnvSecretWriteUpToDate(w, ghSecret("DEPLOY_TOKEN", w.LastUpdate.Time), secretValue) {
+					t.Errorf("\n%s\nUpdate(...): write to %q does not record the value", tc.reason, w.Environment)
+				}
+				recorded = append(recorded, w.Environment)
+			}
+			sort.Strings(recorded)
+			if diff := cmp.Diff(tc.want, recorded); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want recorded environments, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMultiEnvSecretDelete(t *testing.T) {
+	var deleted []string
+	cr := multiEnvSecret(withEnvironments("staging"), withWritten("production", secretValue, secretUpdated))
+	e := &multiEnvSecretExternal{
+		client: &fake.MockService{MockDeleteEnvSecret: func(_ context.Context, _ int64, env, _ string) (*github.Response, error) {
+			deleted = append(deleted, env)
+			return nil, notFound()
+		}},
+		logger: logging.NewNopLogger(),
+	}
+	if err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("Delete(...): %v", err)
+	}
+	if diff := cmp.Diff([]string{"staging", "production"}, deleted); diff != "" {
+		t.Errorf("Delete(...): -want deleted environments, +got:\n%s", diff)
+	}
+}
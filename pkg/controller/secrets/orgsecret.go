@@ -0,0 +1,239 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/secrets/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/secrets"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errNotOrgSecret      = "The managed resource is not an OrgSecret resource"
+	errGetOrgSecret      = "cannot get organization secret"
+	errListSelectedRepos = "cannot list repositories selected for organization secret"
+	errGetOrgPublicKey   = "cannot get organization public key"
+	errCreateOrgSecret   = "cannot create or update organization secret"
+	errDeleteOrgSecret   = "cannot delete organization secret"
+	errGetValueSecret    = "cannot get secret containing the value"
+	errFmtNoValueKey     = "secret containing the value has no key %q"
+)
+
+// SetupOrgSecret adds a controller that reconciles OrgSecrets.
+func SetupOrgSecret(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.OrgSecretGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.OrgSecretGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.OrgSecret{}).
+		Complete(reconciler.New(mgr, of, &orgSecretConnector{client: mgr.GetClient(), newServiceFn: secrets.NewService, logger: logger}, logger, recorder,
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient()))))
+}
+
+type orgSecretConnector struct {
+	client       client.Client
+	newServiceFn func(*ghclient.Config) (secrets.Service, error)
+	logger       logging.Logger
+}
+
+func (c *orgSecretConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.OrgSecret)
+	if !ok {
+		return nil, errors.New(errNotOrgSecret)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &orgSecretExternal{svc, c.client, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type orgSecretExternal struct {
+	client secrets.Service
+	kube   client.Client
+	logger logging.Logger
+}
+
+func (e *orgSecretExternal) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.OrgSecret)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOrgSecret)
+	}
+
+	p := cr.Spec.ForProvider
+	name, err := secrets.SecretName(cr.Spec.ForProvider.Name, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	s, _, err := e.client.GetOrgSecret(ctx, p.Organization, name)
+	if ghclient.IsNotFound(err) {
+		e.logger.Debug("Organization secret does not exist", "name", name)
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, ghclient.Wrap(err, errGetOrgSecret)
+	}
+
+	var selected []int64
+	if p.Visibility == secrets.VisibilitySelected && s.Visibility == secrets.VisibilitySelected {
+		var repos []*github.Repository
+		err := ghclient.ListAll(&github.ListOptions{}, func(opts *github.ListOptions) (*github.Response, error) {
+			l, res, err := e.client.ListSelectedReposForOrgSecret(ctx, p.Organization, name, opts)
+			if err != nil {
+				return nil, err
+			}
+			repos = append(repos, l.Repositories...)
+			return res, nil
+		})
+		if err != nil {
+			return managed.ExternalObservation{}, ghclient.Wrap(err, errListSelectedRepos)
+		}
+		selected = secrets.SelectedRepositoryIDs(repos)
+	}
+
+	value, err := e.value(ctx, p)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.SetConditions(xpv1.Available())
+	upToDate := secrets.IsOrgSecretUpToDate(p, cr.Status.AtProvider, s, selected, value)
+	if !upToDate {
+		e.logger.Debug("Organization secret is not up to date", "name", name)
+	}
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+func (e *orgSecretExternal) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.OrgSecret)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOrgSecret)
+	}
+
+	return managed.ExternalCreation{}, e.write(ctx, cr)
+}
+
+func (e *orgSecretExternal) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.OrgSecret)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOrgSecret)
+	}
+
+	return managed.ExternalUpdate{}, e.write(ctx, cr)
+}
+
+func (e *orgSecretExternal) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.OrgSecret)
+	if !ok {
+		return errors.New(errNotOrgSecret)
+	}
+
+	name, err := secrets.SecretName(cr.Spec.ForProvider.Name, meta.GetExternalName(cr))
+	if err != nil {
+		return err
+	}
+	_, err = e.client.DeleteOrgSecret(ctx, cr.Spec.ForProvider.Organization, name)
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	return ghclient.Wrap(err, errDeleteOrgSecret)
+}
+
+// write encrypts the referenced value and writes it, together with the
+// secret's visibility, to GitHub. It records the hash of the value and the
+// time the secret was written in the observation.
+func (e *orgSecretExternal) write(ctx context.Context, cr *v1alpha1.OrgSecret) error {
+	p := cr.Spec.ForProvider
+	name, err := secrets.SecretName(cr.Spec.ForProvider.Name, meta.GetExternalName(cr))
+	if err != nil {
+		return err
+	}
+	value, err := e.value(ctx, p)
+	if err != nil {
+		return err
+	}
+	key, _, err := e.client.GetOrgPublicKey(ctx, p.Organization)
+	if err != nil {
+		return ghclient.Wrap(err, errGetOrgPublicKey)
+	}
+	enc, err := secrets.EncryptSecret(key.GetKey(), value)
+	if err != nil {
+		return err
+	}
+	if _, err := e.client.CreateOrUpdateOrgSecret(ctx, p.Organization, secrets.GenerateOrgSecret(name, p, key.GetKeyID(), enc)); err != nil {
+		return ghclient.Wrap(err, errCreateOrgSecret)
+	}
+
+	s, _, err := e.client.GetOrgSecret(ctx, p.Organization, name)
+	if err != nil {
+		return ghclient.Wrap(err, errGetOrgSecret)
+	}
+	t := metav1.NewTime(s.UpdatedAt.Time)
+	cr.Status.AtProvider = v1alpha1.OrgSecretObservation{
+		EncryptValue: secrets.GenerateHash(value),
+		LastUpdate:   &t,
+	}
+	return nil
+}
+
+// value returns the value referenced by the supplied parameters.
+func (e *orgSecretExternal) value(ctx context.Context, p v1alpha1.OrgSecretParameters) (string, error) {
+	ref := p.ValueSecretRef
+	s := &corev1.Secret{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return "", errors.Wrap(err, errGetValueSecret)
+	}
+	v, ok := s.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf(errFmtNoValueKey, ref.Key)
+	}
+	return string(v), nil
+}
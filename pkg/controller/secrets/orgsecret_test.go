@@ -0,0 +1,362 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/secrets/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/secrets"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/secrets/fake"
+)
+
+type orgSecretModifier func(*v1alpha1.OrgSecret)
+
+func withSelectedRepositories(ids ...int64) orgSecretModifier {
+	return func(cr *v1alpha1.OrgSecret) {
+		cr.Spec.ForProvider.Visibility = secrets.VisibilitySelected
+		cr.Spec.ForProvider.SelectedRepositoryIDs = ids
+	}
+}
+
+func withOrgWritten(value string, at time.Time) orgSecretModifier {
+	return func(cr *v1alpha1.OrgSecret) {
+		t := metav1.NewTime(at)
+		cr.Status.AtProvider.EncryptValue = secrets.GenerateHash(value)
+		cr.Status.AtProvider.LastUpdate = &t
+	}
+}
+
+func orgSecret(m ...orgSecretModifier) *v1alpha1.OrgSecret {
+	cr := &v1alpha1.OrgSecret{}
+	cr.SetName("token")
+	meta.SetExternalName(cr, "token")
+	cr.Spec.ForProvider = v1alpha1.OrgSecretParameters{
+		Organization: "crossplane",
+		Visibility:   "all",
+		ValueSecretRef: xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "token", Namespace: "default"},
+			Key:             "value",
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// orgSecretService returns a Service that serves an organization secret with
+// the supplied visibility, selected for the supplied repositories. Each page
+// of selected repositories holds a single repository.
+func orgSecretService(visibility string, selected ...int64) *fake.MockService {
+	return &fake.MockService{
+		MockGetOrgSecret: func(_ context.Context, org, name string) (*github.Secret, *github.Response, error) {
+			s := ghSecret(name, secretUpdated)
+			s.Visibility = visibility
+			return s, nil, nil
+		},
+		MockListSelectedReposForOrgSecret: func(_ context.Context, _, _ string, opts *github.ListOptions) (*github.SelectedReposList, *github.Response, error) {
+			l := &github.SelectedReposList{TotalCount: github.Int(len(selected))}
+			res := &github.Response{}
+			page := opts.Page
+			if page == 0 {
+				page = 1
+			}
+			if page <= len(selected) {
+				l.Repositories = []*github.Repository{{ID: github.Int64(selected[page-1])}}
+			}
+			if page < len(selected) {
+				res.NextPage = page + 1
+			}
+			return l, res, nil
+		},
+	}
+}
+
+func TestOrgSecretObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fake.MockService
+		kube   client.Client
+		cr     *v1alpha1.OrgSecret
+		want   want
+	}{
+		"HyphenatedName": {
+			reason: "A resource name with hyphens should not be used as the secret name.",
+			svc:    &fake.MockService{},
+			cr:     orgSecret(func(cr *v1alpha1.OrgSecret) { meta.SetExternalName(cr, "my-token") }),
+			want:   want{err: errors.Errorf("secret name %q must not contain hyphens: set spec.forProvider.name or the crossplane.io/external-name annotation to a name with underscores instead", "my-token")},
+		},
+		"NotFound": {
+			reason: "A secret GitHub does not know about should not exist.",
+			svc: &fake.MockService{MockGetOrgSecret: func(_ context.Context, _, _ string) (*github.Secret, *github.Response, error) {
+				return nil, nil, notFound()
+			}},
+			cr:   orgSecret(),
+			want: want{o: managed.ExternalObservation{}},
+		},
+		"GetError": {
+			reason: "Errors getting the secret should be returned.",
+			svc: &fake.MockService{MockGetOrgSecret: func(_ context.Context, _, _ string) (*github.Secret, *github.Response, error) {
+				return nil, nil, errBoom
+			}},
+			cr:   orgSecret(),
+			want: want{err: errors.Wrap(errBoom, errGetOrgSecret)},
+		},
+		"ExplicitName": {
+			reason: "A set name should be used instead of the external name.",
+			svc: &fake.MockService{MockGetOrgSecret: func(_ context.Context, _, name string) (*github.Secret, *github.Response, error) {
+				if name != "MY_TOKEN" {
+					t.Errorf("GetOrgSecret(...): want secret MY_TOKEN, got %s", name)
+				}
+				return nil, nil, notFound()
+			}},
+			cr:   orgSecret(func(cr *v1alpha1.OrgSecret) { cr.Spec.ForProvider.Name = github.String("my_token") }),
+			want: want{o: managed.ExternalObservation{}},
+		},
+		"UpToDate": {
+			reason: "A secret with the written value and visibility should be up to date.",
+			svc:    orgSecretService("all"),
+			kube:   withValue(secretValue),
+			cr:     orgSecret(withOrgWritten(secretValue, secretUpdated)),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"LegacyHash": {
+			reason: "A secret whose value was recorded with a legacy unversioned hash should be up to date.",
+			svc:    orgSecretService("all"),
+			kube:   withValue(secretValue),
+			cr: orgSecret(withOrgWritten(secretValue, secretUpdated), func(cr *v1alpha1.OrgSecret) {
+				cr.Status.AtProvider.EncryptValue = ghclient.Hash([]byte(secretValue))
+			}),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"ValueChanged": {
+			reason: "A secret whose referenced value changed should not be up to date.",
+			svc:    orgSecretService("all"),
+			kube:   withValue("changed"),
+			cr:     orgSecret(withOrgWritten(secretValue, secretUpdated)),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"UpdatedElsewhere": {
+			reason: "A secret written since this resource last wrote it should not be up to date.",
+			svc:    orgSecretService("all"),
+			kube:   withValue(secretValue),
+			cr:     orgSecret(withOrgWritten(secretValue, secretUpdated.Add(-time.Hour))),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"VisibilityChanged": {
+			reason: "A secret with a different visibility should not be up to date.",
+			svc:    orgSecretService("private"),
+			kube:   withValue(secretValue),
+			cr:     orgSecret(withOrgWritten(secretValue, secretUpdated)),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"SelectedRepositories": {
+			reason: "A secret selected for the desired repositories, in any order, should be up to date.",
+			svc:    orgSecretService(secrets.VisibilitySelected, 2, 1),
+			kube:   withValue(secretValue),
+			cr:     orgSecret(withSelectedRepositories(1, 2), withOrgWritten(secretValue, secretUpdated)),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"SelectedRepositoriesPaged": {
+			reason: "A secret selected for more repositories than fit on a page should be up to date.",
+			svc:    orgSecretService(secrets.VisibilitySelected, 1, 2, 3, 4, 5),
+			kube:   withValue(secretValue),
+			cr:     orgSecret(withSelectedRepositories(5, 4, 3, 2, 1), withOrgWritten(secretValue, secretUpdated)),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"SelectedRepositoriesChanged": {
+			reason: "A secret selected for different repositories should not be up to date.",
+			svc:    orgSecretService(secrets.VisibilitySelected, 1),
+			kube:   withValue(secretValue),
+			cr:     orgSecret(withSelectedRepositories(1, 2), withOrgWritten(secretValue, secretUpdated)),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"ListSelectedError": {
+			reason: "Errors listing the selected repositories should be returned.",
+			svc: func() *fake.MockService {
+				svc := orgSecretService(secrets.VisibilitySelected)
+				svc.MockListSelectedReposForOrgSecret = func(_ context.Context, _, _ string, _ *github.ListOptions) (*github.SelectedReposList, *github.Response, error) {
+					return nil, nil, errBoom
+				}
+				return svc
+			}(),
+			cr:   orgSecret(withSelectedRepositories(1)),
+			want: want{err: errors.Wrap(errBoom, errListSelectedRepos)},
+		},
+		"ValueSecretError": {
+			reason: "Errors getting the secret containing the value should be returned.",
+			svc:    orgSecretService("all"),
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			cr:     orgSecret(),
+			want:   want{err: errors.Wrap(errBoom, errGetValueSecret)},
+		},
+		"ValueKeyMissing": {
+			reason: "A secret containing the value that lacks the referenced key should be an error.",
+			svc:    orgSecretService("all"),
+			kube:   withValue(secretValue),
+			cr: orgSecret(func(cr *v1alpha1.OrgSecret) {
+				cr.Spec.ForProvider.ValueSecretRef.Key = "missing"
+			}),
+			want: want{err: errors.Errorf(errFmtNoValueKey, "missing")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &orgSecretExternal{client: tc.svc, kube: tc.kube, logger: logging.NewNopLogger()}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestOrgSecretCreate(t *testing.T) {
+	type want struct {
+		secret  *github.EncryptedSecret
+		written bool
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.OrgSecret
+		keyErr error
+		want   want
+	}{
+		"All": {
+			reason: "A secret visible to all repositories should be written without selected repositories.",
+			cr:     orgSecret(),
+			want:   want{secret: &github.EncryptedSecret{Name: "TOKEN", KeyID: "key", Visibility: "all"}, written: true},
+		},
+		"Selected": {
+			reason: "A secret visible to selected repositories should be written with them.",
+			cr:     orgSecret(withSelectedRepositories(1, 2)),
+			want:   want{secret: &github.EncryptedSecret{Name: "TOKEN", KeyID: "key", Visibility: secrets.VisibilitySelected, SelectedRepositoryIDs: github.SelectedRepoIDs{1, 2}}, written: true},
+		},
+		"PublicKeyError": {
+			reason: "Errors getting the organization's public key should be returned.",
+			cr:     orgSecret(),
+			keyErr: errBoom,
+			want:   want{err: errors.Wrap(errBoom, errGetOrgPublicKey)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *github.EncryptedSecret
+			e := &orgSecretExternal{
+				client: &fake.MockService{
+					MockGetOrgPublicKey: func(_ context.Context, _ string) (*github.PublicKey, *github.Response, error) {
+						return envPublicKey, nil, tc.keyErr
+					},
+					MockCreateOrUpdateOrgSecret: func(_ context.Context, _ string, s *github.EncryptedSecret) (*github.Response, error) {
+						got = s
+						return nil, nil
+					},
+					MockGetOrgSecret: func(_ context.Context, _, name string) (*github.Secret, *github.Response, error) {
+						return ghSecret(name, secretUpdated), nil, nil
+					},
+				},
+				kube:   withValue(secretValue),
+				logger: logging.NewNopLogger(),
+			}
+			_, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if got != nil && got.EncryptedValue == "" {
+				t.Errorf("\n%s\nCreate(...): want an encrypted value", tc.reason)
+			}
+			if got != nil {
+				got.EncryptedValue = ""
+			}
+			if diff := cmp.Diff(tc.want.secret, got); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want secret, +got secret:\n%s\n", tc.reason, diff)
+			}
+			o := tc.cr.Status.AtProvider
+			written := ghclient.HashMatches(o.EncryptValue, []byte(secretValue)) && o.LastUpdate != nil && o.LastUpdate.Time.Equal(secretUpdated)
+			if diff := cmp.Diff(tc.want.written, written); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want value hash and update time recorded, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestOrgSecretDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   error
+	}{
+		"Deleted": {
+			reason: "Deleting a secret should succeed.",
+		},
+		"AlreadyDeleted": {
+			reason: "A secret that was already deleted should be considered deleted.",
+			err:    notFound(),
+		},
+		"Error": {
+			reason: "Errors deleting the secret should be returned.",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errDeleteOrgSecret),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &orgSecretExternal{
+				client: &fake.MockService{MockDeleteOrgSecret: func(_ context.Context, org, name string) (*github.Response, error) {
+					if org != "crossplane" || name != "TOKEN" {
+						t.Errorf("DeleteOrgSecret(...): unexpected secret %s/%s", org, name)
+					}
+					return nil, tc.err
+				}},
+				logger: logging.NewNopLogger(),
+			}
+			err := e.Delete(context.Background(), orgSecret())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
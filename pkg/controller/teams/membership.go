@@ -0,0 +1,170 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teams
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/teams/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/teams"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errNotTeamMembership = "The managed resource is not a TeamMembership resource"
+	errGetMembership     = "cannot get team membership"
+	errAddMembership     = "cannot add team membership"
+	errRemoveMembership  = "cannot remove team membership"
+
+	membershipStateActive = "active"
+)
+
+// SetupTeamMembership adds a controller that reconciles TeamMemberships.
+func SetupTeamMembership(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.TeamMembershipGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.TeamMembershipGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.TeamMembership{}).
+		Complete(reconciler.New(mgr, of, &teamMembershipConnector{client: mgr.GetClient(), newServiceFn: teams.NewMembershipService, logger: logger}, logger, recorder))
+}
+
+type teamMembershipConnector struct {
+	client       client.Client
+	newServiceFn func(*ghclient.Config) (teams.MembershipService, error)
+	logger       logging.Logger
+}
+
+func (c *teamMembershipConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.TeamMembership)
+	if !ok {
+		return nil, errors.New(errNotTeamMembership)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &teamMembershipExternal{svc, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type teamMembershipExternal struct {
+	client teams.MembershipService
+	logger logging.Logger
+}
+
+func (e *teamMembershipExternal) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.TeamMembership)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTeamMembership)
+	}
+
+	p := cr.Spec.ForProvider
+	m, _, err := e.client.GetTeamMembershipBySlug(ctx, p.Organization, p.Team, p.User)
+	if ghclient.IsNotFound(err) {
+		e.logger.Debug("Team membership does not exist", "team", p.Team, "user", p.User)
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, ghclient.Wrap(err, errGetMembership)
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	teams.LateInitializeMembership(&cr.Spec.ForProvider, m)
+	cr.Status.AtProvider = teams.GenerateMembershipObservation(m)
+
+	// A membership stays pending until the user accepts the invitation to
+	// the organization that adding them to the team sends.
+	if m.GetState() == membershipStateActive {
+		cr.SetConditions(xpv1.Available())
+	} else {
+		cr.SetConditions(xpv1.Unavailable())
+	}
+
+	upToDate := teams.IsMembershipUpToDate(cr.Spec.ForProvider, m)
+	if !upToDate {
+		e.logger.Debug("Team membership role differs", "want", cr.Spec.ForProvider.Role, "got", m.GetRole())
+	}
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+	}, nil
+}
+
+func (e *teamMembershipExternal) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.TeamMembership)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTeamMembership)
+	}
+
+	return managed.ExternalCreation{}, e.add(ctx, cr)
+}
+
+func (e *teamMembershipExternal) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.TeamMembership)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTeamMembership)
+	}
+
+	// Adding an existing member updates their role.
+	return managed.ExternalUpdate{}, e.add(ctx, cr)
+}
+
+func (e *teamMembershipExternal) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.TeamMembership)
+	if !ok {
+		return errors.New(errNotTeamMembership)
+	}
+
+	p := cr.Spec.ForProvider
+	_, err := e.client.RemoveTeamMembershipBySlug(ctx, p.Organization, p.Team, p.User)
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	return ghclient.Wrap(err, errRemoveMembership)
+}
+
+func (e *teamMembershipExternal) add(ctx context.Context, cr *v1alpha1.TeamMembership) error {
+	p := cr.Spec.ForProvider
+	_, _, err := e.client.AddTeamMembershipBySlug(ctx, p.Organization, p.Team, p.User, teams.GenerateAddMembershipOptions(p))
+	return ghclient.Wrap(err, errAddMembership)
+}
@@ -0,0 +1,334 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teams
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/teams/v1alpha1"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/teams/fake"
+)
+
+const (
+	memberTeam = "developers"
+	memberUser = "octocat"
+
+	roleMember     = "member"
+	roleMaintainer = "maintainer"
+	statePending   = "pending"
+)
+
+func teamMembership(role *string) *v1alpha1.TeamMembership {
+	cr := &v1alpha1.TeamMembership{}
+	cr.SetName("octocat-developers")
+	cr.Spec.ForProvider = v1alpha1.TeamMembershipParameters{
+		Organization: teamOrg,
+		Team:         memberTeam,
+		User:         memberUser,
+		Role:         role,
+	}
+	return cr
+}
+
+// teamMember returns a MembershipService whose user has the supplied state
+// and role in the team.
+func teamMember(state, role string) *fake.MockMembershipService {
+	return &fake.MockMembershipService{
+		MockGetTeamMembershipBySlug: func(_ context.Context, org, slug, user string) (*github.Membership, *github.Response, error) {
+			if org != teamOrg || slug != memberTeam || user != memberUser {
+				return nil, nil, &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+			}
+			return &github.Membership{State: github.String(state), Role: github.String(role)}, nil, nil
+		},
+	}
+}
+
+func TestTeamMembershipObserve(t *testing.T) {
+	type want struct {
+		o         managed.ExternalObservation
+		cr        *v1alpha1.TeamMembership
+		available bool
+		err       error
+	}
+
+	observed := func(state, role string, specRole *string) *v1alpha1.TeamMembership {
+		cr := teamMembership(specRole)
+		cr.Status.AtProvider = v1alpha1.TeamMembershipObservation{State: github.String(state), Role: github.String(role)}
+		return cr
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fake.MockMembershipService
+		cr     *v1alpha1.TeamMembership
+		want   want
+	}{
+		"NotFound": {
+			reason: "A membership GitHub does not know about should not exist.",
+			svc: &fake.MockMembershipService{MockGetTeamMembershipBySlug: func(_ context.Context, _, _, _ string) (*github.Membership, *github.Response, error) {
+				return nil, nil, &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+			}},
+			cr:   teamMembership(github.String(roleMember)),
+			want: want{o: managed.ExternalObservation{}, cr: teamMembership(github.String(roleMember))},
+		},
+		"GetError": {
+			reason: "Errors getting the membership should be returned.",
+			svc: &fake.MockMembershipService{MockGetTeamMembershipBySlug: func(_ context.Context, _, _, _ string) (*github.Membership, *github.Response, error) {
+				return nil, nil, errBoom
+			}},
+			cr:   teamMembership(github.String(roleMember)),
+			want: want{err: errors.Wrap(errBoom, errGetMembership), cr: teamMembership(github.String(roleMember))},
+		},
+		"Active": {
+			reason: "An active membership with the desired role should be up to date and available.",
+			svc:    teamMember(membershipStateActive, roleMaintainer),
+			cr:     teamMembership(github.String(roleMaintainer)),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				cr:        observed(membershipStateActive, roleMaintainer, github.String(roleMaintainer)),
+				available: true,
+			},
+		},
+		"Pending": {
+			reason: "A pending membership should be observed as pending and not be available.",
+			svc:    teamMember(statePending, roleMember),
+			cr:     teamMembership(github.String(roleMember)),
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				cr: observed(statePending, roleMember, github.String(roleMember)),
+			},
+		},
+		"PromoteToMaintainer": {
+			reason: "A member who should be a maintainer should not be up to date.",
+			svc:    teamMember(membershipStateActive, roleMember),
+			cr:     teamMembership(github.String(roleMaintainer)),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true},
+				cr:        observed(membershipStateActive, roleMember, github.String(roleMaintainer)),
+				available: true,
+			},
+		},
+		"DemoteToMember": {
+			reason: "A maintainer who should be a member should not be up to date.",
+			svc:    teamMember(membershipStateActive, roleMaintainer),
+			cr:     teamMembership(github.String(roleMember)),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true},
+				cr:        observed(membershipStateActive, roleMaintainer, github.String(roleMember)),
+				available: true,
+			},
+		},
+		"PendingRoleChanged": {
+			reason: "A pending membership with a different role should not be up to date.",
+			svc:    teamMember(statePending, roleMember),
+			cr:     teamMembership(github.String(roleMaintainer)),
+			want: want{
+				o:  managed.ExternalObservation{ResourceExists: true},
+				cr: observed(statePending, roleMember, github.String(roleMaintainer)),
+			},
+		},
+		"LateInitialized": {
+			reason: "An unset role should be late initialized from the membership.",
+			svc:    teamMember(membershipStateActive, roleMaintainer),
+			cr:     teamMembership(nil),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				cr:        observed(membershipStateActive, roleMaintainer, github.String(roleMaintainer)),
+				available: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &teamMembershipExternal{client: tc.svc, logger: logging.NewNopLogger()}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr.Spec, tc.cr.Spec); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want spec, +got spec:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr.Status.AtProvider, tc.cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want observation, +got observation:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.err != nil || !o.ResourceExists {
+				return
+			}
+			available := tc.cr.GetCondition(xpv1.TypeReady).Equal(xpv1.Available())
+			if diff := cmp.Diff(tc.want.available, available); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want available, +got available:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// adds returns a MembershipService that records the role each add requests.
+func adds(roles *[]string, err error) *fake.MockMembershipService {
+	return &fake.MockMembershipService{
+		MockAddTeamMembershipBySlug: func(_ context.Context, org, slug, user string, o *github.TeamAddTeamMembershipOptions) (*github.Membership, *github.Response, error) {
+			if org != teamOrg || slug != memberTeam || user != memberUser {
+				return nil, nil, errors.Errorf("unexpected membership %s/%s/%s", org, slug, user)
+			}
+			*roles = append(*roles, o.Role)
+			return &github.Membership{State: github.String(statePending), Role: github.String(o.Role)}, nil, err
+		},
+	}
+}
+
+func TestTeamMembershipCreate(t *testing.T) {
+	type want struct {
+		roles []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.TeamMembership
+		err    error
+		want   want
+	}{
+		"Maintainer": {
+			reason: "A user should be added to the team with the desired role.",
+			cr:     teamMembership(github.String(roleMaintainer)),
+			want:   want{roles: []string{roleMaintainer}},
+		},
+		"DefaultRole": {
+			reason: "A user without a desired role should be added with GitHub's default role.",
+			cr:     teamMembership(nil),
+			want:   want{roles: []string{""}},
+		},
+		"AddError": {
+			reason: "Errors adding the user should be returned.",
+			cr:     teamMembership(github.String(roleMember)),
+			err:    errBoom,
+			want:   want{roles: []string{roleMember}, err: errors.Wrap(errBoom, errAddMembership)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var roles []string
+			e := &teamMembershipExternal{client: adds(&roles, tc.err), logger: logging.NewNopLogger()}
+			_, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.roles, roles); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want roles, +got roles:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTeamMembershipUpdate(t *testing.T) {
+	type want struct {
+		roles []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.TeamMembership
+		err    error
+		want   want
+	}{
+		"PromoteToMaintainer": {
+			reason: "A member should be promoted by adding them again as a maintainer.",
+			cr:     teamMembership(github.String(roleMaintainer)),
+			want:   want{roles: []string{roleMaintainer}},
+		},
+		"DemoteToMember": {
+			reason: "A maintainer should be demoted by adding them again as a member.",
+			cr:     teamMembership(github.String(roleMember)),
+			want:   want{roles: []string{roleMember}},
+		},
+		"UpdateError": {
+			reason: "Errors changing the role should be returned.",
+			cr:     teamMembership(github.String(roleMaintainer)),
+			err:    errBoom,
+			want:   want{roles: []string{roleMaintainer}, err: errors.Wrap(errBoom, errAddMembership)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var roles []string
+			e := &teamMembershipExternal{client: adds(&roles, tc.err), logger: logging.NewNopLogger()}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.roles, roles); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want roles, +got roles:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTeamMembershipDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   error
+	}{
+		"Removed": {
+			reason: "Removing a user from the team should succeed.",
+		},
+		"AlreadyRemoved": {
+			reason: "A user who is no longer in the team should be considered removed.",
+			err:    &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}},
+		},
+		"RemoveError": {
+			reason: "Errors removing the user should be returned.",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errRemoveMembership),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &teamMembershipExternal{
+				client: &fake.MockMembershipService{MockRemoveTeamMembershipBySlug: func(_ context.Context, org, slug, user string) (*github.Response, error) {
+					if org != teamOrg || slug != memberTeam || user != memberUser {
+						t.Errorf("RemoveTeamMembershipBySlug(...): unexpected membership %s/%s/%s", org, slug, user)
+					}
+					return nil, tc.err
+				}},
+				logger: logging.NewNopLogger(),
+			}
+			err := e.Delete(context.Background(), teamMembership(github.String(roleMember)))
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
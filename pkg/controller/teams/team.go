@@ -0,0 +1,214 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teams
+
+import (
+	"context"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-github/apis/teams/v1alpha1"
+	ghclient "github.com/crossplane-contrib/provider-github/pkg/clients"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/teams"
+	"github.com/crossplane-contrib/provider-github/pkg/controller/reconciler"
+)
+
+const (
+	errUnexpectedObject = "The managed resource is not a Team resource"
+	errGetTeam          = "cannot get team"
+	errGetParentTeam    = "cannot get parent team"
+	errCreateTeam       = "cannot create team"
+	errEditTeam         = "cannot edit team"
+	errDeleteTeam       = "cannot delete team"
+)
+
+// SetupTeam adds a controller that reconciles Teams.
+func SetupTeam(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.TeamGroupKind)
+	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	of := resource.ManagedKind(v1alpha1.TeamGroupVersionKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.Team{}).
+		Complete(reconciler.New(mgr, of, &connector{client: mgr.GetClient(), newServiceFn: teams.NewService, logger: logger}, logger, recorder))
+}
+
+type connector struct {
+	client       client.Client
+	newServiceFn func(*ghclient.Config) (teams.Service, error)
+	logger       logging.Logger
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Team)
+	if !ok {
+		return nil, errors.New(errUnexpectedObject)
+	}
+	cfg, err := ghclient.GetConfig(ctx, c.client, cr)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := c.newServiceFn(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{svc, c.logger.WithValues("request", cr.GetName())}, nil
+}
+
+type external struct {
+	client teams.Service
+	logger logging.Logger
+}
+
+func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mgd.(*v1alpha1.Team)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errUnexpectedObject)
+	}
+
+	// Without an external name the team has not been created yet. The
+	// external name is deliberately not defaulted to the resource's name so
+	// that an unrelated team is never adopted by accident.
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	t, _, err := e.getTeam(ctx, cr)
+	if ghclient.IsNotFound(err) {
+		e.logger.Debug("Team does not exist", "slug", meta.GetExternalName(cr))
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, ghclient.Wrap(err, errGetTeam)
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	teams.LateInitialize(&cr.Spec.ForProvider, t)
+
+	// Renaming a team changes its slug. Record the new one as the external
+	// name; it is persisted along with the late initialized spec.
+	renamed := t.GetSlug() != meta.GetExternalName(cr)
+	if renamed {
+		meta.SetExternalName(cr, t.GetSlug())
+	}
+	cr.Status.AtProvider = teams.GenerateObservation(t)
+	cr.SetConditions(xpv1.Available())
+
+	upToDate := teams.IsUpToDate(cr.Spec.ForProvider, t)
+	if !upToDate {
+		e.logger.Debug("Team is not up to date", "slug", t.GetSlug())
+	}
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: renamed || !cmp.Equal(current, &cr.Spec.ForProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mgd.(*v1alpha1.Team)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errUnexpectedObject)
+	}
+
+	p := cr.Spec.ForProvider
+	parentID, err := e.parentTeamID(ctx, p)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	t, _, err := e.client.CreateTeam(ctx, p.Organization, teams.GenerateNewTeam(p, parentID))
+	if err != nil {
+		return managed.ExternalCreation{}, ghclient.Wrap(err, errCreateTeam)
+	}
+
+	// GitHub derives the slug from the team's name, which may differ from
+	// the external name we looked the team up by.
+	meta.SetExternalName(cr, t.GetSlug())
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mgd.(*v1alpha1.Team)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errUnexpectedObject)
+	}
+
+	p := cr.Spec.ForProvider
+	parentID, err := e.parentTeamID(ctx, p)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	removeParent := p.ParentTeam != nil && *p.ParentTeam == ""
+	_, _, err = e.client.EditTeamBySlug(ctx, p.Organization, meta.GetExternalName(cr), teams.GenerateNewTeam(p, parentID), removeParent)
+	return managed.ExternalUpdate{}, ghclient.Wrap(err, errEditTeam)
+}
+
+func (e *external) Delete(ctx context.Context, mgd resource.Managed) error {
+	cr, ok := mgd.(*v1alpha1.Team)
+	if !ok {
+		return errors.New(errUnexpectedObject)
+	}
+
+	_, err := e.client.DeleteTeamBySlug(ctx, cr.Spec.ForProvider.Organization, meta.GetExternalName(cr))
+	if ghclient.IsNotFound(err) {
+		return nil
+	}
+	return ghclient.Wrap(err, errDeleteTeam)
+}
+
+// getTeam gets the team by its ID once it has been observed, so that it is
+// still found after a rename changed its slug. Before that it gets the team
+// by the slug in its external name.
+func (e *external) getTeam(ctx context.Context, cr *v1alpha1.Team) (*github.Team, *github.Response, error) {
+	o := cr.Status.AtProvider
+	if o.ID != nil && o.OrganizationID != nil {
+		return e.client.GetTeamByID(ctx, *o.OrganizationID, *o.ID)
+	}
+	return e.client.GetTeamBySlug(ctx, cr.Spec.ForProvider.Organization, meta.GetExternalName(cr))
+}
+
+// parentTeamID resolves the ID of the configured parent team. It returns nil
+// when no parent team is configured.
+func (e *external) parentTeamID(ctx context.Context, p v1alpha1.TeamParameters) (*int64, error) {
+	if p.ParentTeam == nil || *p.ParentTeam == "" {
+		return nil, nil
+	}
+	t, _, err := e.client.GetTeamBySlug(ctx, p.Organization, *p.ParentTeam)
+	if err != nil {
+		return nil, ghclient.Wrap(err, errGetParentTeam)
+	}
+	return t.ID, nil
+}
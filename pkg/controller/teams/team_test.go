@@ -0,0 +1,212 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teams
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v33/github"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-github/apis/teams/v1alpha1"
+	"github.com/crossplane-contrib/provider-github/pkg/clients/teams/fake"
+)
+
+var errBoom = errors.New("boom")
+
+const (
+	teamOrg   = "crossplane"
+	teamOrgID = int64(7)
+	teamID    = int64(42)
+)
+
+type teamModifier func(*v1alpha1.Team)
+
+func withTeamExternalName(n string) teamModifier {
+	return func(cr *v1alpha1.Team) { meta.SetExternalName(cr, n) }
+}
+
+func withTeamName(n string) teamModifier {
+	return func(cr *v1alpha1.Team) { cr.Spec.ForProvider.Name = n }
+}
+
+func withTeamObserved() teamModifier {
+	return func(cr *v1alpha1.Team) {
+		cr.Status.AtProvider.ID = github.Int64(teamID)
+		cr.Status.AtProvider.OrganizationID = github.Int64(teamOrgID)
+	}
+}
+
+func team(m ...teamModifier) *v1alpha1.Team {
+	cr := &v1alpha1.Team{}
+	cr.SetName("developers")
+	cr.Spec.ForProvider = v1alpha1.TeamParameters{
+		Organization: teamOrg,
+		Name:         "Developers",
+		Description:  github.String("Writes code"),
+		Privacy:      github.String("closed"),
+		ParentTeam:   github.String(""),
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func ghTeam(name, slug string) *github.Team {
+	return &github.Team{
+		ID:           github.Int64(teamID),
+		Name:         github.String(name),
+		Slug:         github.String(slug),
+		Description:  github.String("Writes code"),
+		Privacy:      github.String("closed"),
+		Organization: &github.Organization{ID: github.Int64(teamOrgID)},
+	}
+}
+
+func TestTeamObserve(t *testing.T) {
+	bySlug := func(tm *github.Team) func(context.Context, string, string) (*github.Team, *github.Response, error) {
+		return func(_ context.Context, _, _ string) (*github.Team, *github.Response, error) {
+			if tm == nil {
+				return nil, nil, &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+			}
+			return tm, nil, nil
+		}
+	}
+
+	type want struct {
+		o    managed.ExternalObservation
+		name string
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		svc    *fake.MockService
+		cr     *v1alpha1.Team
+		want   want
+	}{
+		"NoExternalName": {
+			reason: "A team without an external name should be created rather than adopted by its resource's name.",
+			svc:    &fake.MockService{},
+			cr:     team(),
+			want:   want{o: managed.ExternalObservation{}},
+		},
+		"NotFound": {
+			reason: "A team GitHub does not know about should not exist.",
+			svc:    &fake.MockService{MockGetTeamBySlug: bySlug(nil)},
+			cr:     team(withTeamExternalName("developers")),
+			want:   want{o: managed.ExternalObservation{}, name: "developers"},
+		},
+		"GetError": {
+			reason: "Errors getting the team should be returned.",
+			svc: &fake.MockService{MockGetTeamBySlug: func(_ context.Context, _, _ string) (*github.Team, *github.Response, error) {
+				return nil, nil, errBoom
+			}},
+			cr:   team(withTeamExternalName("developers")),
+			want: want{name: "developers", err: errors.Wrap(errBoom, errGetTeam)},
+		},
+		"Adopted": {
+			reason: "A team named by an explicit external name should be adopted.",
+			svc:    &fake.MockService{MockGetTeamBySlug: bySlug(ghTeam("Developers", "developers"))},
+			cr:     team(withTeamExternalName("developers")),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, name: "developers"},
+		},
+		"NameChanged": {
+			reason: "A team whose name differs from the desired one should not be up to date.",
+			svc:    &fake.MockService{MockGetTeamBySlug: bySlug(ghTeam("Developers", "developers"))},
+			cr:     team(withTeamExternalName("developers"), withTeamName("Engineers")),
+			want:   want{o: managed.ExternalObservation{ResourceExists: true}, name: "developers"},
+		},
+		"Renamed": {
+			reason: "A renamed team should be found by its ID, and its new slug recorded as the external name.",
+			svc: &fake.MockService{MockGetTeamByID: func(_ context.Context, orgID, id int64) (*github.Team, *github.Response, error) {
+				if orgID != teamOrgID || id != teamID {
+					t.Errorf("GetTeamByID(...): want %d/%d, got %d/%d", teamOrgID, teamID, orgID, id)
+				}
+				return ghTeam("Engineers", "engineers"), nil, nil
+			}},
+			cr:   team(withTeamExternalName("developers"), withTeamName("Engineers"), withTeamObserved()),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true}, name: "engineers"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.svc, logger: logging.NewNopLogger()}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.name, meta.GetExternalName(tc.cr)); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTeamCreate(t *testing.T) {
+	cr := team()
+	e := &external{
+		client: &fake.MockService{MockCreateTeam: func(_ context.Context, _ string, nt github.NewTeam) (*github.Team, *github.Response, error) {
+			if nt.Name != "Developers" || nt.ParentTeamID != nil {
+				t.Errorf("CreateTeam(...): unexpected team %+v", nt)
+			}
+			return ghTeam("Developers", "developers"), nil, nil
+		}},
+		logger: logging.NewNopLogger(),
+	}
+	c, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Create(...): %v", err)
+	}
+	if !c.ExternalNameAssigned || meta.GetExternalName(cr) != "developers" {
+		t.Errorf("Create(...): want external name developers assigned, got %q", meta.GetExternalName(cr))
+	}
+}
+
+func TestTeamUpdate(t *testing.T) {
+	cr := team(withTeamExternalName("developers"), withTeamName("Engineers"))
+	e := &external{
+		client: &fake.MockService{MockEditTeamBySlug: func(_ context.Context, _, slug string, nt github.NewTeam, removeParent bool) (*github.Team, *github.Response, error) {
+			if slug != "developers" || nt.Name != "Engineers" || !removeParent {
+				t.Errorf("EditTeamBySlug(...): unexpected edit of %q: %+v, removeParent %t", slug, nt, removeParent)
+			}
+			return ghTeam("Engineers", "engineers"), nil, nil
+		}},
+		logger: logging.NewNopLogger(),
+	}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update(...): %v", err)
+	}
+	// The new slug is recorded by the next Observe, which finds the team by
+	// its ID.
+	if meta.GetExternalName(cr) != "developers" {
+		t.Errorf("Update(...): want external name unchanged, got %q", meta.GetExternalName(cr))
+	}
+}